@@ -1,48 +1,116 @@
 package custommiddleware
 
 import (
-	"fmt"
-	"net/http"
-	"os"
+	"context"
+	"strings"
+
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
+	"go-todo-app-clean-arch/entity"
 	"go-todo-app-clean-arch/pkg/logger"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/usecase"
+	"go-todo-app-clean-arch/usecase/apperror"
 )
 
-func JWTMiddleware() echo.MiddlewareFunc {
+// SessionValidator は JWT の jti（セッション ID）が失効していないかを確認する
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, userID int, sessionID string) error
+}
+
+// AccessTokenAuthenticator は Bearer トークンとして送られたパーソナルアクセストークンを検証する
+type AccessTokenAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*entity.PersonalAccessToken, error)
+}
+
+// JWTMiddleware はアクセストークン（JWT）を keys の kid の鍵で検証し、セッションが有効かを sessions で確認する
+// アクセストークンは auth_token Cookie か Authorization: Bearer ヘッダーで受け取る。ヘッダーがある場合は Cookie を見ない
+// Bearer トークンが todo_pat_ で始まる場合は、パーソナルアクセストークンとして accessTokens で検証する
+// どの場合も、認証した主体（usecase.Principal）をリクエストの context.Context に保存する
+func JWTMiddleware(keys *security.KeyRing, sessions SessionValidator, accessTokens AccessTokenAuthenticator) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			if bearer, ok := bearerToken(c); ok {
+				if strings.HasPrefix(bearer, usecase.AccessTokenPrefix) {
+					accessToken, err := accessTokens.Authenticate(c.Request().Context(), bearer)
+					if err != nil {
+						c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+						return err
+					}
+					setPrincipal(c, &usecase.Principal{
+						UserID: accessToken.UserID,
+						Scopes: accessToken.ScopeList(),
+						Method: usecase.AuthMethodAccessToken,
+					})
+					return next(c)
+				}
+				principal, err := authenticateJWT(c.Request().Context(), bearer, keys, sessions)
+				if err != nil {
+					c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+					return err
+				}
+				principal.Method = usecase.AuthMethodBearer
+				setPrincipal(c, principal)
+				return next(c)
+			}
+
 			// Cookieから"auth_token"を取得
 			// クライアントから送信されたリクエスト内のCookieを調べ、"auth_token"を取得する。
-			cookie, err := c.Cookie("auth_token")
+			cookie, err := c.Cookie(accessTokenCookie)
+			if err != nil {
+				return apperror.NewUnauthorized("Missing auth_token cookie")
+			}
+			principal, err := authenticateJWT(c.Request().Context(), cookie.Value, keys, sessions)
 			if err != nil {
-				return c.JSON(http.StatusUnauthorized, echo.Map{"message": "Missing auth_token cookie"})
+				return err
 			}
+			principal.Method = usecase.AuthMethodCookie
+			setPrincipal(c, principal)
+			return next(c)
+		}
+	}
+}
 
-			// JWTトークンを解析して署名を検証
-			// トークンの署名が正しいかどうかを確認し、有効性を検証する。
-			token, err := jwt.Parse(cookie.Value, func(token *jwt.Token) (interface{}, error) {
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-				}
-				return []byte(os.Getenv("SECRET")), nil
-			})
+// authenticateJWT はアクセストークンを検証し、セッションの主体を返す
+func authenticateJWT(ctx context.Context, tokenString string, keys *security.KeyRing, sessions SessionValidator) (*usecase.Principal, error) {
+	// JWTトークンを解析して署名を検証
+	// トークンの署名が正しいかどうかを確認し、有効性を検証する。
+	token, err := jwt.Parse(tokenString, keys.Keyfunc)
 
-			if err != nil || !token.Valid {
-				return c.JSON(http.StatusUnauthorized, echo.Map{"message": "Invalid token"})
-			}
+	if err != nil || !token.Valid {
+		return nil, apperror.NewUnauthorized("Invalid token")
+	}
 
-			// トークンのClaimsを型変換し、正しい形式（jwt.MapClaims）であることを確認
-			if claims, ok := token.Claims.(jwt.MapClaims); ok {
-				logger.Info("Parsed JWT Token Claims: " + fmt.Sprintf("%v", claims))
-				// 後続の処理で利用できるようにトークン全体をコンテキストに保存
-				c.Set("user", token)
-			} else {
-				logger.Error("Invalid token claims")
-				return c.JSON(http.StatusUnauthorized, "Invalid Claims")
-			}
+	// トークンのClaimsを型変換し、正しい形式（jwt.MapClaims）であることを確認
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		logger.Error("Invalid token claims")
+		return nil, apperror.NewUnauthorized("Invalid Claims")
+	}
 
-			return next(c)
-		}
+	// ログアウトや他の端末からの失効で、有効期限内でも使えなくなったトークンを拒否する
+	// jti のないトークンはセッション管理の導入前に発行されたもので、ログインし直してもらう
+	userID, ok := claims["user_id"].(float64)
+	sessionID, hasSession := claims["jti"].(string)
+	if !ok || !hasSession {
+		return nil, apperror.NewUnauthorized("Invalid Claims")
+	}
+	if err := sessions.ValidateSession(ctx, int(userID), sessionID); err != nil {
+		return nil, err
+	}
+	return &usecase.Principal{UserID: int(userID), SessionID: sessionID}, nil
+}
+
+// setPrincipal は後続のハンドラーとユースケースが使えるよう、主体をリクエストの context.Context に保存する
+func setPrincipal(c echo.Context, principal *usecase.Principal) {
+	req := c.Request()
+	c.SetRequest(req.WithContext(usecase.WithPrincipal(req.Context(), principal)))
+}
+
+func bearerToken(c echo.Context) (string, bool) {
+	scheme, token, found := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
 	}
+	return strings.TrimSpace(token), true
 }
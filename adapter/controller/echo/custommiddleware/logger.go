@@ -38,6 +38,7 @@ func CustomRequestLogger() echo.MiddlewareFunc {
 				zap.String("latency", stop.Sub(start).String()),
 				zap.String("client_ip", c.RealIP()),
 				zap.String("user_agent", c.Request().UserAgent()),
+				zap.String("request_id", c.Response().Header().Get(echo.HeaderXRequestID)),
 			)
 
 			// カラフルな出力
@@ -64,7 +65,8 @@ func CustomRecovery() echo.MiddlewareFunc {
 					logger.ZapLogger.Error("Panic recovered",
 						zap.Any("error", r),
 					)
-					c.Error(err)
+					// HTTPErrorHandler で 500 の problem+json を返す
+					c.Error(fmt.Errorf("panic recovered: %v", r))
 				}
 			}()
 			return next(c)
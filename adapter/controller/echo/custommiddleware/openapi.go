@@ -0,0 +1,245 @@
+package custommiddleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/labstack/echo/v4"
+
+	"go-todo-app-clean-arch/pkg/logger"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// OpenAPI の servers はホスト付きで書かれているため、検証時はパスの接頭辞だけで照合する
+const openAPIBasePath = "/api/v1"
+
+func init() {
+	// kin-openapi は既定で email 形式を検証しないため有効にする
+	openapi3.DefineStringFormatValidator("email", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForEmail))
+	// 2 要素認証の QR コードの画像を返すため、画像のレスポンスも文字列として読めるようにする
+	openapi3filter.RegisterBodyDecoder("image/png", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("image/svg+xml", openapi3filter.FileBodyDecoder)
+}
+
+type OpenAPIValidatorConfig struct {
+	// ValidateResponses を有効にすると、レスポンスも仕様と照合し、食い違えば 500 を返す
+	// 実装と openapi.yaml のずれを検出するための開発・テスト用の設定
+	ValidateResponses bool
+}
+
+// OpenAPIValidator はリクエストを openapi.yaml の定義と照合する
+// 仕様に載っていないパスは検証せずに通す
+// CSRF トークンと JWT はそれぞれのミドルウェアで検証するため、ここでは security を見ない
+func OpenAPIValidator(swagger *openapi3.T, config OpenAPIValidatorConfig) (echo.MiddlewareFunc, error) {
+	swagger.Servers = openapi3.Servers{{URL: openAPIBasePath}}
+	router, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &openapi3filter.Options{
+		MultiError:          true,
+		AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		SkipSettingDefaults: true,
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			route, pathParams, err := router.FindRoute(req)
+			if err != nil {
+				if errors.Is(err, routers.ErrPathNotFound) || errors.Is(err, routers.ErrMethodNotAllowed) {
+					return next(c)
+				}
+				return err
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(req.Context(), input); err != nil {
+				return requestValidationError(err)
+			}
+
+			if !config.ValidateResponses {
+				return next(c)
+			}
+			return validateResponse(c, next, input)
+		}
+	}, nil
+}
+
+// requestValidationError は検証エラーを項目ごとの FieldError にまとめる
+// JSON として読めない・型が違うなど形式の誤りは 400、値が仕様に反するものは 422 にする
+func requestValidationError(err error) error {
+	var fields []apperror.FieldError
+	malformed := false
+	for _, e := range flattenErrors(err) {
+		var requestErr *openapi3filter.RequestError
+		if !errors.As(e, &requestErr) {
+			malformed = true
+			fields = append(fields, apperror.FieldError{Detail: e.Error()})
+			continue
+		}
+
+		schemaErrs := schemaErrors(requestErr.Err)
+		if len(schemaErrs) == 0 {
+			malformed = true
+			fields = append(fields, requestErrorField(requestErr, "", requestErrorDetail(requestErr)))
+			continue
+		}
+		for _, schemaErr := range schemaErrs {
+			fields = append(fields, requestErrorField(requestErr, jsonPointer(schemaErr.JSONPointer()), schemaErr.Reason))
+		}
+	}
+
+	if malformed {
+		return &echo.HTTPError{
+			Code:     http.StatusBadRequest,
+			Message:  "request is malformed",
+			Internal: apperror.NewValidation("request is malformed", fields...),
+		}
+	}
+	return apperror.NewValidation("request does not match the API schema", fields...)
+}
+
+func requestErrorField(err *openapi3filter.RequestError, pointer, detail string) apperror.FieldError {
+	if err.Parameter != nil {
+		return apperror.ParamField(err.Parameter.Name, detail)
+	}
+	if pointer == "" {
+		pointer = "/"
+	}
+	return apperror.BodyField(pointer, detail)
+}
+
+func requestErrorDetail(err *openapi3filter.RequestError) string {
+	if errors.Is(err.Err, openapi3filter.ErrInvalidRequired) {
+		if err.Parameter != nil {
+			return "parameter is required"
+		}
+		return "request body is required"
+	}
+	if err.Err != nil && err.Reason != "" {
+		return err.Reason + ": " + err.Err.Error()
+	}
+	if err.Err != nil {
+		return err.Err.Error()
+	}
+	return err.Reason
+}
+
+// MultiError を入れ子も含めて平らにする
+// errors.As だと RequestError の中の MultiError まで開いてしまうため型で判定する
+func flattenErrors(err error) []error {
+	multi, ok := err.(openapi3.MultiError)
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range multi {
+		errs = append(errs, flattenErrors(e)...)
+	}
+	return errs
+}
+
+func schemaErrors(err error) []*openapi3.SchemaError {
+	if err == nil {
+		return nil
+	}
+	var schemaErrs []*openapi3.SchemaError
+	for _, e := range flattenErrors(err) {
+		var schemaErr *openapi3.SchemaError
+		if !errors.As(e, &schemaErr) {
+			return nil
+		}
+		schemaErrs = append(schemaErrs, schemaErr)
+	}
+	return schemaErrs
+}
+
+// RFC 6901 の JSON Pointer に変換する
+func jsonPointer(path []string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	replacer := strings.NewReplacer("~", "~0", "/", "~1")
+	var b strings.Builder
+	for _, p := range path {
+		b.WriteString("/")
+		b.WriteString(replacer.Replace(p))
+	}
+	return b.String()
+}
+
+// responseRecorder はレスポンスを検証し終えるまで書き込みを保留する
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(b)
+}
+
+func validateResponse(c echo.Context, next echo.HandlerFunc, input *openapi3filter.RequestValidationInput) error {
+	res := c.Response()
+	writer := res.Writer
+	recorder := &responseRecorder{ResponseWriter: writer}
+	res.Writer = recorder
+
+	// エラーレスポンスも検証するため、ここでエラーハンドラを呼んで書き込ませる
+	if err := next(c); err != nil {
+		c.Error(err)
+	}
+	res.Writer = writer
+
+	if recorder.status == 0 {
+		return nil
+	}
+
+	err := openapi3filter.ValidateResponse(c.Request().Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 recorder.status,
+		Header:                 res.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+		Options:                input.Options,
+	})
+	if err != nil {
+		logger.Error("response does not match the API schema: "+err.Error(),
+			"method", c.Request().Method, "path", c.Request().URL.Path, "status", recorder.status)
+
+		// 保留していたレスポンスは捨て、代わりに 500 を返す
+		res.Committed = false
+		res.Status = http.StatusOK
+		res.Size = 0
+		res.Header().Del(echo.HeaderContentType)
+		res.Header().Del(echo.HeaderContentLength)
+		return fmt.Errorf("response does not match the API schema: %w", err)
+	}
+
+	writer.WriteHeader(recorder.status)
+	_, err = writer.Write(recorder.body.Bytes())
+	return err
+}
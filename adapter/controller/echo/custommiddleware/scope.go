@@ -0,0 +1,24 @@
+package custommiddleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"go-todo-app-clean-arch/usecase"
+)
+
+// SessionOnly はパーソナルアクセストークンでのリクエストを拒否する
+// アカウントやトークン自体の管理など、ログインしたブラウザからだけ許す操作に使う
+// スコープで許可する操作は、ユースケースが context.Context の主体で確かめる
+// JWTMiddleware の後に使う
+func SessionOnly(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		principal, ok := usecase.PrincipalFromContext(c.Request().Context())
+		if !ok {
+			return usecase.ErrUnauthenticated
+		}
+		if !principal.IsSession() {
+			return usecase.ErrSessionRequired
+		}
+		return next(c)
+	}
+}
@@ -2,12 +2,14 @@ package custommiddleware
 
 import (
 	"context"
-	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
+// TimeoutMiddleware はリクエストの context に期限を設定する
+// ハンドラは同じ goroutine で実行し、期限を過ぎたら db.WithContext に渡した context から問い合わせを中断させる
+// 中断したリクエストは HTTPErrorHandler が 503 にする
 func TimeoutMiddleware(duration time.Duration) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -15,20 +17,7 @@ func TimeoutMiddleware(duration time.Duration) echo.MiddlewareFunc {
 			defer cancel()
 
 			c.SetRequest(c.Request().WithContext(ctx))
-
-			errChan := make(chan error, 1)
-			go func() {
-				errChan <- next(c)
-			}()
-
-			select {
-			case err := <-errChan:
-				return err
-			case <-ctx.Done():
-				return c.JSON(http.StatusRequestTimeout, map[string]string{
-					"message": "timeout",
-				})
-			}
+			return next(c)
 		}
 	}
 }
@@ -0,0 +1,60 @@
+package custommiddleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/adapter/controller/echo/custommiddleware"
+	"go-todo-app-clean-arch/adapter/controller/echo/handler"
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/pkg/tester"
+)
+
+// 期限を過ぎると実行中の問い合わせを中断し、結果を待たずに 503 を返す
+func TestTimeoutMiddlewareCancelsSlowQuery(t *testing.T) {
+	mockDB, db := tester.MockDB()
+	mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tasks` WHERE user_id = ? AND id = ? ORDER BY `tasks`.`id` LIMIT ?")).
+		WithArgs(1, 1, 1).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "user_id"}).AddRow(1, "Slow", 1))
+	repository := gateway.NewTaskRepository(db)
+
+	e := echo.New()
+	e.HTTPErrorHandler = handler.HTTPErrorHandler
+	e.Use(custommiddleware.TimeoutMiddleware(10 * time.Millisecond))
+	e.GET("/tasks/1", func(c echo.Context) error {
+		task, err := repository.Get(c.Request().Context(), 1, 1)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, task)
+	})
+
+	start := time.Now()
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks/1", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+// 期限内に終わったリクエストはそのまま返す
+func TestTimeoutMiddlewarePassesThrough(t *testing.T) {
+	e := echo.New()
+	e.Use(custommiddleware.TimeoutMiddleware(time.Second))
+	e.GET("/", func(c echo.Context) error {
+		_, ok := c.Request().Context().Deadline()
+		assert.True(t, ok)
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
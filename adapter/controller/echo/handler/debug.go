@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DBStatsProvider はコネクションプールの統計を返す。*sql.DB が満たす
+type DBStatsProvider interface {
+	Stats() sql.DBStats
+}
+
+type DebugHandler struct {
+	db DBStatsProvider
+}
+
+func NewDebugHandler(db DBStatsProvider) *DebugHandler {
+	return &DebugHandler{db: db}
+}
+
+type dbStatsResponse struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`
+}
+
+// DBStats はコネクションプールの統計を返す
+// wait_count・wait_duration_ms が増え続ける場合は max_open_conns が足りていない
+func (h *DebugHandler) DBStats(c echo.Context) error {
+	stats := h.db.Stats()
+	return c.JSON(http.StatusOK, &dbStatsResponse{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	})
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"go-todo-app-clean-arch/adapter/controller/echo/presenter"
+	"go-todo-app-clean-arch/usecase"
+)
+
+type EmailVerificationHandler struct {
+	emailVerificationUseCase usecase.EmailVerificationUseCase
+}
+
+func NewEmailVerificationHandler(emailVerificationUseCase usecase.EmailVerificationUseCase) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		emailVerificationUseCase: emailVerificationUseCase,
+	}
+}
+
+// Verify は確認メールのリンクから直接開かれる
+func (h *EmailVerificationHandler) Verify(c echo.Context) error {
+	if err := h.emailVerificationUseCase.Verify(c.Request().Context(), c.QueryParam("token")); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "email address has been verified"})
+}
+
+// Resend は確認メールを送り直す。アカウントの有無にかかわらず同じレスポンスを返す
+func (h *EmailVerificationHandler) Resend(c echo.Context) error {
+	var requestBody presenter.ResendVerificationEmailJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return err
+	}
+
+	if err := h.emailVerificationUseCase.Resend(c.Request().Context(), string(requestBody.Email)); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, map[string]string{"message": "if the account exists and is not verified, a verification email has been sent"})
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"go-todo-app-clean-arch/adapter/controller/echo/presenter"
+	"go-todo-app-clean-arch/pkg/logger"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+var statusByKind = map[apperror.Kind]int{
+	apperror.KindNotFound:     http.StatusNotFound,
+	apperror.KindConflict:     http.StatusConflict,
+	apperror.KindValidation:   http.StatusUnprocessableEntity,
+	apperror.KindUnauthorized: http.StatusUnauthorized,
+	apperror.KindForbidden:    http.StatusForbidden,
+	// TooManyRequests は Retry-After ヘッダーも付ける
+	apperror.KindTooManyRequests: http.StatusTooManyRequests,
+}
+
+// HTTPErrorHandler はハンドラ・ミドルウェアが返したエラーを RFC 7807 の problem+json に変換する
+// apperror は種類に応じたステータス、echo.HTTPError はそのステータスで返し、
+// タイムアウトやクライアントの切断で中断した場合は 503、それ以外は内部のエラー内容を隠して 500 にする
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	problem := errorToProblem(err)
+	// 中断したクエリのエラーはドライバごとに異なるため、リクエストの context が終わっているかでも判定する
+	ctxErr := c.Request().Context().Err()
+	if ctxErr != nil && problem.Status == http.StatusInternalServerError {
+		problem = newProblem(http.StatusServiceUnavailable, "")
+	}
+	problem.Instance = stringPtr(c.Request().URL.Path)
+	requestId := c.Response().Header().Get(echo.HeaderXRequestID)
+	if requestId != "" {
+		problem.RequestId = stringPtr(requestId)
+	}
+
+	if retryAfter := apperror.RetryAfterOf(err); problem.Status == http.StatusTooManyRequests && retryAfter > 0 {
+		// 秒単位で切り上げ、0 秒と伝えないようにする
+		c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+
+	// クライアントが切断した場合はサーバーの障害ではないため、エラーとして記録しない
+	if problem.Status >= http.StatusInternalServerError && !errors.Is(ctxErr, context.Canceled) {
+		logger.Error(err.Error(), "method", c.Request().Method, "path", c.Request().URL.Path, "request_id", requestId)
+	} else {
+		logger.Warn(err.Error(), "status", problem.Status, "request_id", requestId)
+	}
+
+	var writeErr error
+	if c.Request().Method == http.MethodHead {
+		writeErr = c.NoContent(problem.Status)
+	} else {
+		c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+		writeErr = c.JSON(problem.Status, problem)
+	}
+	if writeErr != nil {
+		logger.Error(writeErr.Error())
+	}
+}
+
+// badRequest はリクエストの形式誤り（JSON の構文エラーやパラメータの型違いなど）を 400 で返す
+func badRequest(detail string, fields ...apperror.FieldError) error {
+	return &echo.HTTPError{
+		Code:     http.StatusBadRequest,
+		Message:  detail,
+		Internal: apperror.NewValidation(detail, fields...),
+	}
+}
+
+func errorToProblem(err error) *presenter.Problem {
+	var appErr *apperror.Error
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		problem := newProblem(httpErr.Code, "")
+		if httpErr.Code < http.StatusInternalServerError {
+			problem.Detail = stringPtr(fmt.Sprint(httpErr.Message))
+			problem.Errors = fieldsToProblem(apperror.FieldsOf(httpErr.Internal))
+		}
+		return problem
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		// タイムアウトやクライアントの切断で DB への問い合わせを中断した
+		return newProblem(http.StatusServiceUnavailable, "")
+	case errors.As(err, &appErr):
+		if status, ok := statusByKind[appErr.Kind]; ok {
+			problem := newProblem(status, "/problems/"+appErr.Kind.String())
+			problem.Detail = stringPtr(appErr.Message)
+			problem.Errors = fieldsToProblem(apperror.FieldsOf(err))
+			return problem
+		}
+	}
+	return newProblem(http.StatusInternalServerError, "")
+}
+
+// problemType を省略した場合はステータスコードだけで意味が伝わるものとして about:blank にする
+func newProblem(status int, problemType string) *presenter.Problem {
+	if problemType == "" {
+		problemType = "about:blank"
+	}
+	return &presenter.Problem{
+		Type:   problemType,
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+}
+
+func fieldsToProblem(fields []apperror.FieldError) *[]presenter.ProblemFieldError {
+	if len(fields) == 0 {
+		return nil
+	}
+	problemFields := make([]presenter.ProblemFieldError, 0, len(fields))
+	for _, field := range fields {
+		problemField := presenter.ProblemFieldError{Detail: field.Detail}
+		if field.Pointer != "" {
+			problemField.Pointer = stringPtr(field.Pointer)
+		}
+		if field.Parameter != "" {
+			problemField.Parameter = stringPtr(field.Parameter)
+		}
+		problemFields = append(problemFields, problemField)
+	}
+	return &problemFields
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
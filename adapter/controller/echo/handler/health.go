@@ -1,13 +1,104 @@
 package handler
 
 import (
+	"context"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
-func Health(c echo.Context) error {
-	return c.JSON(http.StatusOK, &struct {
-		Status string `json:"status"`
-	}{Status: "ok"})
+const (
+	healthStatusOK          = "ok"
+	healthStatusUnavailable = "unavailable"
+	healthStatusDraining    = "draining"
+	healthStatusError       = "error"
+)
+
+// HealthCheck は readiness で確認する依存先
+// Check は ctx の期限までに結果を返す
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	checks   []HealthCheck
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+// NewHealthHandler は timeout を各依存先の確認の制限時間とする
+func NewHealthHandler(timeout time.Duration, checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{checks: checks, timeout: timeout}
+}
+
+// Drain は終了処理の開始を記録する。以降の readiness は失敗させ、新しいリクエストを振り分けられないようにする
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+type healthResponse struct {
+	Status     string                      `json:"status"`
+	Components map[string]*componentHealth `json:"components,omitempty"`
+}
+
+type componentHealth struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Live はプロセスが応答できることだけを返す。依存先の障害では失敗させない
+func (h *HealthHandler) Live(c echo.Context) error {
+	return c.JSON(http.StatusOK, &healthResponse{Status: healthStatusOK})
+}
+
+// Ready は依存先をすべて並行に確認し、1 つでも失敗すれば 503 を返す
+func (h *HealthHandler) Ready(c echo.Context) error {
+	if h.draining.Load() {
+		return c.JSON(http.StatusServiceUnavailable, &healthResponse{Status: healthStatusDraining})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), h.timeout)
+	defer cancel()
+
+	results := make([]*componentHealth, len(h.checks))
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runHealthCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	res := &healthResponse{Status: healthStatusOK, Components: map[string]*componentHealth{}}
+	for i, check := range h.checks {
+		res.Components[check.Name] = results[i]
+		if results[i].Status != healthStatusOK {
+			res.Status = healthStatusUnavailable
+		}
+	}
+	if res.Status != healthStatusOK {
+		return c.JSON(http.StatusServiceUnavailable, res)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func runHealthCheck(ctx context.Context, check HealthCheck) *componentHealth {
+	start := time.Now()
+	err := check.Check(ctx)
+	result := &componentHealth{
+		Status:    healthStatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = healthStatusError
+		result.Error = err.Error()
+	}
+	return result
 }
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"go-todo-app-clean-arch/pkg/security"
+)
+
+// jwksMaxAge は JWKS をキャッシュしてよい秒数
+// 鍵を入れ替えるときは、新しい鍵を公開してからこれより長く待って署名に使い始める
+const jwksMaxAge = 300
+
+type JWKSHandler struct {
+	keys *security.KeyRing
+}
+
+func NewJWKSHandler(keys *security.KeyRing) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+// Keys はアクセストークンの検証に使う公開鍵を JWK Set（RFC 7517）で返す
+func (h *JWKSHandler) Keys(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", jwksMaxAge))
+	return c.JSON(http.StatusOK, h.keys.JWKS())
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"go-todo-app-clean-arch/adapter/controller/echo/presenter"
+	"go-todo-app-clean-arch/usecase"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+const (
+	// oidcStateCookie はログインを始めたブラウザだけがコールバックを完了できるよう、state を入れておく Cookie
+	oidcStateCookie     = "oidc_state"
+	oidcStateCookiePath = "/api/v1/auth/oidc"
+)
+
+// OIDCConfig はコールバックの後にブラウザを戻す先
+type OIDCConfig struct {
+	ReturnURL string
+}
+
+type OIDCHandler struct {
+	oidcUseCase  usecase.OIDCUseCase
+	cookieConfig CookieConfig
+	config       OIDCConfig
+}
+
+func NewOIDCHandler(oidcUseCase usecase.OIDCUseCase, cookieConfig CookieConfig, config OIDCConfig) *OIDCHandler {
+	return &OIDCHandler{
+		oidcUseCase:  oidcUseCase,
+		cookieConfig: cookieConfig,
+		config:       config,
+	}
+}
+
+// Providers はログインに使えるプロバイダーの名前を返す
+func (h *OIDCHandler) Providers(c echo.Context) error {
+	return c.JSON(http.StatusOK, echo.Map{"providers": h.oidcUseCase.Providers()})
+}
+
+// Login はブラウザをプロバイダーの認可エンドポイントにリダイレクトする
+func (h *OIDCHandler) Login(c echo.Context) error {
+	authorization, err := h.oidcUseCase.StartLogin(c.Request().Context(), c.Param("provider"))
+	if err != nil {
+		return err
+	}
+	h.setStateCookie(c, authorization)
+	return c.Redirect(http.StatusFound, authorization.URL)
+}
+
+// Callback はプロバイダーから戻ったブラウザのログイン・連携を完了し、結果を付けてフロントエンドに戻す
+func (h *OIDCHandler) Callback(c echo.Context) error {
+	cookie, cookieErr := c.Cookie(oidcStateCookie)
+	h.clearStateCookie(c)
+
+	// ユーザーがプロバイダーで拒否した場合など（RFC 6749 4.1.2.1）
+	if code := c.QueryParam("error"); code != "" {
+		return h.redirect(c, url.Values{"error": {code}, "error_description": {c.QueryParam("error_description")}}, nil)
+	}
+	// 他人のブラウザで始めたログインのコールバックを開かせ、そのアカウントでログインさせる攻撃を防ぐ
+	state := c.QueryParam("state")
+	if cookieErr != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		return h.redirectError(c, usecase.ErrInvalidOIDCState)
+	}
+
+	result, err := h.oidcUseCase.Callback(c.Request().Context(), state, c.QueryParam("code"), clientInfo(c))
+	if err != nil {
+		return h.redirectError(c, err)
+	}
+	if result.Login == nil {
+		return h.redirect(c, url.Values{"linked": {result.Provider}}, nil)
+	}
+	// 2 要素目の入力が済むまで Cookie は発行しない。チャレンジはサーバーのログに残らないようフラグメントで渡す
+	if challenge := result.Login.Challenge; challenge != nil {
+		return h.redirect(c, nil, url.Values{
+			"challenge_token":      {challenge.Token},
+			"challenge_expires_at": {challenge.ExpiresAt.Format(time.RFC3339)},
+		})
+	}
+	h.cookieConfig.setTokenCookies(c, result.Login.Tokens)
+	return h.redirect(c, nil, nil)
+}
+
+// ListIdentities は連携しているプロバイダーのアカウントを返す
+func (h *OIDCHandler) ListIdentities(c echo.Context) error {
+	identities, err := h.oidcUseCase.ListIdentities(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	res := []presenter.UserIdentity{}
+	for _, identity := range identities {
+		res = append(res, presenter.UserIdentity{
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		})
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// Link は連携を始め、ブラウザで開く認可エンドポイントの URL を返す
+// fetch ではリダイレクトを辿れないため、Login と違って URL を JSON で返す
+func (h *OIDCHandler) Link(c echo.Context) error {
+	authorization, err := h.oidcUseCase.StartLink(c.Request().Context(), c.Param("provider"))
+	if err != nil {
+		return err
+	}
+	h.setStateCookie(c, authorization)
+	return c.JSON(http.StatusOK, echo.Map{"authorization_url": authorization.URL})
+}
+
+func (h *OIDCHandler) Unlink(c echo.Context) error {
+	if err := h.oidcUseCase.Unlink(c.Request().Context(), c.Param("provider")); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// redirectError はクライアントに伝えてよいエラーを error と error_description に入れてフロントエンドに戻す
+// それ以外のエラーは通常どおり 500 で返す
+func (h *OIDCHandler) redirectError(c echo.Context, err error) error {
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Kind == apperror.KindUnknown {
+		return err
+	}
+	return h.redirect(c, url.Values{"error": {appErr.Kind.String()}, "error_description": {appErr.Message}}, nil)
+}
+
+func (h *OIDCHandler) redirect(c echo.Context, query, fragment url.Values) error {
+	u, err := url.Parse(h.config.ReturnURL)
+	if err != nil {
+		return err
+	}
+	values := u.Query()
+	for key, v := range query {
+		values[key] = v
+	}
+	u.RawQuery = values.Encode()
+	if fragment != nil {
+		u.Fragment = fragment.Encode()
+	}
+	return c.Redirect(http.StatusFound, u.String())
+}
+
+// setStateCookie はプロバイダーから戻るリダイレクトでも送られるよう SameSite=Lax にする
+func (h *OIDCHandler) setStateCookie(c echo.Context, authorization *usecase.OIDCAuthorization) {
+	cookie := h.cookieConfig.newCookie(oidcStateCookie, authorization.State, oidcStateCookiePath, authorization.ExpiresAt)
+	cookie.SameSite = http.SameSiteLaxMode
+	c.SetCookie(cookie)
+}
+
+func (h *OIDCHandler) clearStateCookie(c echo.Context) {
+	cookie := h.cookieConfig.newCookie(oidcStateCookie, "", oidcStateCookiePath, time.Now().Add(-1*time.Hour))
+	cookie.SameSite = http.SameSiteLaxMode
+	c.SetCookie(cookie)
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"go-todo-app-clean-arch/adapter/controller/echo/presenter"
+	"go-todo-app-clean-arch/usecase"
+)
+
+type PasswordResetHandler struct {
+	passwordResetUseCase usecase.PasswordResetUseCase
+}
+
+func NewPasswordResetHandler(passwordResetUseCase usecase.PasswordResetUseCase) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		passwordResetUseCase: passwordResetUseCase,
+	}
+}
+
+// ForgotPassword は再設定メールを送る。アカウントの有無にかかわらず同じレスポンスを返す
+func (h *PasswordResetHandler) ForgotPassword(c echo.Context) error {
+	var requestBody presenter.ForgotPasswordJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return err
+	}
+
+	if err := h.passwordResetUseCase.ForgotPassword(c.Request().Context(), string(requestBody.Email)); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, map[string]string{"message": "if the account exists, a password reset email has been sent"})
+}
+
+func (h *PasswordResetHandler) ResetPassword(c echo.Context) error {
+	var requestBody presenter.ResetPasswordJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return err
+	}
+
+	if err := h.passwordResetUseCase.ResetPassword(c.Request().Context(), requestBody.Token, requestBody.Password); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "password has been reset"})
+}
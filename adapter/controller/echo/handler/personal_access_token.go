@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"go-todo-app-clean-arch/adapter/controller/echo/presenter"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type PersonalAccessTokenHandler struct {
+	personalAccessTokenUseCase usecase.PersonalAccessTokenUseCase
+}
+
+func NewPersonalAccessTokenHandler(personalAccessTokenUseCase usecase.PersonalAccessTokenUseCase) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{
+		personalAccessTokenUseCase: personalAccessTokenUseCase,
+	}
+}
+
+func accessTokenToResponse(token *entity.PersonalAccessToken) presenter.AccessToken {
+	scopes := []presenter.AccessTokenScope{}
+	for _, scope := range token.ScopeList() {
+		scopes = append(scopes, presenter.AccessTokenScope(scope))
+	}
+	return presenter.AccessToken{
+		Id:         token.ID,
+		Name:       token.Name,
+		Scopes:     scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+func (h *PersonalAccessTokenHandler) List(c echo.Context) error {
+	tokens, err := h.personalAccessTokenUseCase.List(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	res := []presenter.AccessToken{}
+	for _, token := range tokens {
+		res = append(res, accessTokenToResponse(token))
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// Create はトークンを作り、平文のトークンを返す。平文を返すのはこの 1 回だけ
+func (h *PersonalAccessTokenHandler) Create(c echo.Context) error {
+	var requestBody presenter.CreateAccessTokenJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return err
+	}
+	scopes := make([]string, 0, len(requestBody.Scopes))
+	for _, scope := range requestBody.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	created, err := h.personalAccessTokenUseCase.Create(c.Request().Context(), requestBody.Name, scopes, requestBody.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, struct {
+		presenter.AccessToken
+		Token string `json:"token"`
+	}{
+		AccessToken: accessTokenToResponse(created.Token),
+		Token:       created.Secret,
+	})
+}
+
+func (h *PersonalAccessTokenHandler) Revoke(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return badRequest("invalid token ID", apperror.ParamField("id", "must be an integer"))
+	}
+
+	if err := h.personalAccessTokenUseCase.Revoke(c.Request().Context(), id); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
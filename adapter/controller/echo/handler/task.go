@@ -1,16 +1,18 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
-	"github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
 
 	"go-todo-app-clean-arch/adapter/controller/echo/presenter"
 	"go-todo-app-clean-arch/entity"
 	"go-todo-app-clean-arch/pkg/logger"
 	"go-todo-app-clean-arch/usecase"
+	"go-todo-app-clean-arch/usecase/apperror"
 )
 
 type TaskHandler struct {
@@ -23,94 +25,208 @@ func NewTaskHandler(taskUseCase usecase.TaskUseCase) *TaskHandler {
 	}
 }
 
-func (t *TaskHandler) CreateTask(c echo.Context) error {
-	user := c.Get("user").(*jwt.Token)
-	claims := user.Claims.(jwt.MapClaims)
-	userId := int(claims["user_id"].(float64))
+func taskToResponse(task *entity.Task) *presenter.TaskResponse {
+	return &presenter.TaskResponse{
+		Id:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      presenter.TaskStatus(task.Status),
+		Priority:    int(task.Priority),
+		DueAt:       task.DueAt,
+		CompletedAt: task.CompletedAt,
+		UserId:      task.UserID,
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+	}
+}
+
+func taskPageToResponse(page *entity.TaskPage) *presenter.TaskPageResponse {
+	response := &presenter.TaskPageResponse{
+		Items: make(presenter.TaskList, 0, len(page.Tasks)),
+	}
+	for _, task := range page.Tasks {
+		response.Items = append(response.Items, *taskToResponse(task))
+	}
+	if page.NextCursor != nil {
+		nextCursor := page.NextCursor.Encode()
+		response.NextCursor = &nextCursor
+	}
+	return response
+}
 
+// bindTaskQuery はクエリパラメータを openapi.yaml の定義に沿って読み取り、TaskQuery に変換する
+func bindTaskQuery(c echo.Context) (*entity.TaskQuery, error) {
+	var params presenter.GetAllTasksParams
+	queryParams := c.QueryParams()
+	bindings := []struct {
+		name string
+		dest interface{}
+	}{
+		{"status", &params.Status},
+		{"priority", &params.Priority},
+		{"due_before", &params.DueBefore},
+		{"due_after", &params.DueAfter},
+		{"q", &params.Q},
+		{"sort", &params.Sort},
+		{"order", &params.Order},
+		{"cursor", &params.Cursor},
+		{"limit", &params.Limit},
+	}
+	for _, b := range bindings {
+		if err := runtime.BindQueryParameter("form", true, false, b.name, queryParams, b.dest); err != nil {
+			detail := fmt.Sprintf("invalid format for parameter %s", b.name)
+			return nil, badRequest(detail, apperror.ParamField(b.name, err.Error()))
+		}
+	}
+
+	query := &entity.TaskQuery{
+		DueBefore: params.DueBefore,
+		DueAfter:  params.DueAfter,
+	}
+	if params.Status != nil {
+		for _, status := range *params.Status {
+			query.Statuses = append(query.Statuses, entity.TaskStatus(status))
+		}
+	}
+	if params.Priority != nil {
+		query.Priority = entity.TaskPriority(*params.Priority)
+	}
+	if params.Q != nil {
+		query.Title = *params.Q
+	}
+	if params.Sort != nil {
+		query.SortField = entity.TaskSortField(*params.Sort)
+	}
+	if params.Order != nil {
+		query.SortDirection = entity.SortDirection(*params.Order)
+	}
+	if params.Limit != nil {
+		if *params.Limit < 1 {
+			return nil, badRequest("limit must be greater than 0", apperror.ParamField("limit", "must be greater than 0"))
+		}
+		query.Limit = *params.Limit
+	}
+	if params.Cursor != nil && *params.Cursor != "" {
+		cursor, err := entity.DecodeTaskCursor(*params.Cursor)
+		if err != nil {
+			return nil, badRequest(err.Error(), apperror.ParamField("cursor", err.Error()))
+		}
+		query.Cursor = cursor
+	}
+	return query, nil
+}
+
+func (t *TaskHandler) CreateTask(c echo.Context) error {
 	// リクエストボディをバインド
 	var requestBody presenter.CreateTaskJSONRequestBody
 	if err := c.Bind(&requestBody); err != nil {
-		return c.JSON(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	task := &entity.Task{
-		Title:  requestBody.Title,
-		UserID: userId,
+		Title: requestBody.Title,
+		DueAt: requestBody.DueAt,
+	}
+	if requestBody.Description != nil {
+		task.Description = *requestBody.Description
+	}
+	if requestBody.Status != nil {
+		task.Status = entity.TaskStatus(*requestBody.Status)
+	}
+	if requestBody.Priority != nil {
+		task.Priority = entity.TaskPriority(*requestBody.Priority)
 	}
 
-	createdTask, err := t.taskUseCase.Create(task)
+	createdTask, err := t.taskUseCase.Create(c.Request().Context(), task)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	logger.Info("Task created")
-	return c.JSON(http.StatusOK, createdTask)
+	return c.JSON(http.StatusCreated, taskToResponse(createdTask))
 }
 
 func (t *TaskHandler) GetTaskById(c echo.Context) error {
-	user := c.Get("user").(*jwt.Token)
-	claims := user.Claims.(jwt.MapClaims)
-	userId := int(claims["user_id"].(float64))
-
 	taskId, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid task ID"})
+		return badRequest("invalid task ID", apperror.ParamField("id", "must be an integer"))
 	}
 
-	task, err := t.taskUseCase.Get(userId, taskId)
+	task, err := t.taskUseCase.Get(c.Request().Context(), taskId)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return err
 	}
-	return c.JSON(http.StatusOK, task)
+	return c.JSON(http.StatusOK, taskToResponse(task))
 }
 
 func (t *TaskHandler) GetAllTasks(c echo.Context) error {
-	user := c.Get("user").(*jwt.Token)
-	claims := user.Claims.(jwt.MapClaims)
-	userId := int(claims["user_id"].(float64))
+	query, err := bindTaskQuery(c)
+	if err != nil {
+		return err
+	}
 
-	tasks, err := t.taskUseCase.GetAllTasks(userId)
+	page, err := t.taskUseCase.List(c.Request().Context(), query)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return err
 	}
-	return c.JSON(http.StatusOK, tasks)
+	return c.JSON(http.StatusOK, taskPageToResponse(page))
 }
 
 func (t *TaskHandler) UpdateTaskById(c echo.Context) error {
-	user := c.Get("user").(*jwt.Token)
-	claims := user.Claims.(jwt.MapClaims)
-	userId := int(claims["user_id"].(float64))
-
 	taskId, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid task ID"})
+		return badRequest("invalid task ID", apperror.ParamField("id", "must be an integer"))
 	}
 
-	var task entity.Task
-	if err := c.Bind(&task); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	var requestBody presenter.UpdateTaskByIdJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return err
 	}
 
-	updatedTask, err := t.taskUseCase.Save(&task, userId, taskId)
+	// 省略された項目は nil のまま渡し、ユースケース側で既存の値を維持する
+	// 説明と期限は null を明示すると消す
+	update := &entity.TaskUpdate{
+		Title: requestBody.Title,
+	}
+	if requestBody.Description.IsSpecified() {
+		description := ""
+		if !requestBody.Description.IsNull() {
+			description = requestBody.Description.MustGet()
+		}
+		update.Description = &description
+	}
+	if requestBody.DueAt.IsSpecified() {
+		if requestBody.DueAt.IsNull() {
+			update.ClearDueAt = true
+		} else {
+			dueAt := requestBody.DueAt.MustGet()
+			update.DueAt = &dueAt
+		}
+	}
+	if requestBody.Status != nil {
+		status := entity.TaskStatus(*requestBody.Status)
+		update.Status = &status
+	}
+	if requestBody.Priority != nil {
+		priority := entity.TaskPriority(*requestBody.Priority)
+		update.Priority = &priority
+	}
+
+	updatedTask, err := t.taskUseCase.Save(c.Request().Context(), update, taskId)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return err
 	}
-	return c.JSON(http.StatusOK, updatedTask)
+	return c.JSON(http.StatusOK, taskToResponse(updatedTask))
 }
 
 func (t *TaskHandler) DeleteTaskById(c echo.Context) error {
-	user := c.Get("user").(*jwt.Token)
-	claims := user.Claims.(jwt.MapClaims)
-	userId := int(claims["user_id"].(float64))
-
 	taskId, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid task ID"})
+		return badRequest("invalid task ID", apperror.ParamField("id", "must be an integer"))
 	}
 
-	if err := t.taskUseCase.Delete(taskId, userId); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	if err := t.taskUseCase.Delete(c.Request().Context(), taskId); err != nil {
+		return err
 	}
 	return c.NoContent(http.StatusNoContent)
 }
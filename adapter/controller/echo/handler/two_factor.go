@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	qrcode "github.com/skip2/go-qrcode"
+
+	"go-todo-app-clean-arch/adapter/controller/echo/presenter"
+	"go-todo-app-clean-arch/usecase"
+)
+
+// qrCodeSize は PNG の QR コードの一辺のピクセル数
+const qrCodeSize = 256
+
+type TwoFactorHandler struct {
+	twoFactorUseCase usecase.TwoFactorUseCase
+}
+
+func NewTwoFactorHandler(twoFactorUseCase usecase.TwoFactorUseCase) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorUseCase: twoFactorUseCase,
+	}
+}
+
+func (h *TwoFactorHandler) Status(c echo.Context) error {
+	status, err := h.twoFactorUseCase.Status(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"enabled":                  status.Enabled,
+		"recovery_codes_remaining": status.RecoveryCodesRemaining,
+	})
+}
+
+func (h *TwoFactorHandler) Enroll(c echo.Context) error {
+	enrollment, err := h.twoFactorUseCase.Enroll(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, map[string]string{
+		"secret":      enrollment.Secret,
+		"otpauth_uri": enrollment.URI,
+	})
+}
+
+// QRCode は登録途中の otpauth URI を QR コードの画像で返す
+func (h *TwoFactorHandler) QRCode(c echo.Context) error {
+	enrollment, err := h.twoFactorUseCase.PendingEnrollment(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	qr, err := qrcode.New(enrollment.URI, qrcode.Medium)
+	if err != nil {
+		return err
+	}
+
+	// 秘密鍵を含むため、ブラウザやプロキシに残さない
+	c.Response().Header().Set("Cache-Control", "no-store")
+	if presenter.GetTotpQrCodeParamsFormat(c.QueryParam("format")) == presenter.Svg {
+		return c.Blob(http.StatusOK, "image/svg+xml", qrSVG(qr))
+	}
+	png, err := qr.PNG(qrCodeSize)
+	if err != nil {
+		return err
+	}
+	return c.Blob(http.StatusOK, "image/png", png)
+}
+
+// qrSVG は QR コードの黒いモジュールを 1 単位の正方形で描いた SVG を返す
+// viewBox で大きさを決めるので、表示する側で自由に拡大できる
+func qrSVG(qr *qrcode.QRCode) []byte {
+	bitmap := qr.Bitmap()
+	var path bytes.Buffer
+	for y, row := range bitmap {
+		for x, black := range row {
+			if black {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	var svg bytes.Buffer
+	size := len(bitmap)
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size)
+	fmt.Fprintf(&svg, `<rect width="%d" height="%d" fill="#fff"/>`, size, size)
+	fmt.Fprintf(&svg, `<path d="%s" fill="#000"/></svg>`, path.String())
+	return svg.Bytes()
+}
+
+// Confirm は登録を完了し、リカバリーコードを返す。リカバリーコードを返すのはこの 1 回だけ
+func (h *TwoFactorHandler) Confirm(c echo.Context) error {
+	var requestBody presenter.ConfirmTotpJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return err
+	}
+
+	codes, err := h.twoFactorUseCase.Confirm(c.Request().Context(), requestBody.Code)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string][]string{"recovery_codes": codes})
+}
+
+func (h *TwoFactorHandler) Disable(c echo.Context) error {
+	var requestBody presenter.DisableTotpJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return err
+	}
+
+	if err := h.twoFactorUseCase.Disable(c.Request().Context(), requestBody.Password); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "two-factor authentication has been disabled"})
+}
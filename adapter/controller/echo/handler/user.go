@@ -2,46 +2,53 @@ package handler
 
 import (
 	"net/http"
-	"os"
 	"time"
 
-	"github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
 
 	"go-todo-app-clean-arch/adapter/controller/echo/presenter"
 	"go-todo-app-clean-arch/entity"
-	"go-todo-app-clean-arch/pkg/logger"
 	"go-todo-app-clean-arch/usecase"
+	"go-todo-app-clean-arch/usecase/apperror"
 )
 
+const (
+	accessTokenCookie  = "auth_token"
+	refreshTokenCookie = "refresh_token"
+	// リフレッシュトークンは再発行・ログアウトでしか使わないため、それ以外のリクエストでは送らせない
+	refreshTokenCookiePath = "/api/v1/auth"
+)
+
+// CookieConfig はトークンを入れる Cookie の設定
+type CookieConfig struct {
+	Domain string
+}
+
 type UserHandler struct {
-	userUseCase usecase.UserUseCase
+	userUseCase  usecase.UserUseCase
+	cookieConfig CookieConfig
 }
 
-func NewUserHandler(userUseCase usecase.UserUseCase) *UserHandler {
+func NewUserHandler(userUseCase usecase.UserUseCase, cookieConfig CookieConfig) *UserHandler {
 	return &UserHandler{
-		userUseCase: userUseCase,
+		userUseCase:  userUseCase,
+		cookieConfig: cookieConfig,
 	}
 }
 
 func userToResponse(user *entity.User) *presenter.UserResponse {
 	return &presenter.UserResponse{
-		Id:    user.ID,
-		Email: user.Email,
+		Id:            user.ID,
+		Email:         user.Email,
+		EmailVerified: user.IsEmailVerified(),
 	}
 }
 
 func (u *UserHandler) GetCurrentUser(c echo.Context) error {
-	// JWTトークンからユーザーIDを取得
-	user := c.Get("user").(*jwt.Token)
-	claims := user.Claims.(jwt.MapClaims)
-	userId := int(claims["user_id"].(float64)) // トークンから取得した user_id
-
-	// ユースケースからユーザー情報を取得
-	userEntity, err := u.userUseCase.GetCurrentUser(userId)
+	// 認証したユーザーの情報をユースケースから取得
+	userEntity, err := u.userUseCase.GetCurrentUser(c.Request().Context())
 	if err != nil {
-		logger.Error(err.Error())
-		return c.JSON(http.StatusInternalServerError, &presenter.ErrorResponse{Message: "Failed to retrieve user"})
+		return err
 	}
 
 	// レスポンスとしてユーザー情報を返す
@@ -49,23 +56,54 @@ func (u *UserHandler) GetCurrentUser(c echo.Context) error {
 }
 
 func (u *UserHandler) DeleteUser(c echo.Context) error {
-	user := c.Get("user").(*jwt.Token)
-	claims := user.Claims.(jwt.MapClaims)
-	userId := int(claims["user_id"].(float64))
-
-	if err := u.userUseCase.DeleteUser(userId); err != nil {
-		logger.Error(err.Error())
-		return c.JSON(http.StatusInternalServerError, &presenter.ErrorResponse{Message: err.Error()})
+	if err := u.userUseCase.DeleteUser(c.Request().Context()); err != nil {
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// ChangePassword は現在のパスワードを確かめてからパスワードを変える。リクエストしたセッション以外はログアウトされる
+func (u *UserHandler) ChangePassword(c echo.Context) error {
+	var requestBody presenter.ChangePasswordJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return err
+	}
+
+	if err := u.userUseCase.ChangePassword(c.Request().Context(), requestBody.CurrentPassword, requestBody.Password); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "password has been changed"})
+}
+
+// RequestEmailChange は新しいアドレスに確認メールを送る。確認されるまでメールアドレスは変わらない
+func (u *UserHandler) RequestEmailChange(c echo.Context) error {
+	var requestBody presenter.RequestEmailChangeJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return err
+	}
+
+	if err := u.userUseCase.RequestEmailChange(c.Request().Context(), string(requestBody.Email)); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, map[string]string{"message": "a confirmation email has been sent to the new address"})
+}
+
+// ConfirmEmailChange は新しいアドレスに送った確認メールのリンクから直接開かれる
+func (u *UserHandler) ConfirmEmailChange(c echo.Context) error {
+	if err := u.userUseCase.ConfirmEmailChange(c.Request().Context(), c.QueryParam("token")); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "email address has been changed"})
+}
+
 func (u *UserHandler) Signup(c echo.Context) error {
 	var requestBody presenter.CreateUserJSONRequestBody
 	if err := c.Bind(&requestBody); err != nil {
-		logger.Warn(err.Error())
-		return c.JSON(http.StatusBadRequest, &presenter.ErrorResponse{Message: err.Error()})
+		return err
 	}
 
 	user := &entity.User{
@@ -73,62 +111,234 @@ func (u *UserHandler) Signup(c echo.Context) error {
 		Password: requestBody.Password,
 	}
 
-	createdUser, err := u.userUseCase.Signup(user)
+	createdUser, err := u.userUseCase.Signup(c.Request().Context(), user)
 	if err != nil {
-		logger.Error(err.Error())
-		return c.JSON(http.StatusInternalServerError, &presenter.ErrorResponse{Message: err.Error()})
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, userToResponse(createdUser))
 }
 
+// Login はメールアドレスとパスワードでログインする
+// return_tokens を指定した場合は、Cookie の代わりにレスポンスの本文でトークンを返す
 func (u *UserHandler) Login(c echo.Context) error {
-	var credentials entity.Credentials
-	if err := c.Bind(&credentials); err != nil {
-		logger.Warn(err.Error())
-		return c.JSON(http.StatusBadRequest, &presenter.ErrorResponse{Message: err.Error()})
+	var requestBody presenter.LoginUserJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return err
 	}
 
-	tokenString, err := u.userUseCase.Login(&credentials)
+	credentials := entity.Credentials{
+		Email:    string(requestBody.Email),
+		Password: requestBody.Password,
+	}
+	result, err := u.userUseCase.Login(c.Request().Context(), &credentials, clientInfo(c))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return err
+	}
+	// 2 要素目の入力が済むまで Cookie は発行しない
+	if result.Challenge != nil {
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"message":                "second factor required",
+			"second_factor_required": true,
+			"challenge_token":        result.Challenge.Token,
+			"challenge_expires_at":   result.Challenge.ExpiresAt,
+		})
+	}
+
+	return u.respondTokens(c, "login successful", result.Tokens, requestBody.ReturnTokens != nil && *requestBody.ReturnTokens)
+}
+
+// LoginTOTP は Login が返したチャレンジと 2 要素目のコードでログインを完了する
+func (u *UserHandler) LoginTOTP(c echo.Context) error {
+	var requestBody presenter.LoginTotpJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return err
+	}
+
+	tokens, err := u.userUseCase.LoginTOTP(c.Request().Context(), requestBody.ChallengeToken, requestBody.Code, clientInfo(c))
+	if err != nil {
+		return err
 	}
-	// Set JWT token as a secure cookie
-	cookie := new(http.Cookie)
-	cookie.Name = "auth_token"
-	cookie.Value = tokenString
-	cookie.Expires = time.Now().Add(24 * time.Hour)
-	cookie.Path = "/"
-	cookie.Domain = os.Getenv("API_DOMAIN")
-	// cookie.Secure = true
-	cookie.HttpOnly = true
-	cookie.SameSite = http.SameSiteNoneMode
-	c.SetCookie(cookie)
 
-	// return c.JSON(http.StatusOK, map[string]string{"message": "login successful"})
-	return c.NoContent(http.StatusOK)
+	return u.respondTokens(c, "login successful", tokens, requestBody.ReturnTokens != nil && *requestBody.ReturnTokens)
 }
 
+// Refresh はリフレッシュトークンで、両方のトークンを発行し直す
+// 本文でリフレッシュトークンを送った場合は本文で、refresh_token Cookie の場合は Cookie で返す
+func (u *UserHandler) Refresh(c echo.Context) error {
+	refreshToken, inBody, err := u.refreshToken(c)
+	if err != nil {
+		return err
+	}
+	if refreshToken == "" {
+		return usecase.ErrInvalidRefreshToken
+	}
+
+	tokens, err := u.userUseCase.Refresh(c.Request().Context(), refreshToken, clientInfo(c))
+	if err != nil {
+		// 失効したトークンを送り続けないよう Cookie を消す
+		if !inBody && apperror.IsUnauthorized(err) {
+			u.clearTokenCookies(c)
+		}
+		return err
+	}
+
+	return u.respondTokens(c, "token refreshed", tokens, inBody)
+}
+
+// Logout はリフレッシュトークンをサーバー側で失効させ、Cookie を消す
 func (u *UserHandler) Logout(c echo.Context) error {
+	refreshToken, _, err := u.refreshToken(c)
+	if err != nil {
+		return err
+	}
+	if refreshToken != "" {
+		if err := u.userUseCase.Logout(c.Request().Context(), refreshToken); err != nil {
+			return err
+		}
+	}
+	u.clearTokenCookies(c)
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "logout successful"})
+}
+
+// ListSessions はログイン中のセッションを返す。リクエストしたセッションには current を付ける
+func (u *UserHandler) ListSessions(c echo.Context) error {
+	principal, err := currentPrincipal(c)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := u.userUseCase.ListSessions(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	res := presenter.SessionListResponse{}
+	for _, session := range sessions {
+		res = append(res, presenter.Session{
+			Id:         session.ID,
+			UserAgent:  session.UserAgent,
+			IpAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.ID == principal.SessionID,
+		})
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// RevokeSession は指定したセッションを失効させる。自分のセッションの場合は Cookie も消す
+func (u *UserHandler) RevokeSession(c echo.Context) error {
+	principal, err := currentPrincipal(c)
+	if err != nil {
+		return err
+	}
+	id := c.Param("id")
+
+	if err := u.userUseCase.RevokeSession(c.Request().Context(), id); err != nil {
+		return err
+	}
+	if id == principal.SessionID && principal.Method == usecase.AuthMethodCookie {
+		u.clearTokenCookies(c)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RevokeOtherSessions はリクエストしたセッション以外をすべて失効させる
+func (u *UserHandler) RevokeOtherSessions(c echo.Context) error {
+	if err := u.userUseCase.RevokeOtherSessions(c.Request().Context()); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// currentPrincipal は JWTMiddleware が認証した主体を返す
+// ログインのセッションとパーソナルアクセストークンのどちらで認証した場合も使える
+func currentPrincipal(c echo.Context) (*usecase.Principal, error) {
+	principal, ok := usecase.PrincipalFromContext(c.Request().Context())
+	if !ok {
+		return nil, usecase.ErrUnauthenticated
+	}
+	return principal, nil
+}
+
+func clientInfo(c echo.Context) usecase.ClientInfo {
+	return usecase.ClientInfo{
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	}
+}
+
+// refreshToken は本文か refresh_token Cookie のリフレッシュトークンを返す。どちらにもなければ空文字列を返す
+func (u *UserHandler) refreshToken(c echo.Context) (token string, inBody bool, err error) {
+	var requestBody presenter.RefreshTokenJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return "", false, err
+	}
+	if requestBody.RefreshToken != "" {
+		return requestBody.RefreshToken, true, nil
+	}
+	if cookie, err := c.Cookie(refreshTokenCookie); err == nil {
+		return cookie.Value, false, nil
+	}
+	return "", false, nil
+}
+
+// respondTokens は発行したトークンを、inBody なら本文で、そうでなければ Cookie で返す
+// Cookie を使わないクライアントは、アクセストークンを Authorization: Bearer ヘッダーで送る
+func (u *UserHandler) respondTokens(c echo.Context, message string, tokens *usecase.TokenPair, inBody bool) error {
+	if !inBody {
+		u.setTokenCookies(c, tokens)
+		return c.JSON(http.StatusOK, map[string]string{"message": message})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": message,
+		"tokens": presenter.IssuedTokens{
+			TokenType:             presenter.Bearer,
+			AuthToken:             tokens.AccessToken,
+			AuthTokenExpiresAt:    tokens.AccessTokenExpiresAt,
+			RefreshToken:          tokens.RefreshToken,
+			RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+		},
+	})
+}
+
+func (u *UserHandler) setTokenCookies(c echo.Context, tokens *usecase.TokenPair) {
+	u.cookieConfig.setTokenCookies(c, tokens)
+}
+
+func (u *UserHandler) clearTokenCookies(c echo.Context) {
+	u.cookieConfig.clearTokenCookies(c)
+}
+
+func (cc CookieConfig) setTokenCookies(c echo.Context, tokens *usecase.TokenPair) {
+	c.SetCookie(cc.newCookie(accessTokenCookie, tokens.AccessToken, "/", tokens.AccessTokenExpiresAt))
+	c.SetCookie(cc.newCookie(refreshTokenCookie, tokens.RefreshToken, refreshTokenCookiePath, tokens.RefreshTokenExpiresAt))
+}
+
+func (cc CookieConfig) clearTokenCookies(c echo.Context) {
+	expired := time.Now().Add(-1 * time.Hour)
+	c.SetCookie(cc.newCookie(accessTokenCookie, "", "/", expired))
+	c.SetCookie(cc.newCookie(refreshTokenCookie, "", refreshTokenCookiePath, expired))
+}
+
+func (cc CookieConfig) newCookie(name, value, path string, expires time.Time) *http.Cookie {
 	cookie := new(http.Cookie)
-	cookie.Name = "auth_token"
-	cookie.Value = ""
-	cookie.Expires = time.Now().Add(-1 * time.Hour)
-	cookie.Path = "/"
-	cookie.Domain = os.Getenv("API_DOMAIN")
+	cookie.Name = name
+	cookie.Value = value
+	cookie.Expires = expires
+	cookie.Path = path
+	cookie.Domain = cc.Domain
 	// cookie.Secure = true
 	cookie.HttpOnly = true
 	cookie.SameSite = http.SameSiteNoneMode
-	c.SetCookie(cookie)
-
-	// return c.JSON(http.StatusOK, map[string]string{"message": "logout successful"})
-	return c.NoContent(http.StatusOK)
+	return cookie
 }
 
 func (u *UserHandler) CsrfToken(c echo.Context) error {
-	secret := os.Getenv("SECRET")
-	logger.Info("secret: " + secret)
-
 	token := c.Get("csrf").(string)
 	return c.JSON(http.StatusOK, echo.Map{
 		"csrf_token": token,
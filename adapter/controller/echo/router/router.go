@@ -5,7 +5,6 @@ import (
 	"html/template"
 	"io"
 	"net/http"
-	"os"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
@@ -18,8 +17,11 @@ import (
 	"go-todo-app-clean-arch/adapter/controller/echo/handler"
 	"go-todo-app-clean-arch/adapter/controller/echo/presenter"
 	"go-todo-app-clean-arch/adapter/gateway"
-	"go-todo-app-clean-arch/pkg"
+	"go-todo-app-clean-arch/pkg/config"
 	"go-todo-app-clean-arch/pkg/logger"
+	"go-todo-app-clean-arch/pkg/mailer"
+	"go-todo-app-clean-arch/pkg/oidc"
+	"go-todo-app-clean-arch/pkg/security"
 	"go-todo-app-clean-arch/usecase"
 )
 
@@ -36,14 +38,13 @@ func (t *TemplateRenderer) Render(w io.Writer, name string, data interface{}, c
 }
 
 // Swagger の設定
-func setupSwagger(router *echo.Echo) (*openapi3.T, error) {
+func setupSwagger(router *echo.Echo, conf *config.Config) (*openapi3.T, error) {
 	swagger, err := presenter.GetSwagger()
 	if err != nil {
 		return nil, err
 	}
 
-	env := pkg.GetEnvDefault("APP_ENV", "development")
-	if env == "development" {
+	if conf.IsDevelopment() {
 		swaggerJson, _ := json.Marshal(swagger)
 		var SwaggerInfo = &swag.Spec{
 			InfoInstanceName: "swagger",
@@ -56,23 +57,42 @@ func setupSwagger(router *echo.Echo) (*openapi3.T, error) {
 	return swagger, nil
 }
 
+// レスポンスの検証は開発・テスト用で、web.validate_responses が有効なときだけ行う
+func newOpenAPIValidator(conf *config.Config) (echo.MiddlewareFunc, error) {
+	swagger, err := presenter.GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	return custommiddleware.OpenAPIValidator(swagger, custommiddleware.OpenAPIValidatorConfig{
+		ValidateResponses: conf.Web.ValidateResponses,
+	})
+}
+
 // Echo 用のルータを作成。
-func NewEchoRouter(db *gorm.DB) *echo.Echo {
+// health は終了処理で readiness を失敗させるため、サーバー側で作って渡す
+func NewEchoRouter(db *gorm.DB, conf *config.Config, health *handler.HealthHandler) *echo.Echo {
 	router := echo.New()
+	router.HTTPErrorHandler = handler.HTTPErrorHandler
 
 	// ミドルウェア設定
+	// リクエスト ID はログとエラーレスポンスの request_id で使うため最初に付与する
+	router.Use(middleware.RequestID())
 	router.Use(custommiddleware.CustomRequestLogger())
 	router.Use(custommiddleware.CustomRecovery())
+	router.Use(custommiddleware.TimeoutMiddleware(conf.Web.RequestTimeout))
 	router.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins:     []string{"http://localhost:3000", os.Getenv("FE_URL")},
-		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAccessControlAllowHeaders, echo.HeaderXCSRFToken},
-		AllowMethods:     []string{"GET", "PUT", "POST", "DELETE"},
+		AllowOrigins:  conf.Web.CorsAllowOrigins,
+		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAccessControlAllowHeaders, echo.HeaderXCSRFToken, echo.HeaderAuthorization},
+		ExposeHeaders: []string{echo.HeaderXRequestID},
+		AllowMethods:  []string{"GET", "PUT", "PATCH", "POST", "DELETE"},
 		// AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
 		AllowCredentials: true,
 	}))
 	router.Use(middleware.CSRFWithConfig(middleware.CSRFConfig{
+		// Bearer トークンや Cookie のないリクエストは CSRF の対象外
+		Skipper:        custommiddleware.SkipCSRF,
 		CookiePath:     "/",
-		CookieDomain:   os.Getenv("API_DOMAIN"),
+		CookieDomain:   conf.Web.CookieDomain,
 		CookieHTTPOnly: true,
 		CookieSameSite: http.SameSiteNoneMode,
 		// CookieSameSite: http.SameSiteDefaultMode,
@@ -80,11 +100,18 @@ func NewEchoRouter(db *gorm.DB) *echo.Echo {
 	}))
 
 	// Swagger の設定
-	_, err := setupSwagger(router)
+	_, err := setupSwagger(router, conf)
 	if err != nil {
 		logger.Warn("Swagger setup error: " + err.Error())
 	}
 
+	// openapi.yaml に沿ったリクエストの検証
+	// Swagger UI 用とは別に読み込む（検証用に servers を書き換えるため）
+	openAPIValidator, err := newOpenAPIValidator(conf)
+	if err != nil {
+		logger.Fatal("OpenAPI validator setup error: " + err.Error())
+	}
+
 	// テンプレート設定
 	renderer := &TemplateRenderer{
 		templates: template.Must(template.ParseGlob("./adapter/presenter/html/*")),
@@ -92,30 +119,159 @@ func NewEchoRouter(db *gorm.DB) *echo.Echo {
 	router.Renderer = renderer
 
 	// リポジトリとユースケースの設定
+	m, err := mailer.New(&conf.Mail)
+	if err != nil {
+		logger.Fatal("Mailer setup error: " + err.Error())
+	}
+	verificationPolicy := usecase.EmailVerificationPolicy{
+		Mode:      conf.Auth.UnverifiedPolicy,
+		TaskLimit: conf.Auth.UnverifiedTaskLimit,
+	}
+	passwordPolicy := usecase.PasswordPolicy{
+		MinLength: conf.Auth.PasswordMinLength,
+		MaxBytes:  conf.Auth.PasswordMaxBytes,
+	}
+	if conf.Auth.BreachedPasswordsFile != "" {
+		breached, err := security.LoadBreachedPasswords(conf.Auth.BreachedPasswordsFile)
+		if err != nil {
+			logger.Fatal("Breached password list setup error: " + err.Error())
+		}
+		logger.Info("Loaded breached password list", "file", conf.Auth.BreachedPasswordsFile, "hashes", breached.Len())
+		passwordPolicy.Breached = breached
+	}
+	passwordHasher, err := security.NewPasswordHasher(&conf.Auth)
+	if err != nil {
+		logger.Fatal("Password hasher setup error: " + err.Error())
+	}
+	keyRing, err := security.NewKeyRing(&conf.Auth)
+	if err != nil {
+		logger.Fatal("JWT key setup error: " + err.Error())
+	}
+
+	userRepository := gateway.NewUserRepository(db)
 	taskRepository := gateway.NewTaskRepository(db)
-	taskUseCase := usecase.NewTaskUseCase(taskRepository)
+	taskUseCase := usecase.NewTaskUseCase(taskRepository, userRepository, verificationPolicy)
 	taskHandler := handler.NewTaskHandler(taskUseCase)
 
-	userRepository := gateway.NewUserRepository(db)
-	userUseCase := usecase.NewUserUseCase(userRepository)
-	userHandler := handler.NewUserHandler(userUseCase)
+	emailVerificationUseCase := usecase.NewEmailVerificationUseCase(userRepository, gateway.NewEmailVerificationTokenRepository(db),
+		m, usecase.EmailVerificationConfig{
+			URL:            conf.Auth.EmailVerificationURL,
+			TTL:            conf.Auth.EmailVerificationTTL,
+			ResendInterval: conf.Auth.EmailVerificationResendInterval,
+		})
+	emailVerificationHandler := handler.NewEmailVerificationHandler(emailVerificationUseCase)
+
+	// 複数台のサーバーで失敗の回数を共有するため、既定では DB に保存する
+	loginAttemptRepository := gateway.NewLoginAttemptRepository(db)
+	if conf.Auth.LoginAttemptStore == "memory" {
+		loginAttemptRepository = gateway.NewMemoryLoginAttemptRepository()
+	}
+	loginThrottle := usecase.NewLoginThrottle(loginAttemptRepository, gateway.NewAuditLogRepository(db), usecase.LoginThrottleConfig{
+		AccountThreshold: conf.Auth.LoginAccountThreshold,
+		IPThreshold:      conf.Auth.LoginIPThreshold,
+		LockoutDuration:  conf.Auth.LoginLockoutDuration,
+		BackoffBase:      conf.Auth.LoginBackoffBase,
+		FailureWindow:    conf.Auth.LoginFailureWindow,
+	})
+
+	twoFactorUseCase := usecase.NewTwoFactorUseCase(userRepository, gateway.NewTOTPRepository(db), passwordHasher, loginThrottle, usecase.TwoFactorConfig{
+		Issuer: conf.Auth.TOTPIssuer,
+	})
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorUseCase)
+
+	refreshTokenRepository := gateway.NewRefreshTokenRepository(db)
+	sessionRepository := gateway.NewSessionRepository(db)
+	personalAccessTokenRepository := gateway.NewPersonalAccessTokenRepository(db)
+	userUseCase := usecase.NewUserUseCase(userRepository, refreshTokenRepository, sessionRepository, personalAccessTokenRepository, gateway.NewLoginChallengeRepository(db),
+		emailVerificationUseCase, twoFactorUseCase, loginThrottle, passwordHasher,
+		usecase.TokenConfig{
+			Keys:         keyRing,
+			TTL:          conf.Auth.JWTTTL,
+			RefreshTTL:   conf.Auth.RefreshTokenTTL,
+			ChallengeTTL: conf.Auth.LoginChallengeTTL,
+		}, verificationPolicy, passwordPolicy, m, usecase.EmailChangeConfig{
+			URL:            conf.Auth.EmailChangeURL,
+			TTL:            conf.Auth.EmailChangeTTL,
+			ResendInterval: conf.Auth.EmailChangeResendInterval,
+		})
+	cookieConfig := handler.CookieConfig{
+		Domain: conf.Web.CookieDomain,
+	}
+	userHandler := handler.NewUserHandler(userUseCase, cookieConfig)
+	passwordResetUseCase := usecase.NewPasswordResetUseCase(userRepository, gateway.NewPasswordResetTokenRepository(db),
+		sessionRepository, refreshTokenRepository, personalAccessTokenRepository, m, passwordHasher, usecase.PasswordResetConfig{
+			URL:            conf.Auth.PasswordResetURL,
+			TTL:            conf.Auth.PasswordResetTTL,
+			ResendInterval: conf.Auth.PasswordResetResendInterval,
+		}, passwordPolicy)
+	passwordResetHandler := handler.NewPasswordResetHandler(passwordResetUseCase)
+	personalAccessTokenUseCase := usecase.NewPersonalAccessTokenUseCase(personalAccessTokenRepository)
+	personalAccessTokenHandler := handler.NewPersonalAccessTokenHandler(personalAccessTokenUseCase)
+	// すべてのプロバイダーで同じコールバックを使い、どのプロバイダーかは state から分かる
+	oidcProviders := map[string]usecase.OIDCProvider{}
+	for name, provider := range conf.Auth.OIDCProviders {
+		oidcProviders[name] = oidc.NewProvider(oidc.Config{
+			Issuer:       provider.Issuer,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			Scopes:       provider.Scopes,
+			RedirectURL:  conf.Auth.OIDCCallbackURL,
+		})
+	}
+	oidcUseCase := usecase.NewOIDCUseCase(userRepository, gateway.NewUserIdentityRepository(db), gateway.NewOIDCLoginStateRepository(db),
+		emailVerificationUseCase, userUseCase, usecase.OIDCConfig{
+			Providers: oidcProviders,
+			LoginTTL:  conf.Auth.OIDCLoginTTL,
+		})
+	oidcHandler := handler.NewOIDCHandler(oidcUseCase, cookieConfig, handler.OIDCConfig{
+		ReturnURL: conf.Auth.OIDCReturnURL,
+	})
+	jwtMiddleware := custommiddleware.JWTMiddleware(keyRing, userUseCase, personalAccessTokenUseCase)
+	sessionOnly := custommiddleware.SessionOnly
 
 	// ユーザー用エンドポイント
+	// パーソナルアクセストークンで使えるのは SessionOnly を付けていないものだけ。スコープはユースケースで確かめる
 	users := router.Group("/api/v1/users")
-	users.Use(custommiddleware.JWTMiddleware())
+	users.Use(jwtMiddleware, openAPIValidator)
 	users.GET("", userHandler.GetCurrentUser)
-	users.DELETE("", userHandler.DeleteUser)
+	users.DELETE("", userHandler.DeleteUser, sessionOnly)
+	users.PATCH("/password", userHandler.ChangePassword, sessionOnly)
+	users.POST("/email", userHandler.RequestEmailChange, sessionOnly)
+	users.GET("/sessions", userHandler.ListSessions, sessionOnly)
+	users.DELETE("/sessions", userHandler.RevokeOtherSessions, sessionOnly)
+	users.DELETE("/sessions/:id", userHandler.RevokeSession, sessionOnly)
+	users.GET("/totp", twoFactorHandler.Status, sessionOnly)
+	users.POST("/totp", twoFactorHandler.Enroll, sessionOnly)
+	users.GET("/totp/qr", twoFactorHandler.QRCode, sessionOnly)
+	users.POST("/totp/confirm", twoFactorHandler.Confirm, sessionOnly)
+	users.POST("/totp/disable", twoFactorHandler.Disable, sessionOnly)
+	users.GET("/tokens", personalAccessTokenHandler.List, sessionOnly)
+	users.POST("/tokens", personalAccessTokenHandler.Create, sessionOnly)
+	users.DELETE("/tokens/:id", personalAccessTokenHandler.Revoke, sessionOnly)
+	users.GET("/identities", oidcHandler.ListIdentities, sessionOnly)
+	users.POST("/identities/:provider", oidcHandler.Link, sessionOnly)
+	users.DELETE("/identities/:provider", oidcHandler.Unlink, sessionOnly)
 
 	// 認証用エンドポイント
-	auth := router.Group("/api/v1/auth")
+	auth := router.Group("/api/v1/auth", openAPIValidator)
 	auth.POST("/login", userHandler.Login)
+	auth.POST("/login/totp", userHandler.LoginTOTP)
 	auth.POST("/signup", userHandler.Signup)
+	auth.POST("/refresh", userHandler.Refresh)
 	auth.POST("/logout", userHandler.Logout)
+	auth.POST("/password/forgot", passwordResetHandler.ForgotPassword)
+	auth.POST("/password/reset", passwordResetHandler.ResetPassword)
+	auth.GET("/verify", emailVerificationHandler.Verify)
+	auth.POST("/verify/resend", emailVerificationHandler.Resend)
+	auth.GET("/email/confirm", userHandler.ConfirmEmailChange)
 	auth.GET("/csrf", userHandler.CsrfToken)
+	auth.GET("/oidc", oidcHandler.Providers)
+	auth.GET("/oidc/callback", oidcHandler.Callback)
+	auth.GET("/oidc/:provider", oidcHandler.Login)
 
 	// 認証が必要なタスク用エンドポイント
 	tasks := router.Group("/api/v1/tasks")
-	tasks.Use(custommiddleware.JWTMiddleware())	
+	tasks.Use(jwtMiddleware, openAPIValidator)
 	tasks.POST("", taskHandler.CreateTask)
 	tasks.GET("", taskHandler.GetAllTasks)
 	tasks.GET("/:id", taskHandler.GetTaskById)
@@ -124,7 +280,22 @@ func NewEchoRouter(db *gorm.DB) *echo.Echo {
 
 	// Swagger やその他のルート
 	router.GET("/", handler.Index)
-	router.GET("/health", handler.Health)
+	// /health は以前からの監視設定のために残している。/health/live と同じ
+	router.GET("/health", health.Live)
+	router.GET("/health/live", health.Live)
+	router.GET("/health/ready", health.Ready)
+	// 他のサービスがアクセストークンを検証するための公開鍵
+	router.GET("/.well-known/jwks.json", handler.NewJWKSHandler(keyRing).Keys)
+
+	// 負荷試験などでコネクションプールを調整するための統計
+	if conf.Web.DebugEndpoints {
+		sqlDB, err := db.DB()
+		if err != nil {
+			logger.Fatal("Debug endpoints setup error: " + err.Error())
+		}
+		debugHandler := handler.NewDebugHandler(sqlDB)
+		router.GET("/debug/db/stats", debugHandler.DBStats)
+	}
 
 	return router
 }
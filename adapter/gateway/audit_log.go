@@ -0,0 +1,27 @@
+package gateway
+
+import (
+	"context"
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/entity"
+)
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *entity.AuditLog) error
+}
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{db}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	if err := r.db.WithContext(ctx).Create(log).Error; err != nil {
+		return translateError(r.db, err, "audit log")
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// ErrEmailVerificationTokenAlreadyUsed は使用済みの確認トークンでもう一度確認しようとしたことを表す
+var ErrEmailVerificationTokenAlreadyUsed = apperror.NewUnauthorized("email verification token has already been used")
+
+type EmailVerificationTokenRepository interface {
+	Create(ctx context.Context, token *entity.EmailVerificationToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*entity.EmailVerificationToken, error)
+	// FindLatest は userID に最後に発行したトークンを返す。再送の間隔の確認に使う
+	FindLatest(ctx context.Context, userID int) (*entity.EmailVerificationToken, error)
+	// Verify は token を使用済みにし、ユーザーのメールアドレスを確認済みにする
+	// 同じユーザーの未使用のトークンもすべて使えなくする
+	// token が既に使用済みの場合は ErrEmailVerificationTokenAlreadyUsed を返す
+	Verify(ctx context.Context, token *entity.EmailVerificationToken, now time.Time) error
+}
+
+type emailVerificationTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailVerificationTokenRepository(db *gorm.DB) EmailVerificationTokenRepository {
+	return &emailVerificationTokenRepository{db}
+}
+
+func (r *emailVerificationTokenRepository) Create(ctx context.Context, token *entity.EmailVerificationToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		return translateError(r.db, err, "email verification token")
+	}
+	return nil
+}
+
+func (r *emailVerificationTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.EmailVerificationToken, error) {
+	token := &entity.EmailVerificationToken{}
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(token).Error; err != nil {
+		return nil, translateError(r.db, err, "email verification token")
+	}
+	return token, nil
+}
+
+func (r *emailVerificationTokenRepository) FindLatest(ctx context.Context, userID int) (*entity.EmailVerificationToken, error) {
+	token := &entity.EmailVerificationToken{}
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Order("id DESC").First(token).Error; err != nil {
+		return nil, translateError(r.db, err, "email verification token")
+	}
+	return token, nil
+}
+
+func (r *emailVerificationTokenRepository) Verify(ctx context.Context, token *entity.EmailVerificationToken, now time.Time) error {
+	return consumeSingleUseToken(ctx, r.db, &entity.EmailVerificationToken{}, &token.SingleUseToken, now, ErrEmailVerificationTokenAlreadyUsed, func(user *gorm.DB) error {
+		// 確認済みの日時は最初に確認したときのまま残す
+		return user.Where("email_verified_at IS NULL").Update("email_verified_at", now).Error
+	})
+}
@@ -0,0 +1,86 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type EmailVerificationTokenRepositorySuite struct {
+	tester.DBSuite
+	repository gateway.EmailVerificationTokenRepository
+}
+
+func TestEmailVerificationTokenRepositorySuite(t *testing.T) {
+	suite.Run(t, new(EmailVerificationTokenRepositorySuite))
+}
+
+func (suite *EmailVerificationTokenRepositorySuite) SetupSuite() {
+	suite.DBSuite.SetupSuite()
+	suite.repository = gateway.NewEmailVerificationTokenRepository(suite.DB)
+}
+
+func (suite *EmailVerificationTokenRepositorySuite) createUser(email string) int {
+	user, err := gateway.NewUserRepository(suite.DB).Signup(context.Background(), &entity.User{Email: email, Password: "password"})
+	suite.Require().Nil(err)
+	return user.ID
+}
+
+func (suite *EmailVerificationTokenRepositorySuite) createToken(userID int, tokenHash string, createdAt time.Time) *entity.EmailVerificationToken {
+	token := &entity.EmailVerificationToken{SingleUseToken: entity.SingleUseToken{UserID: userID, TokenHash: tokenHash, ExpiresAt: createdAt.Add(time.Hour), CreatedAt: createdAt}}
+	suite.Require().Nil(suite.repository.Create(context.Background(), token))
+	return token
+}
+
+func (suite *EmailVerificationTokenRepositorySuite) TestVerify() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("verify@example.com")
+	otherID := suite.createUser("verify-other@example.com")
+	suite.createToken(userID, "verify-hash-1", now)
+	suite.createToken(userID, "verify-hash-2", now)
+	suite.createToken(otherID, "verify-hash-other", now)
+
+	token, err := suite.repository.FindByHash(context.Background(), "verify-hash-1")
+	suite.Assert().Nil(err)
+	suite.Assert().True(token.IsUsable(now))
+
+	suite.Assert().Nil(suite.repository.Verify(context.Background(), token, now))
+	user, err := gateway.NewUserRepository(suite.DB).GetCurrentUser(context.Background(), userID)
+	suite.Assert().Nil(err)
+	suite.Assert().True(user.IsEmailVerified())
+	other, _ := gateway.NewUserRepository(suite.DB).GetCurrentUser(context.Background(), otherID)
+	suite.Assert().False(other.IsEmailVerified())
+
+	// 同じユーザーの他のトークンも使えなくなり、他のユーザーのトークンはそのまま
+	for hash, usable := range map[string]bool{"verify-hash-1": false, "verify-hash-2": false, "verify-hash-other": true} {
+		found, err := suite.repository.FindByHash(context.Background(), hash)
+		suite.Assert().Nil(err)
+		suite.Assert().Equal(usable, found.IsUsable(now), hash)
+	}
+
+	// 2 回目は使えない
+	err = suite.repository.Verify(context.Background(), token, now.Add(time.Minute))
+	suite.Assert().True(errors.Is(err, gateway.ErrEmailVerificationTokenAlreadyUsed))
+}
+
+func (suite *EmailVerificationTokenRepositorySuite) TestFindLatest() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("latest@example.com")
+	suite.createToken(userID, "latest-hash-1", now.Add(-time.Hour))
+	suite.createToken(userID, "latest-hash-2", now)
+
+	latest, err := suite.repository.FindLatest(context.Background(), userID)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal("latest-hash-2", latest.TokenHash)
+
+	_, err = suite.repository.FindLatest(context.Background(), suite.createUser("no-token@example.com"))
+	suite.Assert().True(apperror.IsNotFound(err))
+}
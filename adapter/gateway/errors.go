@@ -0,0 +1,31 @@
+package gateway
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// translateError は GORM・DB ドライバのエラーを apperror に変換する
+// resource は "task" などエラーメッセージに使うリソース名
+// 変換できないエラーはそのまま返し、コントローラ側で 500 として扱う
+func translateError(db *gorm.DB, err error, resource string) error {
+	if err == nil {
+		return nil
+	}
+	// ドライバごとのエラー（MySQL の 1062 など）を GORM 共通のエラーに揃える
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		err = translator.Translate(err)
+	}
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return apperror.Wrap(apperror.KindNotFound, resource+" not found", err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return apperror.Wrap(apperror.KindConflict, resource+" already exists", err)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return apperror.Wrap(apperror.KindConflict, resource+" references a missing or in-use record", err)
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package gateway
+
+import (
+	"errors"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// 各 DB ドライバが返すエラーが同じ apperror に変換されることを確認する
+func TestTranslateError(t *testing.T) {
+	dialectors := map[string]gorm.Dialector{
+		"mysql":    mysql.New(mysql.Config{}),
+		"postgres": postgres.New(postgres.Config{}),
+		"sqlite":   sqlite.Open(""),
+	}
+	uniqueErrors := map[string]error{
+		"mysql":    &mysqldriver.MySQLError{Number: 1062, Message: "Duplicate entry"},
+		"postgres": &pgconn.PgError{Code: "23505", Message: "duplicate key value violates unique constraint"},
+		"sqlite":   sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique},
+	}
+	foreignKeyErrors := map[string]error{
+		"mysql":    &mysqldriver.MySQLError{Number: 1452, Message: "Cannot add or update a child row"},
+		"postgres": &pgconn.PgError{Code: "23503", Message: "violates foreign key constraint"},
+		"sqlite":   sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintForeignKey},
+	}
+
+	for name, dialector := range dialectors {
+		t.Run(name, func(t *testing.T) {
+			db := &gorm.DB{Config: &gorm.Config{Dialector: dialector}}
+
+			err := translateError(db, uniqueErrors[name], "user")
+			assert.True(t, apperror.IsConflict(err))
+			assert.Equal(t, "user already exists", err.(*apperror.Error).Message)
+
+			err = translateError(db, foreignKeyErrors[name], "task")
+			assert.True(t, apperror.IsConflict(err))
+			assert.Equal(t, "task references a missing or in-use record", err.(*apperror.Error).Message)
+
+			err = translateError(db, gorm.ErrRecordNotFound, "task")
+			assert.True(t, apperror.IsNotFound(err))
+
+			unknown := errors.New("connection refused")
+			assert.Equal(t, unknown, translateError(db, unknown, "task"))
+		})
+	}
+}
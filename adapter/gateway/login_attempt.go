@@ -0,0 +1,70 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-todo-app-clean-arch/entity"
+)
+
+// LoginAttemptRepository はログインの失敗の回数を保存する
+// サーバーを複数台で動かす場合は DB の実装を使う。メモリの実装はサーバーごとに数える
+type LoginAttemptRepository interface {
+	// Find は kind・identifier の記録を返す。記録がない場合は NotFound を返す
+	Find(ctx context.Context, kind, identifier string) (*entity.LoginAttempt, error)
+	// RecordFailure は失敗を 1 回数え、数えた後の記録を返す
+	// 前回の失敗から window 以上経っている場合は 1 回目として数え直す
+	RecordFailure(ctx context.Context, kind, identifier string, now time.Time, window time.Duration) (*entity.LoginAttempt, error)
+	// Lock は lockedUntil までロックし、失敗の回数を 0 に戻す
+	Lock(ctx context.Context, kind, identifier string, lockedUntil time.Time) error
+	// Reset は記録を消す。記録がない場合もエラーにしない
+	Reset(ctx context.Context, kind, identifier string) error
+}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptRepository {
+	return &loginAttemptRepository{db}
+}
+
+func (r *loginAttemptRepository) Find(ctx context.Context, kind, identifier string) (*entity.LoginAttempt, error) {
+	attempt := &entity.LoginAttempt{}
+	if err := r.db.WithContext(ctx).Where("kind = ? AND identifier = ?", kind, identifier).First(attempt).Error; err != nil {
+		return nil, translateError(r.db, err, "login attempt")
+	}
+	return attempt, nil
+}
+
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, kind, identifier string, now time.Time, window time.Duration) (*entity.LoginAttempt, error) {
+	// 複数のサーバーから同時に数えても取りこぼさないよう、1 つの文で追加・加算する
+	// MySQL は左から順に代入するので、failures を last_failed_at より先に更新する
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "kind"}, {Name: "identifier"}},
+		DoUpdates: []clause.Assignment{
+			{Column: clause.Column{Name: "failures"}, Value: gorm.Expr(
+				"CASE WHEN login_attempts.last_failed_at > ? THEN login_attempts.failures + 1 ELSE 1 END", now.Add(-window))},
+			{Column: clause.Column{Name: "last_failed_at"}, Value: now},
+		},
+	}).Create(&entity.LoginAttempt{Kind: kind, Identifier: identifier, Failures: 1, LastFailedAt: now}).Error
+	if err != nil {
+		return nil, translateError(r.db, err, "login attempt")
+	}
+	return r.Find(ctx, kind, identifier)
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, kind, identifier string, lockedUntil time.Time) error {
+	err := r.db.WithContext(ctx).Model(&entity.LoginAttempt{}).
+		Where("kind = ? AND identifier = ?", kind, identifier).
+		Updates(map[string]interface{}{"failures": 0, "locked_until": lockedUntil}).Error
+	return translateError(r.db, err, "login attempt")
+}
+
+func (r *loginAttemptRepository) Reset(ctx context.Context, kind, identifier string) error {
+	err := r.db.WithContext(ctx).Where("kind = ? AND identifier = ?", kind, identifier).Delete(&entity.LoginAttempt{}).Error
+	return translateError(r.db, err, "login attempt")
+}
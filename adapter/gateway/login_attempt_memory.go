@@ -0,0 +1,68 @@
+package gateway
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// memoryLoginAttemptRepository はサーバーのメモリにログインの失敗を記録する
+// 再起動すると記録は消え、複数台のサーバーの間では共有しない
+type memoryLoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[[2]string]entity.LoginAttempt
+}
+
+func NewMemoryLoginAttemptRepository() LoginAttemptRepository {
+	return &memoryLoginAttemptRepository{attempts: map[[2]string]entity.LoginAttempt{}}
+}
+
+func (r *memoryLoginAttemptRepository) Find(ctx context.Context, kind, identifier string) (*entity.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	attempt, ok := r.attempts[[2]string{kind, identifier}]
+	if !ok {
+		return nil, apperror.NewNotFound("login attempt not found")
+	}
+	return &attempt, nil
+}
+
+func (r *memoryLoginAttemptRepository) RecordFailure(ctx context.Context, kind, identifier string, now time.Time, window time.Duration) (*entity.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := [2]string{kind, identifier}
+	attempt, ok := r.attempts[key]
+	if !ok {
+		attempt = entity.LoginAttempt{Kind: kind, Identifier: identifier}
+	}
+	if attempt.LastFailedAt.After(now.Add(-window)) {
+		attempt.Failures++
+	} else {
+		attempt.Failures = 1
+	}
+	attempt.LastFailedAt = now
+	r.attempts[key] = attempt
+	return &attempt, nil
+}
+
+func (r *memoryLoginAttemptRepository) Lock(ctx context.Context, kind, identifier string, lockedUntil time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := [2]string{kind, identifier}
+	if attempt, ok := r.attempts[key]; ok {
+		attempt.Failures = 0
+		attempt.LockedUntil = &lockedUntil
+		r.attempts[key] = attempt
+	}
+	return nil
+}
+
+func (r *memoryLoginAttemptRepository) Reset(ctx context.Context, kind, identifier string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.attempts, [2]string{kind, identifier})
+	return nil
+}
@@ -0,0 +1,90 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// LoginAttemptRepositorySuite は DB とメモリの実装が同じように数えることを確認する
+type LoginAttemptRepositorySuite struct {
+	tester.DBSuite
+	repositories map[string]gateway.LoginAttemptRepository
+}
+
+func TestLoginAttemptRepositorySuite(t *testing.T) {
+	suite.Run(t, new(LoginAttemptRepositorySuite))
+}
+
+func (suite *LoginAttemptRepositorySuite) SetupSuite() {
+	suite.DBSuite.SetupSuite()
+	suite.repositories = map[string]gateway.LoginAttemptRepository{
+		"database": gateway.NewLoginAttemptRepository(suite.DB),
+		"memory":   gateway.NewMemoryLoginAttemptRepository(),
+	}
+}
+
+func (suite *LoginAttemptRepositorySuite) TestRecordFailure() {
+	now := time.Now().UTC().Truncate(time.Second)
+	for name, repository := range suite.repositories {
+		_, err := repository.Find(context.Background(), entity.LoginAttemptAccount, "count@example.com")
+		suite.Assert().True(apperror.IsNotFound(err), name)
+
+		for i := 1; i <= 3; i++ {
+			attempt, err := repository.RecordFailure(context.Background(), entity.LoginAttemptAccount, "count@example.com", now.Add(time.Duration(i)*time.Second), time.Minute)
+			suite.Require().Nil(err, name)
+			suite.Assert().Equal(i, attempt.Failures, name)
+			suite.Assert().True(now.Add(time.Duration(i)*time.Second).Equal(attempt.LastFailedAt), name)
+		}
+		// 種類が違えば別に数える
+		attempt, err := repository.RecordFailure(context.Background(), entity.LoginAttemptIP, "count@example.com", now, time.Minute)
+		suite.Require().Nil(err, name)
+		suite.Assert().Equal(1, attempt.Failures, name)
+
+		// 前回から window 以上空くと数え直す
+		attempt, err = repository.RecordFailure(context.Background(), entity.LoginAttemptAccount, "count@example.com", now.Add(3*time.Second+time.Minute), time.Minute)
+		suite.Require().Nil(err, name)
+		suite.Assert().Equal(1, attempt.Failures, name)
+	}
+}
+
+func (suite *LoginAttemptRepositorySuite) TestLockAndReset() {
+	now := time.Now().UTC().Truncate(time.Second)
+	for name, repository := range suite.repositories {
+		for i := 0; i < 3; i++ {
+			_, err := repository.RecordFailure(context.Background(), entity.LoginAttemptIP, "192.0.2.1", now, time.Minute)
+			suite.Require().Nil(err, name)
+		}
+
+		suite.Assert().Nil(repository.Lock(context.Background(), entity.LoginAttemptIP, "192.0.2.1", now.Add(time.Hour)), name)
+		attempt, err := repository.Find(context.Background(), entity.LoginAttemptIP, "192.0.2.1")
+		suite.Require().Nil(err, name)
+		suite.Assert().Equal(0, attempt.Failures, name)
+		suite.Assert().True(attempt.IsLocked(now), name)
+
+		// ロック中も数えられ、ロックは残る
+		attempt, err = repository.RecordFailure(context.Background(), entity.LoginAttemptIP, "192.0.2.1", now, time.Minute)
+		suite.Require().Nil(err, name)
+		suite.Assert().Equal(1, attempt.Failures, name)
+		suite.Assert().True(attempt.IsLocked(now), name)
+
+		suite.Assert().Nil(repository.Reset(context.Background(), entity.LoginAttemptIP, "192.0.2.1"), name)
+		_, err = repository.Find(context.Background(), entity.LoginAttemptIP, "192.0.2.1")
+		suite.Assert().True(apperror.IsNotFound(err), name)
+		// 記録がなくてもエラーにしない
+		suite.Assert().Nil(repository.Reset(context.Background(), entity.LoginAttemptIP, "192.0.2.1"), name)
+	}
+}
+
+func (suite *LoginAttemptRepositorySuite) TestAuditLog() {
+	log := &entity.AuditLog{Action: entity.AuditLoginLocked, Target: "ip:192.0.2.1", IPAddress: "192.0.2.1", Detail: "locked"}
+	suite.Assert().Nil(gateway.NewAuditLogRepository(suite.DB).Create(context.Background(), log))
+	suite.Assert().NotZero(log.ID)
+}
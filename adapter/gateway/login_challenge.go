@@ -0,0 +1,76 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// ErrLoginChallengeUnusable は使用済み、または入力できる回数を使い切ったチャレンジを使おうとしたことを表す
+var ErrLoginChallengeUnusable = apperror.NewUnauthorized("login challenge can no longer be used")
+
+type LoginChallengeRepository interface {
+	Create(ctx context.Context, challenge *entity.LoginChallenge) error
+	FindByHash(ctx context.Context, tokenHash string) (*entity.LoginChallenge, error)
+	// RecordAttempt は 2 要素目の入力の前に試行回数を 1 つ増やす
+	// 使用済み・回数を使い切っている場合は ErrLoginChallengeUnusable を返す
+	RecordAttempt(ctx context.Context, challenge *entity.LoginChallenge) error
+	// Consume は challenge を使用済みにする。既に使用済みの場合は ErrLoginChallengeUnusable を返す
+	Consume(ctx context.Context, challenge *entity.LoginChallenge, now time.Time) error
+}
+
+type loginChallengeRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginChallengeRepository(db *gorm.DB) LoginChallengeRepository {
+	return &loginChallengeRepository{db}
+}
+
+func (r *loginChallengeRepository) Create(ctx context.Context, challenge *entity.LoginChallenge) error {
+	if err := r.db.WithContext(ctx).Create(challenge).Error; err != nil {
+		return translateError(r.db, err, "login challenge")
+	}
+	return nil
+}
+
+func (r *loginChallengeRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.LoginChallenge, error) {
+	challenge := &entity.LoginChallenge{}
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(challenge).Error; err != nil {
+		return nil, translateError(r.db, err, "login challenge")
+	}
+	return challenge, nil
+}
+
+func (r *loginChallengeRepository) RecordAttempt(ctx context.Context, challenge *entity.LoginChallenge) error {
+	// 同時に入力されても上限を超えて試せないよう、条件付きの UPDATE で数える
+	result := r.db.WithContext(ctx).Model(&entity.LoginChallenge{}).
+		Where("id = ? AND used_at IS NULL AND attempts < ?", challenge.ID, entity.LoginChallengeMaxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		return translateError(r.db, result.Error, "login challenge")
+	}
+	if result.RowsAffected == 0 {
+		return ErrLoginChallengeUnusable
+	}
+	challenge.Attempts++
+	return nil
+}
+
+func (r *loginChallengeRepository) Consume(ctx context.Context, challenge *entity.LoginChallenge, now time.Time) error {
+	result := r.db.WithContext(ctx).Model(&entity.LoginChallenge{}).
+		Where("id = ? AND used_at IS NULL", challenge.ID).
+		Update("used_at", now)
+	if result.Error != nil {
+		return translateError(r.db, result.Error, "login challenge")
+	}
+	if result.RowsAffected == 0 {
+		return ErrLoginChallengeUnusable
+	}
+	challenge.UsedAt = &now
+	return nil
+}
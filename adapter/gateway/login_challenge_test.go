@@ -0,0 +1,66 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type LoginChallengeRepositorySuite struct {
+	tester.DBSuite
+	repository gateway.LoginChallengeRepository
+}
+
+func TestLoginChallengeRepositorySuite(t *testing.T) {
+	suite.Run(t, new(LoginChallengeRepositorySuite))
+}
+
+func (suite *LoginChallengeRepositorySuite) SetupSuite() {
+	suite.DBSuite.SetupSuite()
+	suite.repository = gateway.NewLoginChallengeRepository(suite.DB)
+}
+
+func (suite *LoginChallengeRepositorySuite) createChallenge(email, tokenHash string, now time.Time) *entity.LoginChallenge {
+	user, err := gateway.NewUserRepository(suite.DB).Signup(context.Background(), &entity.User{Email: email, Password: "password"})
+	suite.Require().Nil(err)
+	challenge := &entity.LoginChallenge{SingleUseToken: entity.SingleUseToken{UserID: user.ID, TokenHash: tokenHash, ExpiresAt: now.Add(5 * time.Minute)}}
+	suite.Require().Nil(suite.repository.Create(context.Background(), challenge))
+	return challenge
+}
+
+func (suite *LoginChallengeRepositorySuite) TestRecordAttempt() {
+	now := time.Now().UTC().Truncate(time.Second)
+	challenge := suite.createChallenge("challenge-attempt@example.com", "attempt-hash", now)
+
+	for i := 0; i < entity.LoginChallengeMaxAttempts; i++ {
+		suite.Assert().Nil(suite.repository.RecordAttempt(context.Background(), challenge))
+	}
+	// 回数を使い切ったら入力できない
+	err := suite.repository.RecordAttempt(context.Background(), challenge)
+	suite.Assert().True(errors.Is(err, gateway.ErrLoginChallengeUnusable))
+
+	found, err := suite.repository.FindByHash(context.Background(), "attempt-hash")
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(entity.LoginChallengeMaxAttempts, found.Attempts)
+	suite.Assert().False(found.IsUsable(now))
+}
+
+func (suite *LoginChallengeRepositorySuite) TestConsume() {
+	now := time.Now().UTC().Truncate(time.Second)
+	challenge := suite.createChallenge("challenge-consume@example.com", "consume-hash", now)
+
+	suite.Assert().Nil(suite.repository.Consume(context.Background(), challenge, now))
+	suite.Assert().True(errors.Is(suite.repository.Consume(context.Background(), challenge, now), gateway.ErrLoginChallengeUnusable))
+	suite.Assert().True(errors.Is(suite.repository.RecordAttempt(context.Background(), challenge), gateway.ErrLoginChallengeUnusable))
+
+	_, err := suite.repository.FindByHash(context.Background(), "missing")
+	suite.Assert().True(apperror.IsNotFound(err))
+}
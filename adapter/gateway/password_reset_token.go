@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// ErrPasswordResetTokenAlreadyUsed は使用済みの再設定トークンでパスワードを変えようとしたことを表す
+var ErrPasswordResetTokenAlreadyUsed = apperror.NewUnauthorized("password reset token has already been used")
+
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, token *entity.PasswordResetToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error)
+	// FindLatest は userID に最後に発行したトークンを返す。再送の間隔の確認に使う
+	FindLatest(ctx context.Context, userID int) (*entity.PasswordResetToken, error)
+	// ResetPassword は token を使用済みにし、ユーザーのパスワードを hashedPassword に変える
+	// 同じユーザーの未使用のトークンもすべて使えなくする
+	// token が既に使用済みの場合は ErrPasswordResetTokenAlreadyUsed を返す
+	ResetPassword(ctx context.Context, token *entity.PasswordResetToken, hashedPassword string, now time.Time) error
+}
+
+type passwordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetTokenRepository(db *gorm.DB) PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{db}
+}
+
+func (r *passwordResetTokenRepository) Create(ctx context.Context, token *entity.PasswordResetToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		return translateError(r.db, err, "password reset token")
+	}
+	return nil
+}
+
+func (r *passwordResetTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	token := &entity.PasswordResetToken{}
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(token).Error; err != nil {
+		return nil, translateError(r.db, err, "password reset token")
+	}
+	return token, nil
+}
+
+func (r *passwordResetTokenRepository) FindLatest(ctx context.Context, userID int) (*entity.PasswordResetToken, error) {
+	token := &entity.PasswordResetToken{}
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Order("id DESC").First(token).Error; err != nil {
+		return nil, translateError(r.db, err, "password reset token")
+	}
+	return token, nil
+}
+
+func (r *passwordResetTokenRepository) ResetPassword(ctx context.Context, token *entity.PasswordResetToken, hashedPassword string, now time.Time) error {
+	return consumeSingleUseToken(ctx, r.db, &entity.PasswordResetToken{}, &token.SingleUseToken, now, ErrPasswordResetTokenAlreadyUsed, func(user *gorm.DB) error {
+		result := user.Update("password", hashedPassword)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
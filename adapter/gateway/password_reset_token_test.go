@@ -0,0 +1,92 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type PasswordResetTokenRepositorySuite struct {
+	tester.DBSuite
+	repository gateway.PasswordResetTokenRepository
+}
+
+func TestPasswordResetTokenRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PasswordResetTokenRepositorySuite))
+}
+
+func (suite *PasswordResetTokenRepositorySuite) SetupSuite() {
+	suite.DBSuite.SetupSuite()
+	suite.repository = gateway.NewPasswordResetTokenRepository(suite.DB)
+}
+
+func (suite *PasswordResetTokenRepositorySuite) createUser(email string) int {
+	user, err := gateway.NewUserRepository(suite.DB).Signup(context.Background(), &entity.User{Email: email, Password: "old-password"})
+	suite.Require().Nil(err)
+	return user.ID
+}
+
+func (suite *PasswordResetTokenRepositorySuite) createToken(userID int, tokenHash string, now time.Time) *entity.PasswordResetToken {
+	token := &entity.PasswordResetToken{SingleUseToken: entity.SingleUseToken{UserID: userID, TokenHash: tokenHash, ExpiresAt: now.Add(time.Hour), CreatedAt: now}}
+	suite.Require().Nil(suite.repository.Create(context.Background(), token))
+	return token
+}
+
+func (suite *PasswordResetTokenRepositorySuite) TestResetPassword() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("reset@example.com")
+	otherID := suite.createUser("reset-other@example.com")
+	suite.createToken(userID, "reset-hash-1", now)
+	suite.createToken(userID, "reset-hash-2", now)
+	suite.createToken(otherID, "reset-hash-other", now)
+
+	token, err := suite.repository.FindByHash(context.Background(), "reset-hash-1")
+	suite.Assert().Nil(err)
+	suite.Assert().True(token.IsUsable(now))
+
+	suite.Assert().Nil(suite.repository.ResetPassword(context.Background(), token, "new-password", now))
+	user, err := gateway.NewUserRepository(suite.DB).GetCurrentUser(context.Background(), userID)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal("new-password", user.Password)
+
+	// 同じユーザーの他のトークンも使えなくなり、他のユーザーのトークンはそのまま
+	for hash, usable := range map[string]bool{"reset-hash-1": false, "reset-hash-2": false, "reset-hash-other": true} {
+		found, err := suite.repository.FindByHash(context.Background(), hash)
+		suite.Assert().Nil(err)
+		suite.Assert().Equal(usable, found.IsUsable(now), hash)
+	}
+
+	// 2 回目は使えない
+	err = suite.repository.ResetPassword(context.Background(), token, "another-password", now)
+	suite.Assert().True(errors.Is(err, gateway.ErrPasswordResetTokenAlreadyUsed))
+	user, _ = gateway.NewUserRepository(suite.DB).GetCurrentUser(context.Background(), userID)
+	suite.Assert().Equal("new-password", user.Password)
+}
+
+func (suite *PasswordResetTokenRepositorySuite) TestFindLatest() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("reset-latest@example.com")
+	suite.createToken(userID, "reset-latest-hash-1", now.Add(-time.Hour))
+	suite.createToken(userID, "reset-latest-hash-2", now)
+
+	latest, err := suite.repository.FindLatest(context.Background(), userID)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal("reset-latest-hash-2", latest.TokenHash)
+
+	_, err = suite.repository.FindLatest(context.Background(), suite.createUser("reset-no-token@example.com"))
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+func (suite *PasswordResetTokenRepositorySuite) TestFindByHashNotFound() {
+	token, err := suite.repository.FindByHash(context.Background(), "missing")
+	suite.Assert().Nil(token)
+	suite.Assert().True(apperror.IsNotFound(err))
+}
@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *entity.PersonalAccessToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error)
+	// List は失効していないトークンを新しい順に返す。期限切れのトークンも含める
+	List(ctx context.Context, userID int) ([]*entity.PersonalAccessToken, error)
+	// Touch は最終利用日時だけを更新する
+	Touch(ctx context.Context, id int, lastUsedAt time.Time) error
+	// Revoke は userID のトークンを失効させる。失効済み・他のユーザーのトークンは NotFound を返す
+	Revoke(ctx context.Context, userID, id int, now time.Time) error
+	// RevokeAll は userID の失効していないトークンをすべて失効させる
+	RevokeAll(ctx context.Context, userID int, now time.Time) error
+}
+
+type personalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPersonalAccessTokenRepository(db *gorm.DB) PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{db}
+}
+
+func (r *personalAccessTokenRepository) Create(ctx context.Context, token *entity.PersonalAccessToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		return translateError(r.db, err, "personal access token")
+	}
+	return nil
+}
+
+func (r *personalAccessTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error) {
+	token := &entity.PersonalAccessToken{}
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(token).Error; err != nil {
+		return nil, translateError(r.db, err, "personal access token")
+	}
+	return token, nil
+}
+
+func (r *personalAccessTokenRepository) List(ctx context.Context, userID int) ([]*entity.PersonalAccessToken, error) {
+	tokens := []*entity.PersonalAccessToken{}
+	err := r.db.WithContext(ctx).Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").Order("id DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, translateError(r.db, err, "personal access token")
+	}
+	return tokens, nil
+}
+
+func (r *personalAccessTokenRepository) Touch(ctx context.Context, id int, lastUsedAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&entity.PersonalAccessToken{}).Where("id = ?", id).Update("last_used_at", lastUsedAt).Error
+	return translateError(r.db, err, "personal access token")
+}
+
+func (r *personalAccessTokenRepository) Revoke(ctx context.Context, userID, id int, now time.Time) error {
+	result := r.db.WithContext(ctx).Model(&entity.PersonalAccessToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return translateError(r.db, result.Error, "personal access token")
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NewNotFound("personal access token not found")
+	}
+	return nil
+}
+
+func (r *personalAccessTokenRepository) RevokeAll(ctx context.Context, userID int, now time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&entity.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error; err != nil {
+		return translateError(r.db, err, "personal access token")
+	}
+	return nil
+}
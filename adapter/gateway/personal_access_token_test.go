@@ -0,0 +1,122 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type PersonalAccessTokenRepositorySuite struct {
+	tester.DBSuite
+	repository gateway.PersonalAccessTokenRepository
+}
+
+func TestPersonalAccessTokenRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenRepositorySuite))
+}
+
+func (suite *PersonalAccessTokenRepositorySuite) SetupSuite() {
+	suite.DBSuite.SetupSuite()
+	suite.repository = gateway.NewPersonalAccessTokenRepository(suite.DB)
+}
+
+func (suite *PersonalAccessTokenRepositorySuite) createUser(email string) int {
+	user, err := gateway.NewUserRepository(suite.DB).Signup(context.Background(), &entity.User{Email: email, Password: "password"})
+	suite.Require().Nil(err)
+	return user.ID
+}
+
+func (suite *PersonalAccessTokenRepositorySuite) createToken(userID int, name, tokenHash string, createdAt time.Time) *entity.PersonalAccessToken {
+	token := &entity.PersonalAccessToken{
+		UserID:    userID,
+		Name:      name,
+		TokenHash: tokenHash,
+		Scopes:    "tasks:read tasks:write",
+		CreatedAt: createdAt,
+	}
+	suite.Require().Nil(suite.repository.Create(context.Background(), token))
+	return token
+}
+
+func (suite *PersonalAccessTokenRepositorySuite) TestList() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("pat-list@example.com")
+	otherID := suite.createUser("pat-list-other@example.com")
+	suite.createToken(userID, "old", "pat-list-old", now.Add(-time.Hour))
+	newToken := suite.createToken(userID, "new", "pat-list-new", now)
+	revoked := suite.createToken(userID, "revoked", "pat-list-revoked", now)
+	suite.createToken(otherID, "other", "pat-list-other", now)
+	suite.Assert().Nil(suite.repository.Revoke(context.Background(), userID, revoked.ID, now))
+
+	tokens, err := suite.repository.List(context.Background(), userID)
+	suite.Assert().Nil(err)
+	suite.Assert().Len(tokens, 2)
+	// 新しい順
+	suite.Assert().Equal(newToken.ID, tokens[0].ID)
+	suite.Assert().Equal("old", tokens[1].Name)
+	suite.Assert().Equal([]string{entity.ScopeTasksRead, entity.ScopeTasksWrite}, tokens[0].ScopeList())
+}
+
+func (suite *PersonalAccessTokenRepositorySuite) TestTouch() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("pat-touch@example.com")
+	suite.createToken(userID, "ci", "pat-touch", now)
+
+	token, err := suite.repository.FindByHash(context.Background(), "pat-touch")
+	suite.Assert().Nil(err)
+	suite.Assert().Nil(token.LastUsedAt)
+
+	suite.Assert().Nil(suite.repository.Touch(context.Background(), token.ID, now))
+	token, err = suite.repository.FindByHash(context.Background(), "pat-touch")
+	suite.Assert().Nil(err)
+	suite.Assert().True(now.Equal(*token.LastUsedAt))
+
+	_, err = suite.repository.FindByHash(context.Background(), "missing")
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+func (suite *PersonalAccessTokenRepositorySuite) TestRevoke() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("pat-revoke@example.com")
+	otherID := suite.createUser("pat-revoke-other@example.com")
+	token := suite.createToken(userID, "ci", "pat-revoke", now)
+
+	// 他のユーザーのトークンは NotFound
+	err := suite.repository.Revoke(context.Background(), otherID, token.ID, now)
+	suite.Assert().True(apperror.IsNotFound(err))
+
+	suite.Assert().Nil(suite.repository.Revoke(context.Background(), userID, token.ID, now))
+	found, err := suite.repository.FindByHash(context.Background(), "pat-revoke")
+	suite.Assert().Nil(err)
+	suite.Assert().False(found.IsUsable(now))
+
+	// 失効済みも NotFound
+	err = suite.repository.Revoke(context.Background(), userID, token.ID, now)
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+func (suite *PersonalAccessTokenRepositorySuite) TestRevokeAll() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("pat-revoke-all@example.com")
+	otherID := suite.createUser("pat-revoke-all-other@example.com")
+	suite.createToken(userID, "ci", "pat-revoke-all-1", now)
+	suite.createToken(userID, "deploy", "pat-revoke-all-2", now)
+	suite.createToken(otherID, "ci", "pat-revoke-all-other", now)
+
+	suite.Assert().Nil(suite.repository.RevokeAll(context.Background(), userID, now))
+	tokens, err := suite.repository.List(context.Background(), userID)
+	suite.Assert().Nil(err)
+	suite.Assert().Empty(tokens)
+
+	// 他のユーザーのトークンはそのまま
+	found, err := suite.repository.FindByHash(context.Background(), "pat-revoke-all-other")
+	suite.Assert().Nil(err)
+	suite.Assert().True(found.IsUsable(now))
+}
@@ -0,0 +1,74 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// ErrRefreshTokenAlreadyUsed はローテーション済み・失効済みのトークンで再発行しようとしたことを表す
+var ErrRefreshTokenAlreadyUsed = apperror.NewUnauthorized("refresh token has already been used")
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entity.RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	// Rotate は current を使用済みにし、next を同じトランザクションで保存する
+	// current が既に使用済み・失効済みの場合は ErrRefreshTokenAlreadyUsed を返す
+	Rotate(ctx context.Context, current *entity.RefreshToken, next *entity.RefreshToken, now time.Time) error
+	RevokeFamily(ctx context.Context, familyID string, now time.Time) error
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		return translateError(r.db, err, "refresh token")
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	token := &entity.RefreshToken{}
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(token).Error; err != nil {
+		return nil, translateError(r.db, err, "refresh token")
+	}
+	return token, nil
+}
+
+func (r *refreshTokenRepository) Rotate(ctx context.Context, current *entity.RefreshToken, next *entity.RefreshToken, now time.Time) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 同じトークンで同時に再発行された場合も、使用済みにできるのは 1 つだけにする
+		result := tx.Model(&entity.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", current.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRefreshTokenAlreadyUsed
+		}
+		return tx.Create(next).Error
+	})
+	if errors.Is(err, ErrRefreshTokenAlreadyUsed) {
+		return err
+	}
+	return translateError(r.db, err, "refresh token")
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, now time.Time) error {
+	err := r.db.WithContext(ctx).Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", now).Error
+	return translateError(r.db, err, "refresh token")
+}
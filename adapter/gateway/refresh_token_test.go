@@ -0,0 +1,114 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type RefreshTokenRepositorySuite struct {
+	tester.DBSuite
+	repository gateway.RefreshTokenRepository
+}
+
+func TestRefreshTokenRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RefreshTokenRepositorySuite))
+}
+
+func (suite *RefreshTokenRepositorySuite) SetupSuite() {
+	suite.DBSuite.SetupSuite()
+	suite.repository = gateway.NewRefreshTokenRepository(suite.DB)
+}
+
+func (suite *RefreshTokenRepositorySuite) createUser(email string) int {
+	user, err := gateway.NewUserRepository(suite.DB).Signup(context.Background(), &entity.User{Email: email, Password: "password"})
+	suite.Require().Nil(err)
+	return user.ID
+}
+
+func (suite *RefreshTokenRepositorySuite) newToken(userID int, familyID, tokenHash string, now time.Time) *entity.RefreshToken {
+	return &entity.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(time.Hour),
+	}
+}
+
+func (suite *RefreshTokenRepositorySuite) TestRotate() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("rotate@example.com")
+	current := suite.newToken(userID, "rotate-family", "rotate-hash-1", now)
+	suite.Assert().Nil(suite.repository.Create(context.Background(), current))
+	suite.Assert().NotZero(current.ID)
+
+	found, err := suite.repository.FindByHash(context.Background(), "rotate-hash-1")
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(current.ID, found.ID)
+	suite.Assert().True(found.IsUsable(now))
+
+	next := suite.newToken(userID, "rotate-family", "rotate-hash-2", now)
+	suite.Assert().Nil(suite.repository.Rotate(context.Background(), found, next, now))
+	suite.Assert().NotZero(next.ID)
+
+	used, err := suite.repository.FindByHash(context.Background(), "rotate-hash-1")
+	suite.Assert().Nil(err)
+	suite.Assert().NotNil(used.UsedAt)
+	suite.Assert().False(used.IsUsable(now))
+
+	// 使用済みのトークンでは 2 回目のローテーションはできず、next も保存されない
+	err = suite.repository.Rotate(context.Background(), found, suite.newToken(userID, "rotate-family", "rotate-hash-3", now), now)
+	suite.Assert().True(errors.Is(err, gateway.ErrRefreshTokenAlreadyUsed))
+	_, err = suite.repository.FindByHash(context.Background(), "rotate-hash-3")
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+func (suite *RefreshTokenRepositorySuite) TestRevokeFamily() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("revoke@example.com")
+	suite.Assert().Nil(suite.repository.Create(context.Background(), suite.newToken(userID, "revoke-family", "revoke-hash-1", now)))
+	suite.Assert().Nil(suite.repository.Create(context.Background(), suite.newToken(userID, "revoke-family", "revoke-hash-2", now)))
+	suite.Assert().Nil(suite.repository.Create(context.Background(), suite.newToken(userID, "other-family", "revoke-hash-3", now)))
+
+	suite.Assert().Nil(suite.repository.RevokeFamily(context.Background(), "revoke-family", now))
+
+	for _, hash := range []string{"revoke-hash-1", "revoke-hash-2"} {
+		token, err := suite.repository.FindByHash(context.Background(), hash)
+		suite.Assert().Nil(err)
+		suite.Assert().NotNil(token.RevokedAt)
+	}
+	other, err := suite.repository.FindByHash(context.Background(), "revoke-hash-3")
+	suite.Assert().Nil(err)
+	suite.Assert().Nil(other.RevokedAt)
+
+	// 失効済みのトークンはローテーションできない
+	revoked, _ := suite.repository.FindByHash(context.Background(), "revoke-hash-1")
+	err = suite.repository.Rotate(context.Background(), revoked, suite.newToken(userID, "revoke-family", "revoke-hash-4", now), now)
+	suite.Assert().True(errors.Is(err, gateway.ErrRefreshTokenAlreadyUsed))
+}
+
+func (suite *RefreshTokenRepositorySuite) TestDeletedWithUser() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("cascade@example.com")
+	suite.Assert().Nil(suite.repository.Create(context.Background(), suite.newToken(userID, "cascade-family", "cascade-hash", now)))
+
+	suite.Assert().Nil(gateway.NewUserRepository(suite.DB).DeleteUser(context.Background(), userID))
+	_, err := suite.repository.FindByHash(context.Background(), "cascade-hash")
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+func (suite *RefreshTokenRepositorySuite) TestCreateDuplicateHash() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("duplicate-token@example.com")
+	suite.Assert().Nil(suite.repository.Create(context.Background(), suite.newToken(userID, "duplicate-family", "duplicate-hash", now)))
+	err := suite.repository.Create(context.Background(), suite.newToken(userID, "duplicate-family", "duplicate-hash", now))
+	suite.Assert().True(apperror.IsConflict(err))
+}
@@ -0,0 +1,86 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.Session) error
+	FindByID(ctx context.Context, id string) (*entity.Session, error)
+	// ListActive は失効・期限切れでないセッションを最後に使われた順に返す
+	ListActive(ctx context.Context, userID int, now time.Time) ([]*entity.Session, error)
+	// Touch は最終利用日時だけを更新する
+	Touch(ctx context.Context, id string, lastSeenAt time.Time) error
+	// Renew はリフレッシュトークンの再発行に合わせて接続元と有効期限を更新する
+	Renew(ctx context.Context, session *entity.Session) error
+	// Revoke は userID のセッションを失効させる。失効済み・他のユーザーのセッションは NotFound を返す
+	Revoke(ctx context.Context, userID int, id string, now time.Time) error
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	if err := r.db.WithContext(ctx).Create(session).Error; err != nil {
+		return translateError(r.db, err, "session")
+	}
+	return nil
+}
+
+func (r *sessionRepository) FindByID(ctx context.Context, id string) (*entity.Session, error) {
+	session := &entity.Session{}
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(session).Error; err != nil {
+		return nil, translateError(r.db, err, "session")
+	}
+	return session, nil
+}
+
+func (r *sessionRepository) ListActive(ctx context.Context, userID int, now time.Time) ([]*entity.Session, error) {
+	sessions := []*entity.Session{}
+	err := r.db.WithContext(ctx).Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").Order("id").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, translateError(r.db, err, "session")
+	}
+	return sessions, nil
+}
+
+func (r *sessionRepository) Touch(ctx context.Context, id string, lastSeenAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&entity.Session{}).Where("id = ?", id).Update("last_seen_at", lastSeenAt).Error
+	return translateError(r.db, err, "session")
+}
+
+func (r *sessionRepository) Renew(ctx context.Context, session *entity.Session) error {
+	err := r.db.WithContext(ctx).Model(&entity.Session{}).Where("id = ?", session.ID).Updates(map[string]interface{}{
+		"user_agent":   session.UserAgent,
+		"ip_address":   session.IPAddress,
+		"last_seen_at": session.LastSeenAt,
+		"expires_at":   session.ExpiresAt,
+	}).Error
+	return translateError(r.db, err, "session")
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, userID int, id string, now time.Time) error {
+	result := r.db.WithContext(ctx).Model(&entity.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return translateError(r.db, result.Error, "session")
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NewNotFound("session not found")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type SessionRepositorySuite struct {
+	tester.DBSuite
+	repository gateway.SessionRepository
+}
+
+func TestSessionRepositorySuite(t *testing.T) {
+	suite.Run(t, new(SessionRepositorySuite))
+}
+
+func (suite *SessionRepositorySuite) SetupSuite() {
+	suite.DBSuite.SetupSuite()
+	suite.repository = gateway.NewSessionRepository(suite.DB)
+}
+
+func (suite *SessionRepositorySuite) createUser(email string) int {
+	user, err := gateway.NewUserRepository(suite.DB).Signup(context.Background(), &entity.User{Email: email, Password: "password"})
+	suite.Require().Nil(err)
+	return user.ID
+}
+
+func (suite *SessionRepositorySuite) createSession(userID int, id string, lastSeenAt, expiresAt time.Time) *entity.Session {
+	session := &entity.Session{
+		ID:         id,
+		UserID:     userID,
+		UserAgent:  "Mozilla/5.0",
+		IPAddress:  "192.0.2.1",
+		CreatedAt:  lastSeenAt,
+		LastSeenAt: lastSeenAt,
+		ExpiresAt:  expiresAt,
+	}
+	suite.Require().Nil(suite.repository.Create(context.Background(), session))
+	return session
+}
+
+func (suite *SessionRepositorySuite) TestListActive() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("sessions@example.com")
+	otherID := suite.createUser("other-sessions@example.com")
+	suite.createSession(userID, "list-old", now.Add(-2*time.Hour), now.Add(time.Hour))
+	suite.createSession(userID, "list-new", now.Add(-time.Minute), now.Add(time.Hour))
+	suite.createSession(userID, "list-expired", now.Add(-time.Minute), now)
+	suite.createSession(userID, "list-revoked", now.Add(-time.Minute), now.Add(time.Hour))
+	suite.createSession(otherID, "list-other", now, now.Add(time.Hour))
+	suite.Assert().Nil(suite.repository.Revoke(context.Background(), userID, "list-revoked", now))
+
+	sessions, err := suite.repository.ListActive(context.Background(), userID, now)
+	suite.Assert().Nil(err)
+	suite.Assert().Len(sessions, 2)
+	// 最後に使われた順
+	suite.Assert().Equal("list-new", sessions[0].ID)
+	suite.Assert().Equal("list-old", sessions[1].ID)
+	suite.Assert().Equal("Mozilla/5.0", sessions[0].UserAgent)
+	suite.Assert().Equal("192.0.2.1", sessions[0].IPAddress)
+}
+
+func (suite *SessionRepositorySuite) TestTouchAndRenew() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("renew@example.com")
+	session := suite.createSession(userID, "renew", now.Add(-time.Hour), now.Add(time.Hour))
+
+	suite.Assert().Nil(suite.repository.Touch(context.Background(), "renew", now))
+	found, err := suite.repository.FindByID(context.Background(), "renew")
+	suite.Assert().Nil(err)
+	suite.Assert().True(now.Equal(found.LastSeenAt))
+	suite.Assert().True(session.ExpiresAt.Equal(found.ExpiresAt))
+
+	found.IPAddress = "198.51.100.1"
+	found.ExpiresAt = now.Add(24 * time.Hour)
+	suite.Assert().Nil(suite.repository.Renew(context.Background(), found))
+	renewed, err := suite.repository.FindByID(context.Background(), "renew")
+	suite.Assert().Nil(err)
+	suite.Assert().Equal("198.51.100.1", renewed.IPAddress)
+	suite.Assert().True(now.Add(24 * time.Hour).Equal(renewed.ExpiresAt))
+}
+
+func (suite *SessionRepositorySuite) TestRevoke() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("revoke-session@example.com")
+	otherID := suite.createUser("revoke-other@example.com")
+	suite.createSession(userID, "revoke-mine", now, now.Add(time.Hour))
+
+	// 他のユーザーのセッションは NotFound
+	err := suite.repository.Revoke(context.Background(), otherID, "revoke-mine", now)
+	suite.Assert().True(apperror.IsNotFound(err))
+
+	suite.Assert().Nil(suite.repository.Revoke(context.Background(), userID, "revoke-mine", now))
+	found, err := suite.repository.FindByID(context.Background(), "revoke-mine")
+	suite.Assert().Nil(err)
+	suite.Assert().False(found.IsActive(now))
+
+	// 失効済みも NotFound
+	err = suite.repository.Revoke(context.Background(), userID, "revoke-mine", now)
+	suite.Assert().True(apperror.IsNotFound(err))
+
+	_, err = suite.repository.FindByID(context.Background(), "missing")
+	suite.Assert().True(apperror.IsNotFound(err))
+}
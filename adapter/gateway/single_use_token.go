@@ -0,0 +1,38 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/entity"
+)
+
+// consumeSingleUseToken は 1 つのトランザクションで token を使用済みにし、同じユーザーの未使用のトークンもすべて使えなくしてから
+// updateUser でユーザーを更新する。model は token のテーブルを表すモデル
+// 同じトークンで同時にリクエストされても更新は 1 回だけにし、token が既に使用済みの場合は alreadyUsed を返す
+func consumeSingleUseToken(ctx context.Context, db *gorm.DB, model interface{}, token *entity.SingleUseToken, now time.Time, alreadyUsed error, updateUser func(tx *gorm.DB) error) error {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(model).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return alreadyUsed
+		}
+		if err := tx.Model(model).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+		return updateUser(tx.Model(&entity.User{}).Where("id = ?", token.UserID))
+	})
+	if errors.Is(err, alreadyUsed) {
+		return err
+	}
+	return translateError(db, err, "user")
+}
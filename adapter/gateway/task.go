@@ -1,18 +1,23 @@
 package gateway
 
 import (
-	"github.com/jinzhu/copier"
+	"context"
+	"fmt"
+	"strings"
+
 	"gorm.io/gorm"
 
 	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase/apperror"
 )
 
 type TaskRepository interface {
-	Create(task *entity.Task) (*entity.Task, error)
-	Get(userId int, taskId int) (*entity.Task, error)
-	GetAllTasks(userId int) ([]*entity.Task, error)
-	Save(task *entity.Task, userId int, taskId int) (*entity.Task, error)
-	Delete(userId int, taskId int) error
+	Create(ctx context.Context, task *entity.Task) (*entity.Task, error)
+	Get(ctx context.Context, userId int, taskId int) (*entity.Task, error)
+	Count(ctx context.Context, userId int) (int64, error)
+	List(ctx context.Context, query *entity.TaskQuery) (*entity.TaskPage, error)
+	Save(ctx context.Context, task *entity.Task) (*entity.Task, error)
+	Delete(ctx context.Context, taskId int, userId int) error
 }
 
 type taskRepository struct {
@@ -23,54 +28,129 @@ func NewTaskRepository(db *gorm.DB) TaskRepository {
 	return &taskRepository{db}
 }
 
-func (t *taskRepository) Create(task *entity.Task) (*entity.Task, error) {
-	if err := t.db.Create(task).Error; err != nil {
-		return nil, err
+func (t *taskRepository) Create(ctx context.Context, task *entity.Task) (*entity.Task, error) {
+	if err := t.db.WithContext(ctx).Create(task).Error; err != nil {
+		return nil, translateError(t.db, err, "task")
 	}
 
 	return task, nil
 }
 
-func (t *taskRepository) Get(userId int, taskId int) (*entity.Task, error) {
+func (t *taskRepository) Get(ctx context.Context, userId int, taskId int) (*entity.Task, error) {
 	task := entity.Task{}
-	if err := t.db.
+	if err := t.db.WithContext(ctx).
 		Where("user_id = ? AND id = ?", userId, taskId).
 		First(&task).Error; err != nil {
-		return nil, err
+		return nil, translateError(t.db, err, "task")
 	}
 
 	return &task, nil
 }
 
-func (t *taskRepository) GetAllTasks(userId int) ([]*entity.Task, error) {
-	var tasks []*entity.Task
-	if err := t.db.Where("user_id = ?", userId).Find(&tasks).Error; err != nil {
-		return nil, err
+func (t *taskRepository) Count(ctx context.Context, userId int) (int64, error) {
+	var count int64
+	if err := t.db.WithContext(ctx).Model(&entity.Task{}).Where("user_id = ?", userId).Count(&count).Error; err != nil {
+		return 0, translateError(t.db, err, "task")
 	}
 
-	return tasks, nil
+	return count, nil
 }
 
-func (t *taskRepository) Save(task *entity.Task, userId int, taskId int) (*entity.Task, error) {
-	selectedTask, err := t.Get(userId, taskId)
-	if err != nil {
-		return nil, err
+// List は query の条件でタスクを絞り込み、キーセット方式で 1 ページ分を返す
+// SortField・SortDirection・Limit はユースケース側で検証済みであること
+func (t *taskRepository) List(ctx context.Context, query *entity.TaskQuery) (*entity.TaskPage, error) {
+	db := t.db.WithContext(ctx).Where("user_id = ?", query.UserID)
+	if len(query.Statuses) > 0 {
+		db = db.Where("status IN ?", query.Statuses)
+	}
+	if query.Priority != 0 {
+		db = db.Where("priority = ?", query.Priority)
+	}
+	if query.DueBefore != nil {
+		db = db.Where("due_at < ?", *query.DueBefore)
+	}
+	if query.DueAfter != nil {
+		db = db.Where("due_at >= ?", *query.DueAfter)
+	}
+	if query.Title != "" {
+		db = db.Where("LOWER(title) LIKE ? ESCAPE '!'", "%"+escapeLike(strings.ToLower(query.Title))+"%")
+	}
+
+	column := string(query.SortField)
+	// 照合順序は DB ごとに大文字・小文字の扱いが違うため、タイトルは小文字にして並べる
+	if query.SortField == entity.TaskSortTitle {
+		column = "LOWER(title)"
+	}
+	direction := "ASC"
+	operator := ">"
+	if query.SortDirection == entity.SortDesc {
+		direction = "DESC"
+		operator = "<"
+	}
+	// due_at は NULL を許容するため、昇順・降順どちらでも NULL を末尾に並べる
+	nullable := query.SortField == entity.TaskSortDueAt
+
+	if query.Cursor != nil {
+		value, err := query.Cursor.SortValue()
+		if err != nil {
+			return nil, apperror.Wrap(apperror.KindValidation, "invalid cursor", err)
+		}
+		if title, ok := value.(string); ok && query.SortField == entity.TaskSortTitle {
+			value = strings.ToLower(title)
+		}
+		switch {
+		case query.Cursor.IsNull:
+			db = db.Where(fmt.Sprintf("%s IS NULL AND id %s ?", column, operator), query.Cursor.ID)
+		case nullable:
+			db = db.Where(fmt.Sprintf("(%[1]s IS NOT NULL AND (%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))) OR %[1]s IS NULL", column, operator),
+				value, value, query.Cursor.ID)
+		default:
+			db = db.Where(fmt.Sprintf("%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?)", column, operator),
+				value, value, query.Cursor.ID)
+		}
 	}
 
-	if err := copier.CopyWithOption(selectedTask, task, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
+	if nullable {
+		db = db.Order(fmt.Sprintf("%s IS NULL", column))
 	}
-	if err := t.db.Save(selectedTask).Error; err != nil {
-		return nil, err
+	db = db.Order(fmt.Sprintf("%s %s", column, direction)).Order("id " + direction)
+
+	// 次のページの有無を判定するため 1 件多く取得する
+	var tasks []*entity.Task
+	if err := db.Limit(query.Limit + 1).Find(&tasks).Error; err != nil {
+		return nil, translateError(t.db, err, "task")
+	}
+
+	page := &entity.TaskPage{Tasks: tasks}
+	if len(tasks) > query.Limit {
+		page.Tasks = tasks[:query.Limit]
+		page.NextCursor = entity.NewTaskCursor(page.Tasks[query.Limit-1], query.SortField, query.SortDirection)
 	}
+	return page, nil
+}
 
-	return selectedTask, nil
+// LIKE のワイルドカードをエスケープする（エスケープ文字は DB 間で共通に使える '!'）
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
 }
 
-func (t *taskRepository) Delete(taskId int, userId int) error {
+// Save はユースケース側で取得・更新済みのタスクを全カラム保存する
+func (t *taskRepository) Save(ctx context.Context, task *entity.Task) (*entity.Task, error) {
+	if err := t.db.WithContext(ctx).Save(task).Error; err != nil {
+		return nil, translateError(t.db, err, "task")
+	}
+
+	return task, nil
+}
+
+func (t *taskRepository) Delete(ctx context.Context, taskId int, userId int) error {
 	task := entity.Task{ID: taskId, UserID: userId}
-	if err := t.db.Where("id = ? AND user_id=?", taskId, userId).Delete(&task).Error; err != nil {
-		return err
+	result := t.db.WithContext(ctx).Where("id = ? AND user_id=?", taskId, userId).Delete(&task)
+	if result.Error != nil {
+		return translateError(t.db, result.Error, "task")
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NewNotFound("task not found")
 	}
 	return nil
 }
@@ -1,9 +1,11 @@
 package gateway_test
 
 import (
+	"context"
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/suite"
@@ -11,10 +13,11 @@ import (
 	"go-todo-app-clean-arch/adapter/gateway"
 	"go-todo-app-clean-arch/entity"
 	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
 )
 
 type TaskRepositorySuite struct {
-	tester.DBSQLiteSuite
+	tester.DBSuite
 	repository gateway.TaskRepository
 }
 
@@ -23,10 +26,17 @@ func TestTaskRepositorySuite(t *testing.T) {
 }
 
 func (suite *TaskRepositorySuite) SetupSuite() {
-	suite.DBSQLiteSuite.SetupSuite()
+	suite.DBSuite.SetupSuite()
 	suite.repository = gateway.NewTaskRepository(suite.DB)
 }
 
+// tasks.user_id は users への外部キーのため、タスクの持ち主を先に作る
+func (suite *TaskRepositorySuite) createUser(email string) int {
+	user, err := gateway.NewUserRepository(suite.DB).Signup(context.Background(), &entity.User{Email: email, Password: "password"})
+	suite.Require().Nil(err)
+	return user.ID
+}
+
 func (suite *TaskRepositorySuite) MockDB() sqlmock.Sqlmock {
 	mock, mockGormDB := tester.MockDB()
 	suite.repository = gateway.NewTaskRepository(mockGormDB)
@@ -38,43 +48,207 @@ func (suite *TaskRepositorySuite) AfterTest(suiteName, testName string) {
 }
 
 func (suite *TaskRepositorySuite) TestTaskRepositoryCRUD() {
+	userID := suite.createUser("crud@example.com")
 	task := &entity.Task{
-		Title:     "Test Task",
-		UserID:   1,
+		Title:    "Test Task",
+		Status:   entity.TaskStatusTodo,
+		Priority: entity.TaskPriorityMedium,
+		UserID:   userID,
 	}
-	task, err := suite.repository.Create(task)
+	task, err := suite.repository.Create(context.Background(), task)
 	suite.Assert().Nil(err)
 	suite.Assert().NotZero(task.ID)
 	suite.Assert().Equal("Test Task", task.Title)
-	suite.Assert().Equal(1, task.UserID)
+	suite.Assert().Equal(userID, task.UserID)
+	suite.Assert().False(task.CreatedAt.IsZero())
 
-	getTask, err := suite.repository.Get(task.ID, task.UserID)
+	getTask, err := suite.repository.Get(context.Background(), task.UserID, task.ID)
 	suite.Assert().Nil(err)
 	suite.Assert().Equal("Test Task", getTask.Title)
-	suite.Assert().Equal(1, getTask.UserID)
+	suite.Assert().Equal(userID, getTask.UserID)
+
+	suite.Assert().Equal(entity.TaskStatusTodo, getTask.Status)
+	suite.Assert().Equal(entity.TaskPriorityMedium, getTask.Priority)
 
 	getTask.Title = "Updated Task"
-	updateTask, err := suite.repository.Save(getTask)
+	getTask.Status = entity.TaskStatusDone
+	updateTask, err := suite.repository.Save(context.Background(), getTask)
 	suite.Assert().Nil(err)
 	suite.Assert().Equal("Updated Task", updateTask.Title)
 
-	err = suite.repository.Delete(updateTask.ID)
+	savedTask, err := suite.repository.Get(context.Background(), userID, updateTask.ID)
 	suite.Assert().Nil(err)
-	deleteTask, err := suite.repository.Get(updateTask.ID, 1)
+	suite.Assert().Equal("Updated Task", savedTask.Title)
+	suite.Assert().Equal(entity.TaskStatusDone, savedTask.Status)
+
+	err = suite.repository.Delete(context.Background(), updateTask.ID, updateTask.UserID)
+	suite.Assert().Nil(err)
+	deleteTask, err := suite.repository.Get(context.Background(), userID, updateTask.ID)
 	suite.Assert().Nil(deleteTask)
-	suite.Assert().Equal("record not found", err.Error())
+	suite.Assert().True(apperror.IsNotFound(err))
+	suite.Assert().Equal("task not found", err.(*apperror.Error).Message)
+
+	// 削除済みのタスクを再度削除すると not found になる
+	err = suite.repository.Delete(context.Background(), updateTask.ID, updateTask.UserID)
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+func (suite *TaskRepositorySuite) TestTaskList() {
+	userID := suite.createUser("list@example.com")
+	otherUserID := suite.createUser("list-other@example.com")
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	titles := []string{"Buy milk", "Write report", "buy bread", "Call 100% support", "Walk dog"}
+	for i, title := range titles {
+		task := &entity.Task{
+			Title:     title,
+			Status:    entity.TaskStatusTodo,
+			Priority:  entity.TaskPriority(i%3 + 1),
+			UserID:    userID,
+			CreatedAt: base.Add(time.Duration(i) * time.Hour),
+		}
+		if i%2 == 0 {
+			dueAt := base.Add(time.Duration(24*(5-i)) * time.Hour)
+			task.DueAt = &dueAt
+		}
+		if i == 1 {
+			task.Status = entity.TaskStatusDone
+		}
+		_, err := suite.repository.Create(context.Background(), task)
+		suite.Assert().Nil(err)
+	}
+	// 他のユーザーのタスクは含まれない
+	_, err := suite.repository.Create(context.Background(), &entity.Task{Title: "Buy milk", Status: entity.TaskStatusTodo, Priority: entity.TaskPriorityLow, UserID: otherUserID})
+	suite.Assert().Nil(err)
+
+	// 作成日時の昇順で 2 件ずつページングする
+	var got []string
+	query := &entity.TaskQuery{UserID: userID, SortField: entity.TaskSortCreatedAt, SortDirection: entity.SortAsc, Limit: 2}
+	for i := 0; i < 5; i++ {
+		page, err := suite.repository.List(context.Background(), query)
+		suite.Assert().Nil(err)
+		for _, task := range page.Tasks {
+			got = append(got, task.Title)
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	suite.Assert().Equal(titles, got)
+
+	// 期限の昇順では期限なしのタスクが末尾に来る
+	got = nil
+	query = &entity.TaskQuery{UserID: userID, SortField: entity.TaskSortDueAt, SortDirection: entity.SortAsc, Limit: 2}
+	for i := 0; i < 5; i++ {
+		page, err := suite.repository.List(context.Background(), query)
+		suite.Assert().Nil(err)
+		for _, task := range page.Tasks {
+			got = append(got, task.Title)
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	suite.Assert().Equal([]string{"Walk dog", "buy bread", "Buy milk", "Write report", "Call 100% support"}, got)
+
+	page, err := suite.repository.List(context.Background(), &entity.TaskQuery{
+		UserID: userID, Title: "BUY", SortField: entity.TaskSortTitle, SortDirection: entity.SortDesc, Limit: 10,
+	})
+	suite.Assert().Nil(err)
+	// タイトルの並びは大文字・小文字を区別しない
+	suite.Assert().Len(page.Tasks, 2)
+	suite.Assert().Equal("Buy milk", page.Tasks[0].Title)
+	suite.Assert().Equal("buy bread", page.Tasks[1].Title)
+	suite.Assert().Nil(page.NextCursor)
+
+	got = nil
+	query = &entity.TaskQuery{UserID: userID, SortField: entity.TaskSortTitle, SortDirection: entity.SortAsc, Limit: 2}
+	for i := 0; i < 5; i++ {
+		page, err := suite.repository.List(context.Background(), query)
+		suite.Assert().Nil(err)
+		for _, task := range page.Tasks {
+			got = append(got, task.Title)
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	suite.Assert().Equal([]string{"buy bread", "Buy milk", "Call 100% support", "Walk dog", "Write report"}, got)
+
+	page, err = suite.repository.List(context.Background(), &entity.TaskQuery{
+		UserID: userID, Title: "100%", SortField: entity.TaskSortCreatedAt, SortDirection: entity.SortAsc, Limit: 10,
+	})
+	suite.Assert().Nil(err)
+	suite.Assert().Len(page.Tasks, 1)
+
+	dueBefore := base.Add(96 * time.Hour)
+	page, err = suite.repository.List(context.Background(), &entity.TaskQuery{
+		UserID: userID, Statuses: []entity.TaskStatus{entity.TaskStatusTodo}, DueBefore: &dueBefore,
+		SortField: entity.TaskSortCreatedAt, SortDirection: entity.SortAsc, Limit: 10,
+	})
+	suite.Assert().Nil(err)
+	suite.Assert().Len(page.Tasks, 2)
+	suite.Assert().Equal("buy bread", page.Tasks[0].Title)
+	suite.Assert().Equal("Walk dog", page.Tasks[1].Title)
+
+	page, err = suite.repository.List(context.Background(), &entity.TaskQuery{
+		UserID: userID, Statuses: []entity.TaskStatus{entity.TaskStatusDone},
+		SortField: entity.TaskSortPriority, SortDirection: entity.SortDesc, Limit: 10,
+	})
+	suite.Assert().Nil(err)
+	suite.Assert().Len(page.Tasks, 1)
+	suite.Assert().Equal("Write report", page.Tasks[0].Title)
+}
+
+func (suite *TaskRepositorySuite) TestTaskCreateUnknownUser() {
+	task, err := suite.repository.Create(context.Background(), &entity.Task{Title: "Orphan", Status: entity.TaskStatusTodo, Priority: entity.TaskPriorityLow, UserID: 999999})
+	suite.Assert().Nil(task)
+	suite.Assert().True(apperror.IsConflict(err))
+}
+
+// ユーザーを削除するとタスクも外部キーの ON DELETE CASCADE で削除される
+func (suite *TaskRepositorySuite) TestTaskDeletedWithUser() {
+	userID := suite.createUser("cascade@example.com")
+	task, err := suite.repository.Create(context.Background(), &entity.Task{Title: "Cascade", Status: entity.TaskStatusTodo, Priority: entity.TaskPriorityLow, UserID: userID})
+	suite.Assert().Nil(err)
+
+	err = gateway.NewUserRepository(suite.DB).DeleteUser(context.Background(), userID)
+	suite.Assert().Nil(err)
+	_, err = suite.repository.Get(context.Background(), userID, task.ID)
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+// 中断されたリクエストの context では DB に書き込まない
+func (suite *TaskRepositorySuite) TestTaskCanceledContext() {
+	userID := suite.createUser("canceled@example.com")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	task, err := suite.repository.Create(ctx, &entity.Task{Title: "Canceled", Status: entity.TaskStatusTodo, Priority: entity.TaskPriorityLow, UserID: userID})
+	suite.Assert().Nil(task)
+	suite.Assert().True(errors.Is(err, context.Canceled))
+
+	page, err := suite.repository.List(ctx, &entity.TaskQuery{UserID: userID, SortField: entity.TaskSortCreatedAt, SortDirection: entity.SortAsc, Limit: 10})
+	suite.Assert().Nil(page)
+	suite.Assert().True(errors.Is(err, context.Canceled))
+
+	count, err := suite.repository.Count(context.Background(), userID)
+	suite.Assert().Nil(err)
+	suite.Assert().Zero(count)
 }
 
 func (suite *TaskRepositorySuite) TestTaskCreateFailure() {
 	mockDB := suite.MockDB()
 	mockDB.ExpectBegin()
-	mockDB.ExpectExec(regexp.QuoteMeta("INSERT INTO `tasks` (`title`,`user_id`) VALUES (?,?)")).
-		WithArgs("Fail Task", 1).
+	mockDB.ExpectExec(regexp.QuoteMeta("INSERT INTO `tasks` (`title`,`description`,`status`,`priority`,`due_at`,`completed_at`,`user_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?)")).
+		WithArgs("Fail Task", "", "todo", 2, nil, nil, 1, sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnError(errors.New("create error"))
 	mockDB.ExpectRollback()
 
 	task := &entity.Task{Title: "Fail Task", UserID: 1}
-	createdTask, err := suite.repository.Create(task)
+	createdTask, err := suite.repository.Create(context.Background(), task)
 	suite.Assert().Nil(createdTask)
 	suite.Assert().NotNil(err)
 	suite.Assert().Equal("create error", err.Error())
@@ -83,23 +257,23 @@ func (suite *TaskRepositorySuite) TestTaskCreateFailure() {
 func (suite *TaskRepositorySuite) TestTaskDeleteFailure() {
 	mockDB := suite.MockDB()
 	mockDB.ExpectBegin()
-	mockDB.ExpectExec(regexp.QuoteMeta("DELETE FROM `tasks` WHERE `tasks`.`id` = ?")).
-		WithArgs(1, 1).
+	mockDB.ExpectExec(regexp.QuoteMeta("DELETE FROM `tasks` WHERE (id = ? AND user_id=?) AND `tasks`.`id` = ?")).
+		WithArgs(1, 1, 1).
 		WillReturnError(errors.New("delete error"))
 	mockDB.ExpectRollback()
 
-	err := suite.repository.Delete(1)
+	err := suite.repository.Delete(context.Background(), 1, 1)
 	suite.Assert().NotNil(err)
 	suite.Assert().Equal("delete error", err.Error())
 }
 
 func (suite *TaskRepositorySuite) TestTaskGetFailure() {
 	mockDB := suite.MockDB()
-	mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tasks` WHERE `tasks`.`id` = ? ORDER BY `tasks`.`id` LIMIT ?")).
-		WithArgs(1, 1).
+	mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tasks` WHERE user_id = ? AND id = ? ORDER BY `tasks`.`id` LIMIT ?")).
+		WithArgs(1, 1, 1).
 		WillReturnError(errors.New("get error"))
 
-	task, err := suite.repository.Get(1, 1)
+	task, err := suite.repository.Get(context.Background(), 1, 1)
 	suite.Assert().Nil(task)
 	suite.Assert().NotNil(err)
 	suite.Assert().Equal("get error", err.Error())
@@ -107,14 +281,32 @@ func (suite *TaskRepositorySuite) TestTaskGetFailure() {
 
 func (suite *TaskRepositorySuite) TestTaskSaveFailure() {
 	mockDB := suite.MockDB()
-	mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tasks` WHERE `tasks`.`id` = ? ORDER BY `tasks`.`id` LIMIT ?")).
-		WithArgs(1, 1).
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(regexp.QuoteMeta("UPDATE `tasks` SET `title`=?,`description`=?,`status`=?,`priority`=?,`due_at`=?,`completed_at`=?,`user_id`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
+		WithArgs("Fail Save", "", "", 0, nil, nil, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
 		WillReturnError(errors.New("save error"))
+	mockDB.ExpectRollback()
 
-	task := &entity.Task{ID: 1, Title: "Fail Save"}
-	savedTask, err := suite.repository.Save(task)
+	task := &entity.Task{ID: 1, Title: "Fail Save", UserID: 1}
+	savedTask, err := suite.repository.Save(context.Background(), task)
 	suite.Assert().Nil(savedTask)
 	suite.Assert().NotNil(err)
 	suite.Assert().Equal("save error", err.Error())
 }
 
+// 問い合わせ中に期限が切れると、結果を待たずに打ち切る
+func (suite *TaskRepositorySuite) TestTaskGetTimeout() {
+	mockDB := suite.MockDB()
+	mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tasks` WHERE user_id = ? AND id = ? ORDER BY `tasks`.`id` LIMIT ?")).
+		WithArgs(1, 1, 1).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "user_id"}).AddRow(1, "Slow", 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	task, err := suite.repository.Get(ctx, 1, 1)
+	suite.Assert().Nil(task)
+	suite.Assert().True(errors.Is(err, sqlmock.ErrCancelled))
+	suite.Assert().Less(time.Since(start), time.Second)
+}
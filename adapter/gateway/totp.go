@@ -0,0 +1,124 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// ErrTOTPCodeAlreadyUsed は受け付け済みのステップ以前のコードでもう一度認証しようとしたことを表す
+var ErrTOTPCodeAlreadyUsed = apperror.NewUnauthorized("TOTP code has already been used")
+
+type TOTPRepository interface {
+	FindCredential(ctx context.Context, userID int) (*entity.TOTPCredential, error)
+	// StartEnrollment は登録途中の credential を保存する。登録途中のものがあれば置き換える
+	// 登録が完了したものがある場合は Conflict を返す
+	StartEnrollment(ctx context.Context, credential *entity.TOTPCredential) error
+	// Enable は登録を完了し、step を使用済みにして、リカバリーコードを codes に入れ替える
+	// 登録途中のものがない場合は NotFound を返す
+	Enable(ctx context.Context, userID int, step int64, codes []*entity.RecoveryCode, now time.Time) error
+	// UseStep は step を使用済みにする。step 以降を受け付け済みの場合は ErrTOTPCodeAlreadyUsed を返す
+	UseStep(ctx context.Context, userID int, step int64) error
+	// UseRecoveryCode は未使用のリカバリーコードを使用済みにする。見つからない場合は NotFound を返す
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string, now time.Time) error
+	CountRecoveryCodes(ctx context.Context, userID int) (int64, error)
+	// Delete は登録とリカバリーコードを削除する
+	Delete(ctx context.Context, userID int) error
+}
+
+type totpRepository struct {
+	db *gorm.DB
+}
+
+func NewTOTPRepository(db *gorm.DB) TOTPRepository {
+	return &totpRepository{db}
+}
+
+func (r *totpRepository) FindCredential(ctx context.Context, userID int) (*entity.TOTPCredential, error) {
+	credential := &entity.TOTPCredential{}
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(credential).Error; err != nil {
+		return nil, translateError(r.db, err, "TOTP credential")
+	}
+	return credential, nil
+}
+
+func (r *totpRepository) StartEnrollment(ctx context.Context, credential *entity.TOTPCredential) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND confirmed_at IS NULL", credential.UserID).
+			Delete(&entity.TOTPCredential{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(credential).Error
+	})
+	return translateError(r.db, err, "TOTP credential")
+}
+
+func (r *totpRepository) Enable(ctx context.Context, userID int, step int64, codes []*entity.RecoveryCode, now time.Time) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.TOTPCredential{}).
+			Where("user_id = ? AND confirmed_at IS NULL", userID).
+			Updates(map[string]interface{}{"confirmed_at": now, "last_used_step": step})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(codes).Error
+	})
+	return translateError(r.db, err, "TOTP credential")
+}
+
+func (r *totpRepository) UseStep(ctx context.Context, userID int, step int64) error {
+	// 同じコードで同時にリクエストされても、受け付けるのは 1 回だけにする
+	result := r.db.WithContext(ctx).Model(&entity.TOTPCredential{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return translateError(r.db, result.Error, "TOTP credential")
+	}
+	if result.RowsAffected == 0 {
+		return ErrTOTPCodeAlreadyUsed
+	}
+	return nil
+}
+
+func (r *totpRepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string, now time.Time) error {
+	result := r.db.WithContext(ctx).Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", now)
+	if result.Error != nil {
+		return translateError(r.db, result.Error, "recovery code")
+	}
+	if result.RowsAffected == 0 {
+		return translateError(r.db, gorm.ErrRecordNotFound, "recovery code")
+	}
+	return nil
+}
+
+func (r *totpRepository) CountRecoveryCodes(ctx context.Context, userID int) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		return 0, translateError(r.db, err, "recovery code")
+	}
+	return count, nil
+}
+
+func (r *totpRepository) Delete(ctx context.Context, userID int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&entity.TOTPCredential{}).Error
+	})
+	return translateError(r.db, err, "TOTP credential")
+}
@@ -0,0 +1,105 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type TOTPRepositorySuite struct {
+	tester.DBSuite
+	repository gateway.TOTPRepository
+}
+
+func TestTOTPRepositorySuite(t *testing.T) {
+	suite.Run(t, new(TOTPRepositorySuite))
+}
+
+func (suite *TOTPRepositorySuite) SetupSuite() {
+	suite.DBSuite.SetupSuite()
+	suite.repository = gateway.NewTOTPRepository(suite.DB)
+}
+
+func (suite *TOTPRepositorySuite) createUser(email string) int {
+	user, err := gateway.NewUserRepository(suite.DB).Signup(context.Background(), &entity.User{Email: email, Password: "password"})
+	suite.Require().Nil(err)
+	return user.ID
+}
+
+func (suite *TOTPRepositorySuite) TestEnrollAndEnable() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("totp-enable@example.com")
+
+	// 登録途中のものは何度でも置き換えられる
+	suite.Assert().Nil(suite.repository.StartEnrollment(context.Background(), &entity.TOTPCredential{UserID: userID, Secret: "FIRST"}))
+	suite.Assert().Nil(suite.repository.StartEnrollment(context.Background(), &entity.TOTPCredential{UserID: userID, Secret: "SECOND"}))
+	credential, err := suite.repository.FindCredential(context.Background(), userID)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal("SECOND", credential.Secret)
+	suite.Assert().False(credential.IsEnabled())
+
+	codes := []*entity.RecoveryCode{{UserID: userID, CodeHash: "code-1"}, {UserID: userID, CodeHash: "code-2"}}
+	suite.Assert().Nil(suite.repository.Enable(context.Background(), userID, 100, codes, now))
+	credential, _ = suite.repository.FindCredential(context.Background(), userID)
+	suite.Assert().True(credential.IsEnabled())
+	suite.Assert().Equal(int64(100), credential.LastUsedStep)
+	count, err := suite.repository.CountRecoveryCodes(context.Background(), userID)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(int64(2), count)
+
+	// 登録が完了したら置き換えられない
+	err = suite.repository.StartEnrollment(context.Background(), &entity.TOTPCredential{UserID: userID, Secret: "THIRD"})
+	suite.Assert().True(apperror.IsConflict(err))
+	err = suite.repository.Enable(context.Background(), userID, 101, nil, now)
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+func (suite *TOTPRepositorySuite) TestUseStep() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("totp-step@example.com")
+	suite.Require().Nil(suite.repository.StartEnrollment(context.Background(), &entity.TOTPCredential{UserID: userID, Secret: "SECRET"}))
+	suite.Require().Nil(suite.repository.Enable(context.Background(), userID, 100, []*entity.RecoveryCode{{UserID: userID, CodeHash: "step-code"}}, now))
+
+	// 受け付け済みのステップ以前のコードは使えない
+	suite.Assert().True(errors.Is(suite.repository.UseStep(context.Background(), userID, 100), gateway.ErrTOTPCodeAlreadyUsed))
+	suite.Assert().Nil(suite.repository.UseStep(context.Background(), userID, 101))
+	suite.Assert().True(errors.Is(suite.repository.UseStep(context.Background(), userID, 101), gateway.ErrTOTPCodeAlreadyUsed))
+}
+
+func (suite *TOTPRepositorySuite) TestUseRecoveryCode() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("totp-recovery@example.com")
+	otherID := suite.createUser("totp-recovery-other@example.com")
+	suite.Require().Nil(suite.repository.StartEnrollment(context.Background(), &entity.TOTPCredential{UserID: userID, Secret: "SECRET"}))
+	suite.Require().Nil(suite.repository.Enable(context.Background(), userID, 1, []*entity.RecoveryCode{{UserID: userID, CodeHash: "recovery-code"}}, now))
+
+	suite.Assert().True(apperror.IsNotFound(suite.repository.UseRecoveryCode(context.Background(), otherID, "recovery-code", now)))
+	suite.Assert().Nil(suite.repository.UseRecoveryCode(context.Background(), userID, "recovery-code", now))
+	suite.Assert().True(apperror.IsNotFound(suite.repository.UseRecoveryCode(context.Background(), userID, "recovery-code", now)))
+	count, _ := suite.repository.CountRecoveryCodes(context.Background(), userID)
+	suite.Assert().Equal(int64(0), count)
+}
+
+func (suite *TOTPRepositorySuite) TestDelete() {
+	now := time.Now().UTC().Truncate(time.Second)
+	userID := suite.createUser("totp-delete@example.com")
+	suite.Require().Nil(suite.repository.StartEnrollment(context.Background(), &entity.TOTPCredential{UserID: userID, Secret: "SECRET"}))
+	suite.Require().Nil(suite.repository.Enable(context.Background(), userID, 1, []*entity.RecoveryCode{{UserID: userID, CodeHash: "delete-code"}}, now))
+
+	suite.Assert().Nil(suite.repository.Delete(context.Background(), userID))
+	_, err := suite.repository.FindCredential(context.Background(), userID)
+	suite.Assert().True(apperror.IsNotFound(err))
+	count, _ := suite.repository.CountRecoveryCodes(context.Background(), userID)
+	suite.Assert().Equal(int64(0), count)
+
+	// 登録がなくても失敗しない
+	suite.Assert().Nil(suite.repository.Delete(context.Background(), userID))
+}
@@ -1,15 +1,41 @@
 package gateway
 
 import (
+	"context"
+	"time"
+
 	"gorm.io/gorm"
+
 	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+var (
+	// ErrPasswordChanged は確認した後に、同時にパスワードが変えられていたことを表す
+	ErrPasswordChanged = apperror.NewConflict("password has been changed by another request")
+	// ErrEmailChangeTokenAlreadyUsed は使用済みの確認トークンでもう一度変更しようとしたことを表す
+	ErrEmailChangeTokenAlreadyUsed = apperror.NewUnauthorized("email change token has already been used")
 )
 
 type UserRepository interface {
-	Signup(user *entity.User) (*entity.User, error)
-	GetCurrentUser(userId int) (*entity.User, error)
-	DeleteUser(userId int) error
-	FindByEmail(email string) (*entity.User, error)
+	Signup(ctx context.Context, user *entity.User) (*entity.User, error)
+	GetCurrentUser(ctx context.Context, userId int) (*entity.User, error)
+	DeleteUser(ctx context.Context, userId int) error
+	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+	// UpdatePasswordHash はパスワードのハッシュが currentHash のままの場合だけ newHash に置き換える
+	// 同時にパスワードが変えられていたら何もしない
+	UpdatePasswordHash(ctx context.Context, userID int, currentHash, newHash string) error
+	// ChangePassword はパスワードのハッシュが currentHash のままの場合だけ newHash に置き換える
+	// UpdatePasswordHash と違い、同時にパスワードが変えられていたら ErrPasswordChanged を返す
+	ChangePassword(ctx context.Context, userID int, currentHash, newHash string) error
+	CreateEmailChangeToken(ctx context.Context, token *entity.EmailChangeToken) error
+	FindEmailChangeToken(ctx context.Context, tokenHash string) (*entity.EmailChangeToken, error)
+	// FindLatestEmailChangeToken は userID に最後に発行したトークンを返す。再送の間隔の確認に使う
+	FindLatestEmailChangeToken(ctx context.Context, userID int) (*entity.EmailChangeToken, error)
+	// ChangeEmail は token を使用済みにし、ユーザーのメールアドレスを token.NewEmail に変えて確認済みにする
+	// 同じユーザーの未使用のトークンもすべて使えなくする
+	// token が既に使用済みの場合は ErrEmailChangeTokenAlreadyUsed、アドレスが他のユーザーに使われている場合は Conflict を返す
+	ChangeEmail(ctx context.Context, token *entity.EmailChangeToken, now time.Time) error
 }
 
 type userRepository struct {
@@ -20,32 +46,95 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db}
 }
 
-func (u *userRepository) Signup(user *entity.User) (*entity.User, error) {
-	if err := u.db.Create(user).Error; err != nil {
-		return nil, err
+func (u *userRepository) Signup(ctx context.Context, user *entity.User) (*entity.User, error) {
+	if err := u.db.WithContext(ctx).Create(user).Error; err != nil {
+		return nil, translateError(u.db, err, "user")
 	}
 	return user, nil
 }
 
-func (u *userRepository) GetCurrentUser(userId int) (*entity.User, error) {
+func (u *userRepository) GetCurrentUser(ctx context.Context, userId int) (*entity.User, error) {
 	user := entity.User{}
-	if err := u.db.First(&user, userId).Error; err != nil {
-		return nil, err
+	if err := u.db.WithContext(ctx).First(&user, userId).Error; err != nil {
+		return nil, translateError(u.db, err, "user")
 	}
 	return &user, nil
 }
 
-func (u *userRepository) DeleteUser(userId int) error {
-	if err := u.db.Delete(&entity.User{}, userId).Error; err != nil {
-		return err
+func (u *userRepository) DeleteUser(ctx context.Context, userId int) error {
+	result := u.db.WithContext(ctx).Delete(&entity.User{}, userId)
+	if result.Error != nil {
+		return translateError(u.db, result.Error, "user")
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NewNotFound("user not found")
 	}
 	return nil
 }
 
-func (u *userRepository) FindByEmail(email string) (*entity.User, error) {
+func (u *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	user := &entity.User{}
-	if err := u.db.Where("email = ?", email).First(user).Error; err != nil {
-		return nil, err
+	if err := u.db.WithContext(ctx).Where("email = ?", email).First(user).Error; err != nil {
+		return nil, translateError(u.db, err, "user")
 	}
 	return user, nil
 }
+
+func (u *userRepository) UpdatePasswordHash(ctx context.Context, userID int, currentHash, newHash string) error {
+	err := u.db.WithContext(ctx).Model(&entity.User{}).Where("id = ? AND password = ?", userID, currentHash).Update("password", newHash).Error
+	if err != nil {
+		return translateError(u.db, err, "user")
+	}
+	return nil
+}
+
+func (u *userRepository) ChangePassword(ctx context.Context, userID int, currentHash, newHash string) error {
+	result := u.db.WithContext(ctx).Model(&entity.User{}).Where("id = ? AND password = ?", userID, currentHash).Update("password", newHash)
+	if result.Error != nil {
+		return translateError(u.db, result.Error, "user")
+	}
+	if result.RowsAffected == 0 {
+		return ErrPasswordChanged
+	}
+	return nil
+}
+
+func (u *userRepository) CreateEmailChangeToken(ctx context.Context, token *entity.EmailChangeToken) error {
+	if err := u.db.WithContext(ctx).Create(token).Error; err != nil {
+		return translateError(u.db, err, "email change token")
+	}
+	return nil
+}
+
+func (u *userRepository) FindEmailChangeToken(ctx context.Context, tokenHash string) (*entity.EmailChangeToken, error) {
+	token := &entity.EmailChangeToken{}
+	if err := u.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(token).Error; err != nil {
+		return nil, translateError(u.db, err, "email change token")
+	}
+	return token, nil
+}
+
+func (u *userRepository) FindLatestEmailChangeToken(ctx context.Context, userID int) (*entity.EmailChangeToken, error) {
+	token := &entity.EmailChangeToken{}
+	if err := u.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Order("id DESC").First(token).Error; err != nil {
+		return nil, translateError(u.db, err, "email change token")
+	}
+	return token, nil
+}
+
+func (u *userRepository) ChangeEmail(ctx context.Context, token *entity.EmailChangeToken, now time.Time) error {
+	return consumeSingleUseToken(ctx, u.db, &entity.EmailChangeToken{}, &token.SingleUseToken, now, ErrEmailChangeTokenAlreadyUsed, func(user *gorm.DB) error {
+		// 新しいアドレスに届いたリンクで確認できたため、確認済みにする
+		result := user.Updates(map[string]interface{}{
+			"email":             token.NewEmail,
+			"email_verified_at": now,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,114 @@
+package gateway
+
+import (
+	"context"
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type UserIdentityRepository interface {
+	// Create は連携を保存する。同じアカウントが連携済み、またはユーザーが同じプロバイダーの
+	// 別のアカウントを連携済みの場合は Conflict を返す
+	Create(ctx context.Context, identity *entity.UserIdentity) error
+	// CreateWithUser は user を作成し、identity をそのユーザーに連携する。どちらかに失敗した場合はどちらも保存しない
+	// メールアドレスが登録済み、またはアカウントが連携済みの場合は Conflict を返す
+	CreateWithUser(ctx context.Context, user *entity.User, identity *entity.UserIdentity) error
+	FindBySubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error)
+	// List はユーザーが連携しているアカウントを連携した順に返す
+	List(ctx context.Context, userID int) ([]*entity.UserIdentity, error)
+	// Delete はユーザーの provider の連携を解除する。連携していない場合は NotFound を返す
+	Delete(ctx context.Context, userID int, provider string) error
+}
+
+type userIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(db *gorm.DB) UserIdentityRepository {
+	return &userIdentityRepository{db}
+}
+
+func (r *userIdentityRepository) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	if err := r.db.WithContext(ctx).Create(identity).Error; err != nil {
+		return translateError(r.db, err, "user identity")
+	}
+	return nil
+}
+
+func (r *userIdentityRepository) CreateWithUser(ctx context.Context, user *entity.User, identity *entity.UserIdentity) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+	return translateError(r.db, err, "user")
+}
+
+func (r *userIdentityRepository) FindBySubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	identity := &entity.UserIdentity{}
+	if err := r.db.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).First(identity).Error; err != nil {
+		return nil, translateError(r.db, err, "user identity")
+	}
+	return identity, nil
+}
+
+func (r *userIdentityRepository) List(ctx context.Context, userID int) ([]*entity.UserIdentity, error) {
+	identities := []*entity.UserIdentity{}
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&identities).Error; err != nil {
+		return nil, translateError(r.db, err, "user identity")
+	}
+	return identities, nil
+}
+
+func (r *userIdentityRepository) Delete(ctx context.Context, userID int, provider string) error {
+	result := r.db.WithContext(ctx).Where("user_id = ? AND provider = ?", userID, provider).Delete(&entity.UserIdentity{})
+	if result.Error != nil {
+		return translateError(r.db, result.Error, "user identity")
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NewNotFound("user identity not found")
+	}
+	return nil
+}
+
+type OIDCLoginStateRepository interface {
+	Create(ctx context.Context, state *entity.OIDCLoginState) error
+	// Consume は stateHash の状態を削除して返す。同じ state は 1 回しか使えず、2 回目は NotFound を返す
+	// 有効期限は確かめないので、呼び出し側で IsUsable を確かめる
+	Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error)
+}
+
+type oidcLoginStateRepository struct {
+	db *gorm.DB
+}
+
+func NewOIDCLoginStateRepository(db *gorm.DB) OIDCLoginStateRepository {
+	return &oidcLoginStateRepository{db}
+}
+
+func (r *oidcLoginStateRepository) Create(ctx context.Context, state *entity.OIDCLoginState) error {
+	if err := r.db.WithContext(ctx).Create(state).Error; err != nil {
+		return translateError(r.db, err, "login state")
+	}
+	return nil
+}
+
+func (r *oidcLoginStateRepository) Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error) {
+	state := &entity.OIDCLoginState{}
+	if err := r.db.WithContext(ctx).Where("state_hash = ?", stateHash).First(state).Error; err != nil {
+		return nil, translateError(r.db, err, "login state")
+	}
+	// 同時に同じ state が使われても、削除できた 1 つのリクエストだけを通す
+	result := r.db.WithContext(ctx).Where("id = ?", state.ID).Delete(&entity.OIDCLoginState{})
+	if result.Error != nil {
+		return nil, translateError(r.db, result.Error, "login state")
+	}
+	if result.RowsAffected == 0 {
+		return nil, apperror.NewNotFound("login state not found")
+	}
+	return state, nil
+}
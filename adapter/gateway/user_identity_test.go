@@ -0,0 +1,108 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type UserIdentityRepositorySuite struct {
+	tester.DBSuite
+	repository      gateway.UserIdentityRepository
+	stateRepository gateway.OIDCLoginStateRepository
+}
+
+func TestUserIdentityRepositorySuite(t *testing.T) {
+	suite.Run(t, new(UserIdentityRepositorySuite))
+}
+
+func (suite *UserIdentityRepositorySuite) SetupSuite() {
+	suite.DBSuite.SetupSuite()
+	suite.repository = gateway.NewUserIdentityRepository(suite.DB)
+	suite.stateRepository = gateway.NewOIDCLoginStateRepository(suite.DB)
+}
+
+func (suite *UserIdentityRepositorySuite) createUser(email string) *entity.User {
+	// OpenID Provider でだけログインするユーザーはパスワードを持たない
+	user, err := gateway.NewUserRepository(suite.DB).Signup(context.Background(), &entity.User{Email: email})
+	suite.Require().Nil(err)
+	return user
+}
+
+func (suite *UserIdentityRepositorySuite) TestLinkAndUnlink() {
+	user := suite.createUser("identity-link@example.com")
+	other := suite.createUser("identity-other@example.com")
+
+	identity := &entity.UserIdentity{UserID: user.ID, Provider: "company", Subject: "sub-1", Email: user.Email}
+	suite.Require().Nil(suite.repository.Create(context.Background(), identity))
+	suite.Assert().Nil(suite.repository.Create(context.Background(), &entity.UserIdentity{UserID: user.ID, Provider: "google", Subject: "sub-1"}))
+
+	found, err := suite.repository.FindBySubject(context.Background(), "company", "sub-1")
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(user.ID, found.UserID)
+	suite.Assert().Equal(user.Email, found.Email)
+
+	// 同じアカウントを別のユーザーに、同じプロバイダーの 2 つ目のアカウントを同じユーザーには連携できない
+	err = suite.repository.Create(context.Background(), &entity.UserIdentity{UserID: other.ID, Provider: "company", Subject: "sub-1"})
+	suite.Assert().True(apperror.IsConflict(err))
+	err = suite.repository.Create(context.Background(), &entity.UserIdentity{UserID: user.ID, Provider: "company", Subject: "sub-2"})
+	suite.Assert().True(apperror.IsConflict(err))
+
+	identities, err := suite.repository.List(context.Background(), user.ID)
+	suite.Assert().Nil(err)
+	suite.Require().Len(identities, 2)
+	suite.Assert().Equal("company", identities[0].Provider)
+
+	suite.Assert().Nil(suite.repository.Delete(context.Background(), user.ID, "company"))
+	suite.Assert().True(apperror.IsNotFound(suite.repository.Delete(context.Background(), user.ID, "company")))
+	_, err = suite.repository.FindBySubject(context.Background(), "company", "sub-1")
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+func (suite *UserIdentityRepositorySuite) TestCreateWithUser() {
+	user := &entity.User{Email: "identity-new@example.com"}
+	identity := &entity.UserIdentity{Provider: "company", Subject: "sub-new", Email: user.Email}
+	suite.Require().Nil(suite.repository.CreateWithUser(context.Background(), user, identity))
+	suite.Assert().NotZero(user.ID)
+	found, err := suite.repository.FindBySubject(context.Background(), "company", "sub-new")
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(user.ID, found.UserID)
+
+	// 連携済みのアカウントで作ろうとした場合は、ユーザーも作らない
+	err = suite.repository.CreateWithUser(context.Background(), &entity.User{Email: "identity-orphan@example.com"},
+		&entity.UserIdentity{Provider: "company", Subject: "sub-new"})
+	suite.Assert().True(apperror.IsConflict(err))
+	_, err = gateway.NewUserRepository(suite.DB).FindByEmail(context.Background(), "identity-orphan@example.com")
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+func (suite *UserIdentityRepositorySuite) TestConsumeState() {
+	user := suite.createUser("identity-state@example.com")
+	now := time.Now().UTC().Truncate(time.Second)
+	state := &entity.OIDCLoginState{
+		StateHash:    "state-hash",
+		Provider:     "company",
+		Nonce:        "nonce",
+		CodeVerifier: "verifier",
+		UserID:       &user.ID,
+		ExpiresAt:    now.Add(10 * time.Minute),
+	}
+	suite.Require().Nil(suite.stateRepository.Create(context.Background(), state))
+
+	consumed, err := suite.stateRepository.Consume(context.Background(), "state-hash")
+	suite.Assert().Nil(err)
+	suite.Assert().Equal("verifier", consumed.CodeVerifier)
+	suite.Assert().Equal(user.ID, *consumed.UserID)
+	suite.Assert().True(consumed.IsUsable(now))
+
+	// 同じ state は 2 回使えない
+	_, err = suite.stateRepository.Consume(context.Background(), "state-hash")
+	suite.Assert().True(apperror.IsNotFound(err))
+}
@@ -1,9 +1,11 @@
 package gateway_test
 
 import (
+	"context"
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/suite"
@@ -11,10 +13,11 @@ import (
 	"go-todo-app-clean-arch/adapter/gateway"
 	"go-todo-app-clean-arch/entity"
 	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
 )
 
 type UserRepositorySuite struct {
-	tester.DBSQLiteSuite
+	tester.DBSuite
 	repository gateway.UserRepository
 }
 
@@ -23,7 +26,7 @@ func TestUserRepositorySuite(t *testing.T) {
 }
 
 func (suite *UserRepositorySuite) SetupSuite() {
-	suite.DBSQLiteSuite.SetupSuite()
+	suite.DBSuite.SetupSuite()
 	suite.repository = gateway.NewUserRepository(suite.DB)
 }
 
@@ -42,32 +45,154 @@ func (suite *UserRepositorySuite) TestUserRepositoryCRUD() {
 		Email:    "test@example.com",
 		Password: "password",
 	}
-	createdUser, err := suite.repository.Create(user)
+	createdUser, err := suite.repository.Signup(context.Background(), user)
 	suite.Assert().Nil(err)
 	suite.Assert().NotZero(createdUser.ID)
 	suite.Assert().Equal("test@example.com", createdUser.Email)
 
-	getUser, err := suite.repository.Get(createdUser.ID)
+	getUser, err := suite.repository.GetCurrentUser(context.Background(), createdUser.ID)
 	suite.Assert().Nil(err)
 	suite.Assert().Equal("test@example.com", getUser.Email)
 
-	err = suite.repository.Delete(createdUser.ID)
+	err = suite.repository.DeleteUser(context.Background(), createdUser.ID)
 	suite.Assert().Nil(err)
-	deletedUser, err := suite.repository.Get(createdUser.ID)
+	deletedUser, err := suite.repository.GetCurrentUser(context.Background(), createdUser.ID)
 	suite.Assert().Nil(deletedUser)
-	suite.Assert().Equal("record not found", err.Error())
+	suite.Assert().True(apperror.IsNotFound(err))
+
+	err = suite.repository.DeleteUser(context.Background(), createdUser.ID)
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+func (suite *UserRepositorySuite) TestUserUpdatePasswordHash() {
+	user, err := suite.repository.Signup(context.Background(), &entity.User{Email: "rehash@example.com", Password: "old-hash"})
+	suite.Require().Nil(err)
+
+	suite.Assert().Nil(suite.repository.UpdatePasswordHash(context.Background(), user.ID, "old-hash", "new-hash"))
+	updated, _ := suite.repository.GetCurrentUser(context.Background(), user.ID)
+	suite.Assert().Equal("new-hash", updated.Password)
+
+	// 先にパスワードが変えられていたら上書きしない
+	suite.Assert().Nil(suite.repository.UpdatePasswordHash(context.Background(), user.ID, "old-hash", "stale-hash"))
+	updated, _ = suite.repository.GetCurrentUser(context.Background(), user.ID)
+	suite.Assert().Equal("new-hash", updated.Password)
+}
+
+func (suite *UserRepositorySuite) TestUserChangePassword() {
+	user, err := suite.repository.Signup(context.Background(), &entity.User{Email: "change@example.com", Password: "old-hash"})
+	suite.Require().Nil(err)
+
+	suite.Assert().Nil(suite.repository.ChangePassword(context.Background(), user.ID, "old-hash", "new-hash"))
+	updated, _ := suite.repository.GetCurrentUser(context.Background(), user.ID)
+	suite.Assert().Equal("new-hash", updated.Password)
+
+	// 確かめた後に別のリクエストがパスワードを変えていたら失敗させる
+	err = suite.repository.ChangePassword(context.Background(), user.ID, "old-hash", "stale-hash")
+	suite.Assert().True(errors.Is(err, gateway.ErrPasswordChanged))
+	updated, _ = suite.repository.GetCurrentUser(context.Background(), user.ID)
+	suite.Assert().Equal("new-hash", updated.Password)
+}
+
+func (suite *UserRepositorySuite) TestUserChangeEmail() {
+	now := time.Now().UTC().Truncate(time.Second)
+	user, err := suite.repository.Signup(context.Background(), &entity.User{Email: "before@example.com", Password: "password"})
+	suite.Require().Nil(err)
+	for _, hash := range []string{"email-change-hash-1", "email-change-hash-2"} {
+		suite.Require().Nil(suite.repository.CreateEmailChangeToken(context.Background(), &entity.EmailChangeToken{
+			SingleUseToken: entity.SingleUseToken{
+				UserID:    user.ID,
+				TokenHash: hash,
+				ExpiresAt: now.Add(time.Hour),
+				CreatedAt: now,
+			},
+			NewEmail: "after@example.com",
+		}))
+	}
+
+	latest, err := suite.repository.FindLatestEmailChangeToken(context.Background(), user.ID)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal("email-change-hash-2", latest.TokenHash)
+
+	token, err := suite.repository.FindEmailChangeToken(context.Background(), "email-change-hash-1")
+	suite.Assert().Nil(err)
+	suite.Assert().True(token.IsUsable(now))
+
+	suite.Assert().Nil(suite.repository.ChangeEmail(context.Background(), token, now))
+	updated, err := suite.repository.GetCurrentUser(context.Background(), user.ID)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal("after@example.com", updated.Email)
+	suite.Assert().True(updated.IsEmailVerified())
+
+	// 同じユーザーの他のトークンも使えなくなる
+	other, err := suite.repository.FindEmailChangeToken(context.Background(), "email-change-hash-2")
+	suite.Assert().Nil(err)
+	suite.Assert().False(other.IsUsable(now))
+
+	// 2 回目は使えない
+	err = suite.repository.ChangeEmail(context.Background(), token, now)
+	suite.Assert().True(errors.Is(err, gateway.ErrEmailChangeTokenAlreadyUsed))
+}
+
+func (suite *UserRepositorySuite) TestUserChangeEmailDuplicate() {
+	now := time.Now().UTC().Truncate(time.Second)
+	user, err := suite.repository.Signup(context.Background(), &entity.User{Email: "owner@example.com", Password: "password"})
+	suite.Require().Nil(err)
+	token := &entity.EmailChangeToken{SingleUseToken: entity.SingleUseToken{UserID: user.ID, TokenHash: "email-change-taken", ExpiresAt: now.Add(time.Hour), CreatedAt: now}, NewEmail: "taken@example.com"}
+	suite.Require().Nil(suite.repository.CreateEmailChangeToken(context.Background(), token))
+	// 確認メールを送った後に、他のユーザーがそのアドレスで登録した
+	_, err = suite.repository.Signup(context.Background(), &entity.User{Email: "taken@example.com", Password: "password"})
+	suite.Require().Nil(err)
+
+	err = suite.repository.ChangeEmail(context.Background(), token, now)
+	suite.Assert().True(apperror.IsConflict(err))
+	// トランザクションを戻すため、トークンは使用済みにならない
+	found, _ := suite.repository.FindEmailChangeToken(context.Background(), "email-change-taken")
+	suite.Assert().True(found.IsUsable(now))
+	updated, _ := suite.repository.GetCurrentUser(context.Background(), user.ID)
+	suite.Assert().Equal("owner@example.com", updated.Email)
+}
+
+func (suite *UserRepositorySuite) TestUserSignupDuplicateEmail() {
+	user := &entity.User{Email: "duplicate@example.com", Password: "password"}
+	_, err := suite.repository.Signup(context.Background(), user)
+	suite.Assert().Nil(err)
+
+	duplicated, err := suite.repository.Signup(context.Background(), &entity.User{Email: "duplicate@example.com", Password: "password"})
+	suite.Assert().Nil(duplicated)
+	suite.Assert().True(apperror.IsConflict(err))
+	suite.Assert().Equal("user already exists", err.(*apperror.Error).Message)
+}
+
+func (suite *UserRepositorySuite) TestUserFindByEmailNotFound() {
+	user, err := suite.repository.FindByEmail(context.Background(), "missing@example.com")
+	suite.Assert().Nil(user)
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+func (suite *UserRepositorySuite) TestUserCanceledContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	user, err := suite.repository.Signup(ctx, &entity.User{Email: "canceled@example.com", Password: "password"})
+	suite.Assert().Nil(user)
+	suite.Assert().True(errors.Is(err, context.Canceled))
+
+	// 登録されていない
+	user, err = suite.repository.FindByEmail(context.Background(), "canceled@example.com")
+	suite.Assert().Nil(user)
+	suite.Assert().True(apperror.IsNotFound(err))
 }
 
 func (suite *UserRepositorySuite) TestUserCreateFailure() {
 	mockDB := suite.MockDB()
 	mockDB.ExpectBegin()
-	mockDB.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`email`,`password`) VALUES (?,?)")).
-		WithArgs("fail@example.com", "password").
+	mockDB.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`email`,`password`,`email_verified_at`) VALUES (?,?,?)")).
+		WithArgs("fail@example.com", "password", nil).
 		WillReturnError(errors.New("create error"))
 	mockDB.ExpectRollback()
 
 	user := &entity.User{Email: "fail@example.com", Password: "password"}
-	createdUser, err := suite.repository.Create(user)
+	createdUser, err := suite.repository.Signup(context.Background(), user)
 	suite.Assert().Nil(createdUser)
 	suite.Assert().NotNil(err)
 	suite.Assert().Equal("create error", err.Error())
@@ -80,7 +205,7 @@ func (suite *UserRepositorySuite) TestUserDeleteFailure() {
 		WillReturnError(errors.New("delete error"))
 	mockDB.ExpectRollback()
 
-	err := suite.repository.Delete(1)
+	err := suite.repository.DeleteUser(context.Background(), 1)
 	suite.Assert().NotNil(err)
 	suite.Assert().Equal("delete error", err.Error())
 }
@@ -91,8 +216,22 @@ func (suite *UserRepositorySuite) TestUserGetFailure() {
 		WithArgs(1, 1).
 		WillReturnError(errors.New("get error"))
 
-	user, err := suite.repository.Get(1)
+	user, err := suite.repository.GetCurrentUser(context.Background(), 1)
 	suite.Assert().Nil(user)
 	suite.Assert().NotNil(err)
 	suite.Assert().Equal("get error", err.Error())
 }
+
+func (suite *UserRepositorySuite) TestUserGetTimeout() {
+	mockDB := suite.MockDB()
+	mockDB.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ? ORDER BY `users`.`id` LIMIT ?")).
+		WithArgs(1, 1).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "slow@example.com"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	user, err := suite.repository.GetCurrentUser(ctx, 1)
+	suite.Assert().Nil(user)
+	suite.Assert().True(errors.Is(err, sqlmock.ErrCancelled))
+}
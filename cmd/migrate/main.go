@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/joho/godotenv"
+
+	"go-todo-app-clean-arch/infrastructure/database"
+	"go-todo-app-clean-arch/pkg"
+	"go-todo-app-clean-arch/pkg/config"
+	"go-todo-app-clean-arch/pkg/logger"
+)
+
+const usage = `Usage: migrate [flags] <command>
+
+接続先は server と同じ設定（設定ファイル・環境変数・フラグ）から読み込む
+
+Commands:
+  up            未適用のマイグレーションをすべて適用する
+  down N        適用済みのマイグレーションを新しいものから N 件取り消す
+  status        マイグレーションの一覧と適用状況を表示する
+  create NAME   次の番号の up/down ファイルを全方言分作成する
+
+Flags:
+`
+
+func main() {
+	dir := flag.String("dir", database.MigrationsDir, "migrations directory used by create")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	defer logger.Sync()
+
+	if pkg.GetEnvDefault("APP_ENV", "development") == "development" {
+		if err := godotenv.Load(".env.development"); err != nil {
+			logger.Warn("Error loading .env.development file")
+		}
+	}
+
+	conf, err := config.Load(flag.CommandLine, os.Args[1:])
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// create はファイルを作るだけなので DB に接続しない
+	if args[0] == "create" {
+		if len(args) != 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		files, err := database.CreateMigration(*dir, args[1])
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		for _, file := range files {
+			fmt.Println("created", file)
+		}
+		return
+	}
+
+	// マイグレーションには DB の設定だけを使う
+	if err = errors.Join(err, conf.Database.Validate()); err != nil {
+		logger.Fatal("invalid configuration:\n" + err.Error())
+	}
+	migrator, err := newMigrator(&conf.Database)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+
+	switch args[0] {
+	case "up":
+		migrations, err := migrator.Up()
+		printMigrations("applied", migrations)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		if len(migrations) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		if len(args) != 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("invalid number of migrations %q", args[1]))
+		}
+		migrations, err := migrator.Down(n)
+		printMigrations("rolled back", migrations)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d  %-40s %s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func newMigrator(conf *config.DatabaseConfig) (*database.Migrator, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), conf.ConnectTimeout)
+	defer cancel()
+	db, err := database.Connect(ctx, database.NewConfig(conf))
+	if err != nil {
+		return nil, err
+	}
+	return database.NewMigrator(db)
+}
+
+func printMigrations(action string, migrations []database.Migration) {
+	for _, migration := range migrations {
+		fmt.Printf("%s %04d_%s\n", action, migration.Version, migration.Name)
+	}
+}
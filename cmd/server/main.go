@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -12,30 +13,56 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+	"gorm.io/gorm"
 
 	"go-todo-app-clean-arch/infrastructure/database"
 	"go-todo-app-clean-arch/infrastructure/web"
 	"go-todo-app-clean-arch/pkg"
+	"go-todo-app-clean-arch/pkg/config"
 	"go-todo-app-clean-arch/pkg/logger"
 )
 
+// 受付を止めてから処理中のリクエストが終わるのを待つ時間
+const shutdownTimeout = 2 * time.Second
+
 func main() {
-	appEnv := pkg.GetEnvDefault("APP_ENV", "development")
-	if appEnv == "development" {
+	// .env.development は環境変数として読み込むので、設定ファイルやフラグの指定が優先する
+	if pkg.GetEnvDefault("APP_ENV", "development") == "development" {
 		err := godotenv.Load(".env.development")
 		if err != nil {
-			logger.Warn("Error loading .env.local file")
+			logger.Warn("Error loading .env.development file")
 		}
-		logger.Info("Loaded SECRET from .env.development: ", os.Getenv("SECRET"))
 	}
 
-	db, err := database.NewDatabaseSQLFactory(database.InstanceMySQL)
+	conf, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err = errors.Join(err, conf.Validate()); err != nil {
+		logger.Fatal("invalid configuration:\n" + err.Error())
+	}
+	if err := logger.Configure(conf.Log.Level, conf.Log.File, conf.IsDevelopment()); err != nil {
+		logger.Fatal(err.Error())
+	}
+
+	db, err := connectDatabase(&conf.Database)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
 
-	// server, err := web.NewServer(web.InstanceGin, db)
-	server, err := web.NewServer(web.InstanceEcho, db)
+	// 通常は cmd/migrate で適用する。コンテナ起動時などに自動で適用したい場合だけ有効にする
+	if conf.Database.MigrateOnStart {
+		migrator, err := database.NewMigrator(db)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		migrations, err := migrator.Up()
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		for _, migration := range migrations {
+			logger.Info(fmt.Sprintf("Applied migration %04d_%s", migration.Version, migration.Name))
+		}
+	}
+
+	server, err := web.NewServer(conf, db)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
@@ -51,10 +78,20 @@ func main() {
 	log.Println("Shutdown Server ...")
 	defer logger.Sync()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	// readiness を失敗させている間と、処理中のリクエストを待つ時間
+	ctx, cancel := context.WithTimeout(context.Background(), conf.Web.ShutdownDrainDelay+shutdownTimeout)
 	defer cancel()
 	if err := server.Shutdown(ctx); err != nil {
 		logger.Error(fmt.Sprintf("Server Shutdown: %s", err.Error()))
 	}
-	<-ctx.Done()
+}
+
+// connectDatabase は database.connect_timeout の間、DB に接続できるまで再試行する
+// 待っている間に SIGINT・SIGTERM を受けた場合はすぐに諦める
+func connectDatabase(conf *config.DatabaseConfig) (*gorm.DB, error) {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, conf.ConnectTimeout)
+	defer cancel()
+	return database.Connect(ctx, database.NewConfig(conf))
 }
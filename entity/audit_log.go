@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// 監査ログに記録する出来事
+const (
+	// AuditLoginLocked はログインの失敗が続き、アカウントまたは接続元をロックしたこと
+	AuditLoginLocked = "login.locked"
+)
+
+// AuditLog はセキュリティに関わる出来事の記録
+type AuditLog struct {
+	ID     int
+	Action string
+	// Target は出来事の対象。"account:" + メールアドレスのように種類を前に付ける
+	Target    string
+	IPAddress string
+	Detail    string
+	CreatedAt time.Time
+}
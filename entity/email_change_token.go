@@ -0,0 +1,8 @@
+package entity
+
+// EmailChangeToken はメールアドレスの変更を確認するため、新しいアドレスに送る 1 回限りのトークン
+// 確認されるまで User.Email は変えない
+type EmailChangeToken struct {
+	SingleUseToken
+	NewEmail string
+}
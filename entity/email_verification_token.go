@@ -0,0 +1,6 @@
+package entity
+
+// EmailVerificationToken は登録時にメールで送る、メールアドレス確認用の 1 回限りのトークン
+type EmailVerificationToken struct {
+	SingleUseToken
+}
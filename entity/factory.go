@@ -1,5 +0,0 @@
-package entity
-
-func NewDomains() []interface{} {
-	return []interface{}{&Task{}, &User{}}
-}
\ No newline at end of file
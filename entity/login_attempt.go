@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// ログインの失敗を数える単位
+const (
+	LoginAttemptAccount = "account"
+	LoginAttemptIP      = "ip"
+)
+
+// LoginAttempt はアカウントまたは接続元ごとのログインの失敗の記録
+type LoginAttempt struct {
+	// Kind は LoginAttemptAccount か LoginAttemptIP。Identifier はメールアドレスか IP アドレス
+	Kind         string `gorm:"primaryKey"`
+	Identifier   string `gorm:"primaryKey"`
+	Failures     int
+	LastFailedAt time.Time
+	LockedUntil  *time.Time
+}
+
+// IsLocked は now の時点でロックされているかを返す
+func (a *LoginAttempt) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// LoginChallengeMaxAttempts は 1 つのチャレンジで 2 要素目を入力できる回数
+const LoginChallengeMaxAttempts = 5
+
+// LoginChallenge はパスワードの確認が済み、2 要素目の入力を待っているログイン
+// トークンはログインのレスポンスでだけ返す
+type LoginChallenge struct {
+	SingleUseToken
+	Attempts int
+}
+
+// IsUsable はまだ使われておらず、有効期限内で、入力できる回数が残っているかを返す
+func (c *LoginChallenge) IsUsable(now time.Time) bool {
+	return c.SingleUseToken.IsUsable(now) && c.Attempts < LoginChallengeMaxAttempts
+}
@@ -0,0 +1,6 @@
+package entity
+
+// PasswordResetToken はパスワード再設定メールで送る 1 回限りのトークン
+type PasswordResetToken struct {
+	SingleUseToken
+}
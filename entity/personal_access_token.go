@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// パーソナルアクセストークンで許可できる操作
+const (
+	ScopeTasksRead  = "tasks:read"
+	ScopeTasksWrite = "tasks:write"
+	ScopeUserRead   = "user:read"
+)
+
+// AccessTokenScopes はパーソナルアクセストークンに指定できるスコープの一覧
+var AccessTokenScopes = []string{ScopeTasksRead, ScopeTasksWrite, ScopeUserRead}
+
+// PersonalAccessToken はスクリプトや CI から API を呼ぶためのトークン
+// RefreshToken と同じく、DB には SHA-256 のハッシュだけを保存する
+type PersonalAccessToken struct {
+	ID        int
+	UserID    int
+	Name      string
+	TokenHash string
+	// Scopes は許可するスコープを空白で区切った文字列
+	Scopes     string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// IsValidAccessTokenScope は scope がパーソナルアクセストークンに指定できるかを返す
+func IsValidAccessTokenScope(scope string) bool {
+	for _, s := range AccessTokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeList はスコープを 1 つずつに分けて返す
+func (t *PersonalAccessToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
+
+// HasScope は scope が許可されているかを返す
+func (t *PersonalAccessToken) HasScope(scope string) bool {
+	for _, s := range t.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUsable は失効しておらず、有効期限内かを返す。ExpiresAt が nil のトークンは期限がない
+func (t *PersonalAccessToken) IsUsable(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
@@ -0,0 +1,20 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/entity"
+)
+
+func TestPersonalAccessTokenHasScope(t *testing.T) {
+	token := entity.PersonalAccessToken{Scopes: "tasks:read user:read"}
+	assert.Equal(t, []string{entity.ScopeTasksRead, entity.ScopeUserRead}, token.ScopeList())
+	assert.True(t, token.HasScope(entity.ScopeTasksRead))
+	assert.False(t, token.HasScope(entity.ScopeTasksWrite))
+	assert.False(t, token.HasScope("tasks"))
+
+	assert.True(t, entity.IsValidAccessTokenScope(entity.ScopeTasksWrite))
+	assert.False(t, entity.IsValidAccessTokenScope("admin"))
+}
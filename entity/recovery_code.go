@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// RecoveryCode は認証アプリを使えないときに TOTP の代わりに使う 1 回限りのコード
+// DB には正規化したコードの SHA-256 だけを保存する
+type RecoveryCode struct {
+	ID        int
+	UserID    int
+	CodeHash  string
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// RefreshToken はアクセストークンを再発行するためのトークン
+// トークン自体はクライアントだけが持ち、DB には SHA-256 のハッシュだけを保存する
+// FamilyID はログインごとに発行し、ローテーションで発行し直したトークンにも引き継ぐ
+type RefreshToken struct {
+	ID        int
+	UserID    int
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	// UsedAt はローテーションで使用済みになった日時。使用済みのトークンが再び使われたら漏洩とみなす
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// IsUsable はまだ使用・失効しておらず、有効期限内かを返す
+func (t *RefreshToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// Session はログイン 1 回分の利用状況
+// ID はアクセストークンの jti とリフレッシュトークンの FamilyID に使う
+type Session struct {
+	ID         string
+	UserID     int
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	// ExpiresAt は最後に発行したリフレッシュトークンの有効期限。これを過ぎると再発行できない
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+// IsActive は失効しておらず、有効期限内かを返す
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
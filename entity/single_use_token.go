@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// SingleUseToken はメールで送るなど、1 回だけ使えるトークンに共通する項目
+// トークン自体は受け取った人だけが持ち、DB には SHA-256 のハッシュだけを保存する
+type SingleUseToken struct {
+	ID        int
+	UserID    int
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// IsUsable はまだ使われておらず、有効期限内かを返す
+func (t *SingleUseToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
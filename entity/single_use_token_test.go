@@ -0,0 +1,51 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/entity"
+)
+
+// 有効期限や失効・ロックを持つエンティティの now の時点の状態をまとめて確認する
+func TestValidity(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+
+	tests := map[string]struct {
+		check func(now time.Time) bool
+		at    time.Time
+		want  bool
+	}{
+		"single use token":         {(&entity.SingleUseToken{ExpiresAt: later}).IsUsable, now, true},
+		"single use token expired": {(&entity.SingleUseToken{ExpiresAt: later}).IsUsable, later, false},
+		"single use token used":    {(&entity.SingleUseToken{ExpiresAt: later, UsedAt: &now}).IsUsable, now, false},
+		// 埋め込んだエンティティも同じ判定を使う
+		"email change token used": {(&entity.EmailChangeToken{SingleUseToken: entity.SingleUseToken{ExpiresAt: later, UsedAt: &now}}).IsUsable, now, false},
+		"login challenge":         {(&entity.LoginChallenge{SingleUseToken: entity.SingleUseToken{ExpiresAt: later}}).IsUsable, now, true},
+		"login challenge expired": {(&entity.LoginChallenge{SingleUseToken: entity.SingleUseToken{ExpiresAt: later}}).IsUsable, later, false},
+		"login challenge out of attempts": {
+			(&entity.LoginChallenge{SingleUseToken: entity.SingleUseToken{ExpiresAt: later}, Attempts: entity.LoginChallengeMaxAttempts}).IsUsable, now, false,
+		},
+		"refresh token":               {(&entity.RefreshToken{ExpiresAt: later}).IsUsable, now, true},
+		"refresh token expired":       {(&entity.RefreshToken{ExpiresAt: later}).IsUsable, later, false},
+		"refresh token used":          {(&entity.RefreshToken{ExpiresAt: later, UsedAt: &now}).IsUsable, now, false},
+		"refresh token revoked":       {(&entity.RefreshToken{ExpiresAt: later, RevokedAt: &now}).IsUsable, now, false},
+		"session":                     {(&entity.Session{ExpiresAt: later}).IsActive, now, true},
+		"session expired":             {(&entity.Session{ExpiresAt: later}).IsActive, later, false},
+		"session revoked":             {(&entity.Session{ExpiresAt: later, RevokedAt: &now}).IsActive, now, false},
+		"access token without expiry": {(&entity.PersonalAccessToken{}).IsUsable, later, true},
+		"access token":                {(&entity.PersonalAccessToken{ExpiresAt: &later}).IsUsable, now, true},
+		"access token expired":        {(&entity.PersonalAccessToken{ExpiresAt: &later}).IsUsable, later, false},
+		"access token revoked":        {(&entity.PersonalAccessToken{RevokedAt: &now}).IsUsable, now, false},
+		// LoginAttempt はロック中に true になる
+		"login attempt":              {(&entity.LoginAttempt{Failures: 3}).IsLocked, now, false},
+		"login attempt locked":       {(&entity.LoginAttempt{LockedUntil: &later}).IsLocked, now, true},
+		"login attempt lock elapsed": {(&entity.LoginAttempt{LockedUntil: &later}).IsLocked, later, false},
+	}
+	for name, tt := range tests {
+		assert.Equal(t, tt.want, tt.check(tt.at), name)
+	}
+}
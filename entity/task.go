@@ -1,9 +1,74 @@
 package entity
 
+import "time"
+
+type TaskStatus string
+
+const (
+	TaskStatusTodo       TaskStatus = "todo"
+	TaskStatusInProgress TaskStatus = "in_progress"
+	TaskStatusDone       TaskStatus = "done"
+	TaskStatusArchived   TaskStatus = "archived"
+)
+
+func (s TaskStatus) IsValid() bool {
+	switch s {
+	case TaskStatusTodo, TaskStatusInProgress, TaskStatusDone, TaskStatusArchived:
+		return true
+	}
+	return false
+}
+
+type TaskPriority int
+
+// 0 は未指定として扱うため、優先度は 1 から始める
+const (
+	TaskPriorityLow TaskPriority = iota + 1
+	TaskPriorityMedium
+	TaskPriorityHigh
+)
+
+func (p TaskPriority) IsValid() bool {
+	return p >= TaskPriorityLow && p <= TaskPriorityHigh
+}
+
 type Task struct {
-	ID          int 	`json:"id" gorm:"primaryKey"`
-	Title       string  `json:"title" gorm:"not null"`
-	UserID      int 	`json:"user_id" gorm:"not null"`
+	ID          int          `json:"id" gorm:"primaryKey"`
+	Title       string       `json:"title" gorm:"not null"`
+	Description string       `json:"description" gorm:"type:text"`
+	Status      TaskStatus   `json:"status" gorm:"type:varchar(20);not null;default:todo"`
+	Priority    TaskPriority `json:"priority" gorm:"not null;default:2"`
+	DueAt       *time.Time   `json:"due_at"`
+	CompletedAt *time.Time   `json:"completed_at"`
+	UserID      int          `json:"user_id" gorm:"not null"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// SetStatus はステータスを変更し、完了日時を合わせて更新する
+// done に遷移したときだけ CompletedAt を記録し、done 以外に戻した場合はクリアする
+func (t *Task) SetStatus(status TaskStatus, now time.Time) {
+	if t.Status == status && (status != TaskStatusDone || t.CompletedAt != nil) {
+		return
+	}
+	t.Status = status
+	if status == TaskStatusDone {
+		t.CompletedAt = &now
+		return
+	}
+	t.CompletedAt = nil
+}
+
+// TaskUpdate はタスクの部分更新。nil の項目は今の値のままにする
+type TaskUpdate struct {
+	Title *string
+	// Description を空文字列にすると説明を消す
+	Description *string
+	Status      *TaskStatus
+	Priority    *TaskPriority
+	DueAt       *time.Time
+	// ClearDueAt が true なら DueAt にかかわらず期限を消す
+	ClearDueAt bool
 }
 
 // jsonの設定を追加しないと、フロントエンドにデータを返す際にKeyがIDなど大文字になってしまう
@@ -19,4 +84,4 @@ type Task struct {
 // 	"id": 1,
 // 	"title": "test",
 // 	"user_id": 1
-// }
\ No newline at end of file
+// }
@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var ErrInvalidTaskCursor = errors.New("invalid task cursor")
+
+type TaskSortField string
+
+const (
+	TaskSortCreatedAt TaskSortField = "created_at"
+	TaskSortUpdatedAt TaskSortField = "updated_at"
+	TaskSortDueAt     TaskSortField = "due_at"
+	TaskSortPriority  TaskSortField = "priority"
+	TaskSortTitle     TaskSortField = "title"
+)
+
+func (f TaskSortField) IsValid() bool {
+	switch f {
+	case TaskSortCreatedAt, TaskSortUpdatedAt, TaskSortDueAt, TaskSortPriority, TaskSortTitle:
+		return true
+	}
+	return false
+}
+
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+func (d SortDirection) IsValid() bool {
+	return d == SortAsc || d == SortDesc
+}
+
+// TaskQuery はタスク一覧の絞り込み・並び替え・ページングの条件
+// ゼロ値の項目は条件として使わない
+type TaskQuery struct {
+	UserID        int
+	Statuses      []TaskStatus
+	Priority      TaskPriority
+	DueBefore     *time.Time
+	DueAfter      *time.Time
+	Title         string
+	SortField     TaskSortField
+	SortDirection SortDirection
+	Cursor        *TaskCursor
+	Limit         int
+}
+
+type TaskPage struct {
+	Tasks      []*Task
+	NextCursor *TaskCursor
+}
+
+// TaskCursor は前ページ最後のタスクの並び替えキーと ID を保持する
+// クライアントには Encode した不透明な文字列として渡す
+type TaskCursor struct {
+	SortField     TaskSortField `json:"s"`
+	SortDirection SortDirection `json:"d"`
+	Value         string        `json:"v,omitempty"`
+	IsNull        bool          `json:"n,omitempty"`
+	ID            int           `json:"i"`
+}
+
+func NewTaskCursor(task *Task, field TaskSortField, direction SortDirection) *TaskCursor {
+	cursor := &TaskCursor{SortField: field, SortDirection: direction, ID: task.ID}
+	switch field {
+	case TaskSortCreatedAt:
+		cursor.Value = task.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case TaskSortUpdatedAt:
+		cursor.Value = task.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case TaskSortDueAt:
+		if task.DueAt == nil {
+			cursor.IsNull = true
+		} else {
+			cursor.Value = task.DueAt.UTC().Format(time.RFC3339Nano)
+		}
+	case TaskSortPriority:
+		cursor.Value = strconv.Itoa(int(task.Priority))
+	case TaskSortTitle:
+		cursor.Value = task.Title
+	}
+	return cursor
+}
+
+func DecodeTaskCursor(s string) (*TaskCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidTaskCursor
+	}
+	cursor := &TaskCursor{}
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return nil, ErrInvalidTaskCursor
+	}
+	if !cursor.SortField.IsValid() || !cursor.SortDirection.IsValid() || cursor.ID <= 0 {
+		return nil, ErrInvalidTaskCursor
+	}
+	if _, err := cursor.SortValue(); err != nil {
+		return nil, ErrInvalidTaskCursor
+	}
+	return cursor, nil
+}
+
+func (c *TaskCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// SortValue は並び替えキーを DB の比較に使える型で返す
+func (c *TaskCursor) SortValue() (interface{}, error) {
+	if c.IsNull {
+		return nil, nil
+	}
+	switch c.SortField {
+	case TaskSortCreatedAt, TaskSortUpdatedAt, TaskSortDueAt:
+		t, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, err
+		}
+		return t.Local(), nil
+	case TaskSortPriority:
+		return strconv.Atoi(c.Value)
+	case TaskSortTitle:
+		return c.Value, nil
+	}
+	return nil, ErrInvalidTaskCursor
+}
@@ -0,0 +1,43 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/entity"
+)
+
+func TestTaskCursorEncodeDecode(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 500, time.UTC)
+	task := &entity.Task{ID: 10, CreatedAt: createdAt}
+
+	cursor := entity.NewTaskCursor(task, entity.TaskSortCreatedAt, entity.SortDesc)
+	decoded, err := entity.DecodeTaskCursor(cursor.Encode())
+	assert.Nil(t, err)
+	assert.Equal(t, cursor, decoded)
+
+	value, err := decoded.SortValue()
+	assert.Nil(t, err)
+	assert.True(t, createdAt.Equal(value.(time.Time)))
+}
+
+func TestTaskCursorNullDueAt(t *testing.T) {
+	cursor := entity.NewTaskCursor(&entity.Task{ID: 3}, entity.TaskSortDueAt, entity.SortAsc)
+	assert.True(t, cursor.IsNull)
+
+	decoded, err := entity.DecodeTaskCursor(cursor.Encode())
+	assert.Nil(t, err)
+	value, err := decoded.SortValue()
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+}
+
+func TestDecodeTaskCursorInvalid(t *testing.T) {
+	for _, s := range []string{"", "not base64!", "e30", "eyJzIjoiaWQiLCJkIjoiYXNjIiwiaSI6MX0"} {
+		cursor, err := entity.DecodeTaskCursor(s)
+		assert.Nil(t, cursor)
+		assert.ErrorIs(t, err, entity.ErrInvalidTaskCursor)
+	}
+}
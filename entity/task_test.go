@@ -2,6 +2,7 @@ package entity_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -19,3 +20,30 @@ func TestTask(t *testing.T) {
 	assert.Equal(t, "Test Task", task.Title)
 	assert.Equal(t, 1, task.UserID)
 }
+
+func TestTaskStatusIsValid(t *testing.T) {
+	assert.True(t, entity.TaskStatusTodo.IsValid())
+	assert.True(t, entity.TaskStatusInProgress.IsValid())
+	assert.True(t, entity.TaskStatusDone.IsValid())
+	assert.True(t, entity.TaskStatusArchived.IsValid())
+	assert.False(t, entity.TaskStatus("unknown").IsValid())
+	assert.False(t, entity.TaskPriority(0).IsValid())
+	assert.True(t, entity.TaskPriorityHigh.IsValid())
+}
+
+func TestTaskSetStatus(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	task := entity.Task{Status: entity.TaskStatusTodo}
+
+	task.SetStatus(entity.TaskStatusDone, now)
+	assert.Equal(t, entity.TaskStatusDone, task.Status)
+	assert.Equal(t, now, *task.CompletedAt)
+
+	// 完了済みのまま再度 done にしても完了日時は変わらない
+	task.SetStatus(entity.TaskStatusDone, now.Add(time.Hour))
+	assert.Equal(t, now, *task.CompletedAt)
+
+	task.SetStatus(entity.TaskStatusInProgress, now)
+	assert.Equal(t, entity.TaskStatusInProgress, task.Status)
+	assert.Nil(t, task.CompletedAt)
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// TOTPCredential はユーザーの認証アプリ（TOTP）の登録
+// ConfirmedAt が nil の間は登録の途中で、ログインでは 2 要素目を求めない
+type TOTPCredential struct {
+	UserID      int `gorm:"primaryKey;autoIncrement:false"`
+	Secret      string
+	ConfirmedAt *time.Time
+	// LastUsedStep は最後に受け付けたコードのステップ。これ以前のコードは受け付けない
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+// IsEnabled は登録が完了し、ログインで 2 要素目を求めるかを返す
+func (c *TOTPCredential) IsEnabled() bool {
+	return c.ConfirmedAt != nil
+}
@@ -0,0 +1,19 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/entity"
+)
+
+func TestTOTPCredentialIsEnabled(t *testing.T) {
+	credential := entity.TOTPCredential{UserID: 1, Secret: "JBSWY3DPEHPK3PXP"}
+	assert.False(t, credential.IsEnabled())
+
+	confirmedAt := time.Now()
+	credential.ConfirmedAt = &confirmedAt
+	assert.True(t, credential.IsEnabled())
+}
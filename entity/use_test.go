@@ -2,6 +2,7 @@ package entity_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -19,3 +20,12 @@ func TestUser(t *testing.T) {
 	assert.Equal(t, "test@example.com", user.Email)
 	assert.Equal(t, "password123", user.Password)
 }
+
+func TestUserIsEmailVerified(t *testing.T) {
+	user := entity.User{}
+	assert.False(t, user.IsEmailVerified())
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	user.EmailVerifiedAt = &now
+	assert.True(t, user.IsEmailVerified())
+}
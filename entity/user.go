@@ -1,12 +1,27 @@
 package entity
 
+import "time"
+
 type User struct {
-	ID       int
-	Email    string
-	Password string
+	ID    int
+	Email string `gorm:"unique;not null"`
+	// Password はパスワードのハッシュ。OpenID Provider でだけログインするユーザーは空
+	Password string `gorm:"not null"`
+	// EmailVerifiedAt はメールアドレスの確認が済んだ日時。未確認の場合は nil
+	EmailVerifiedAt *time.Time
+}
+
+// IsEmailVerified はメールアドレスの確認が済んでいるかを返す
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// HasPassword はパスワードでログインできるかを返す
+func (u *User) HasPassword() bool {
+	return u.Password != ""
 }
 
 type Credentials struct {
 	Email    string
 	Password string
-}
\ No newline at end of file
+}
@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// UserIdentity は外部の OpenID Provider のアカウントとユーザーの連携
+// Provider は設定での名前、Subject は ID トークンの sub で、この組で 1 つのアカウントを表す
+type UserIdentity struct {
+	ID       int
+	UserID   int
+	Provider string
+	Subject  string
+	// Email は連携したときにプロバイダーが返したメールアドレス（表示用）
+	Email     string
+	CreatedAt time.Time
+}
+
+// OIDCLoginState は OpenID Provider の認可エンドポイントに送った、ログイン・連携の途中の状態
+// state はブラウザにだけ渡し、DB には SHA-256 のハッシュだけを保存する
+type OIDCLoginState struct {
+	ID        int
+	StateHash string
+	Provider  string
+	// Nonce は ID トークンに入っていることを確かめ、CodeVerifier は認可コードの交換で送る（PKCE）
+	Nonce        string
+	CodeVerifier string
+	// UserID は連携の場合に連携先のユーザー。ログインの場合は nil
+	UserID    *int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// TableName は GORM のテーブル名。既定の命名では o_id_c_login_states になるため指定する
+func (OIDCLoginState) TableName() string {
+	return "oidc_login_states"
+}
+
+// IsUsable は有効期限内かを返す。使った state は削除するので、使用済みかどうかは持たない
+func (s *OIDCLoginState) IsUsable(now time.Time) bool {
+	return now.Before(s.ExpiresAt)
+}
+
+// IsLink はアカウントの連携のための状態かを返す
+func (s *OIDCLoginState) IsLink() bool {
+	return s.UserID != nil
+}
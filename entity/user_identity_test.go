@@ -0,0 +1,27 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/entity"
+)
+
+func TestOIDCLoginState(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := entity.OIDCLoginState{ExpiresAt: now.Add(10 * time.Minute)}
+	assert.True(t, state.IsUsable(now))
+	assert.False(t, state.IsUsable(now.Add(10*time.Minute)))
+	assert.False(t, state.IsLink())
+
+	userID := 1
+	state.UserID = &userID
+	assert.True(t, state.IsLink())
+}
+
+func TestUserHasPassword(t *testing.T) {
+	assert.True(t, (&entity.User{Password: "$argon2id$v=19$m=19456,t=2,p=1$c2FsdA$a2V5"}).HasPassword())
+	assert.False(t, (&entity.User{}).HasPassword())
+}
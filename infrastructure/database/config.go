@@ -1,7 +1,11 @@
 package database
 
 import (
-	"go-todo-app-clean-arch/pkg"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go-todo-app-clean-arch/pkg/config"
 )
 
 type Config struct {
@@ -11,21 +15,75 @@ type Config struct {
 	Driver   string
 	User     string
 	Password string
+	// SSLMode と SSLRootCert は PostgreSQL でのみ使う
+	SSLMode     string
+	SSLRootCert string
+	// 0 の場合は database/sql の既定値のまま
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
 }
 
-func NewConfigMySQL() *Config {
+// NewConfig は設定ファイル・環境変数などから読み込んだ DB の設定を接続用の設定に変換する
+func NewConfig(c *config.DatabaseConfig) *Config {
 	return &Config{
-		Host:     pkg.GetEnvDefault("DB_HOST", "localhost"),
-		Database: pkg.GetEnvDefault("DB_NAME", "api_database"),
-		Port:     pkg.GetEnvDefault("DB_PORT", "3306"),
-		Driver:   pkg.GetEnvDefault("DB_DRIVER", "mysql"),
-		User:     pkg.GetEnvDefault("DB_USER", "app"),
-		Password: pkg.GetEnvDefault("DB_PASSWORD", "password"),
+		Host:            c.Host,
+		Database:        c.Name,
+		Port:            c.Port,
+		Driver:          c.Driver,
+		User:            c.User,
+		Password:        c.Password,
+		SSLMode:         c.SSLMode,
+		SSLRootCert:     c.SSLRootCert,
+		MaxOpenConns:    c.MaxOpenConns,
+		MaxIdleConns:    c.MaxIdleConns,
+		ConnMaxLifetime: c.ConnMaxLifetime,
+		ConnMaxIdleTime: c.ConnMaxIdleTime,
 	}
 }
 
-func NewConfigSQLite() *Config {
-	return &Config{
-		Database: pkg.GetEnvDefault("DB_NAME", "api_database.sqlite"),
+func (c *Config) MySQLDSN() string {
+	return fmt.Sprintf(
+		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True",
+		c.User,
+		c.Password,
+		c.Host,
+		c.Port,
+		c.Database)
+}
+
+// PostgresDSN は URL 形式の接続文字列を返す
+// パスワードなどに記号が含まれていてもよいよう、各要素はエスケープする
+func (c *Config) PostgresDSN() (string, error) {
+	if !isValidSSLMode(c.SSLMode) {
+		return "", fmt.Errorf("%w: %q", errInvalidSSLMode, c.SSLMode)
+	}
+	query := url.Values{}
+	query.Set("sslmode", c.SSLMode)
+	if c.SSLRootCert != "" {
+		query.Set("sslrootcert", c.SSLRootCert)
+	}
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     fmt.Sprintf("%s:%s", c.Host, c.Port),
+		Path:     "/" + c.Database,
+		RawQuery: query.Encode(),
 	}
-}
\ No newline at end of file
+	return dsn.String(), nil
+}
+
+// SQLiteDSN は外部キー制約を有効にした接続文字列を返す
+// SQLite は既定で外部キーを検証しないため、他の DB と挙動を揃える
+func (c *Config) SQLiteDSN() string {
+	return c.Database + "?_foreign_keys=on"
+}
+
+func isValidSSLMode(mode string) bool {
+	switch mode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+		return true
+	}
+	return false
+}
@@ -1,39 +1,103 @@
 package database
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
+
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/pkg"
+	"go-todo-app-clean-arch/pkg/logger"
 )
 
 const (
 	InstanceSQLite = iota
 	InstanceMySQL
+	InstancePostgres
 )
 
 var (
 	errInvalidSQLDatabaseInstance = errors.New("invalid sql db instance")
+	errInvalidSSLMode             = errors.New("invalid sslmode")
 )
 
-func NewDatabaseSQLFactory(instance int) (db *gorm.DB, err error) {
+// InstanceFromDriver は DB_DRIVER などで指定されたドライバ名を Instance に変換する
+func InstanceFromDriver(driver string) (int, error) {
+	switch driver {
+	case "sqlite":
+		return InstanceSQLite, nil
+	case "mysql":
+		return InstanceMySQL, nil
+	case "postgres":
+		return InstancePostgres, nil
+	}
+	return 0, fmt.Errorf("%w: %q", errInvalidSQLDatabaseInstance, driver)
+}
+
+// NewDatabaseSQLFactory は config.Driver の DB に接続し、コネクションプールを設定する
+func NewDatabaseSQLFactory(config *Config) (db *gorm.DB, err error) {
+	instance, err := InstanceFromDriver(config.Driver)
+	if err != nil {
+		return nil, err
+	}
 	switch instance {
 	case InstanceMySQL:
-		configs := NewConfigMySQL()
-		dsn := fmt.Sprintf(
-			"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True",
-			configs.User,
-			configs.Password,
-			configs.Host,
-			configs.Port,
-			configs.Database)
-		db, err = gorm.Open(mysql.Open(dsn), &gorm.Config{})
+		db, err = gorm.Open(mysql.Open(config.MySQLDSN()), &gorm.Config{})
+	case InstancePostgres:
+		var dsn string
+		if dsn, err = config.PostgresDSN(); err != nil {
+			return nil, err
+		}
+		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	case InstanceSQLite:
-		configs := NewConfigSQLite()
-		db, err = gorm.Open(sqlite.Open(configs.Database), &gorm.Config{})
-	default:
-		return nil, errInvalidSQLDatabaseInstance
+		db, err = gorm.Open(sqlite.Open(config.SQLiteDSN()), &gorm.Config{})
+	}
+	if err != nil {
+		// 接続の確認に失敗しても gorm はプールを返すため、再試行で溜まらないよう閉じる
+		if db != nil {
+			if sqlDB, dbErr := db.DB(); dbErr == nil {
+				sqlDB.Close()
+			}
+		}
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	if config.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(config.MaxOpenConns)
+	}
+	if config.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(config.MaxIdleConns)
+	}
+	sqlDB.SetConnMaxLifetime(config.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+	return db, nil
+}
+
+// 起動時の接続の再試行間隔
+var connectBackoff = pkg.Backoff{Initial: 500 * time.Millisecond, Max: 5 * time.Second, Multiplier: 2}
+
+// Connect は DB に接続できるまで ctx の期限まで待ち時間を伸ばしながら再試行する
+// DB のコンテナがサーバーより遅れて起動する場合に備える
+func Connect(ctx context.Context, config *Config) (*gorm.DB, error) {
+	var db *gorm.DB
+	err := pkg.Retry(ctx, connectBackoff, func() (err error) {
+		db, err = NewDatabaseSQLFactory(config)
+		return err
+	}, func(attempt int, wait time.Duration, err error) {
+		logger.Warn("Database is not ready, retrying",
+			"driver", config.Driver, "attempt", attempt, "wait", wait.String(), "error", err.Error())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("connect to %s database: %w", config.Driver, err)
 	}
-	return db, err
+	return db, nil
 }
@@ -0,0 +1,45 @@
+package database_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/infrastructure/database"
+)
+
+func TestConnectAppliesPoolSettings(t *testing.T) {
+	db, err := database.Connect(context.Background(), &database.Config{
+		Driver:          "sqlite",
+		Database:        filepath.Join(t.TempDir(), "connect.sqlite"),
+		MaxOpenConns:    3,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: time.Minute,
+	})
+	assert.Nil(t, err)
+
+	sqlDB, err := db.DB()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, sqlDB.Stats().MaxOpenConnections)
+	assert.Nil(t, sqlDB.Close())
+}
+
+func TestConnectGivesUpAfterTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// 接続を受け付けないポートに接続し続け、期限で諦める
+	start := time.Now()
+	_, err := database.Connect(ctx, &database.Config{
+		Driver:   "mysql",
+		Host:     "127.0.0.1",
+		Port:     "1",
+		Database: "api_database",
+		User:     "app",
+	})
+	assert.ErrorContains(t, err, "connect to mysql database")
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
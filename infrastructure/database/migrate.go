@@ -0,0 +1,323 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MigrationsDir はリポジトリ内のマイグレーションファイルの置き場所
+// 新しいファイルを作るときに使い、実行時はバイナリに埋め込んだものを読む
+const MigrationsDir = "infrastructure/database/migrations"
+
+//go:embed migrations
+var embeddedMigrations embed.FS
+
+// マイグレーションは方言ごとのディレクトリに同じバージョン・名前で置く
+var migrationDialects = []string{"mysql", "postgres", "sqlite"}
+
+var (
+	migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+	migrationNamePattern = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+var (
+	ErrUnsupportedDialect   = errors.New("unsupported migration dialect")
+	ErrInvalidMigrationName = errors.New("invalid migration name")
+	ErrInvalidMigrationStep = errors.New("number of migrations to roll back must be greater than 0")
+)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// schemaMigration は適用済みのマイグレーションを記録する schema_migrations テーブル
+type schemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator は db の方言に合わせた埋め込みマイグレーションを読み込む
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := LoadMigrations(embeddedMigrations, "migrations", db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// LoadMigrations は dir/<dialect> 以下の up/down ファイルをバージョン順に読み込む
+func LoadMigrations(fsys fs.FS, dir, dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, path.Join(dir, dialect))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDialect, dialect)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	files := map[int]int{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		matches := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("unexpected migration file %s", entry.Name())
+		}
+		version, _ := strconv.Atoi(matches[1])
+		body, err := fs.ReadFile(fsys, path.Join(dir, dialect, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		} else if migration.Name != matches[2] {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, matches[2])
+		}
+		if matches[3] == "up" {
+			migration.Up = string(body)
+		} else {
+			migration.Down = string(body)
+		}
+		files[version]++
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if files[migration.Version] != 2 {
+			return nil, fmt.Errorf("migration %04d_%s must have both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up は未適用のマイグレーションをすべて適用し、適用したものを返す
+func (m *Migrator) Up() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := execStatements(tx, migration.Up); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s up: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down は適用済みのマイグレーションを新しいものから n 件取り消し、取り消したものを返す
+func (m *Migrator) Down(n int) ([]Migration, error) {
+	if n <= 0 {
+		return nil, ErrInvalidMigrationStep
+	}
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < n; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := execStatements(tx, migration.Down); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s down: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Status はバイナリに含まれるマイグレーションと適用状況を返す
+// DB にだけ記録されているもの（別のバージョンのバイナリで適用したもの）も含める
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range applied {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, MigrationStatus{Version: record.Version, Name: record.Name, AppliedAt: &appliedAt})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Pending は未適用のマイグレーションを返す
+// readiness の確認で繰り返し呼ぶため、schema_migrations テーブルを作らずに読むだけにする
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	db := m.db.WithContext(ctx)
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return m.migrations, nil
+	}
+	var versions []int
+	if err := db.Model(&schemaMigration{}).Pluck("version", &versions).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int]bool, len(versions))
+	for _, version := range versions {
+		applied[version] = true
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+func (m *Migrator) applied() (map[int]schemaMigration, error) {
+	if err := m.db.Migrator().AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, err
+	}
+	var records []schemaMigration
+	if err := m.db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int]schemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// MySQL のドライバは 1 回の Exec で複数の文を実行できないため、文ごとに分けて実行する
+func execStatements(tx *gorm.DB, script string) error {
+	for _, statement := range splitStatements(script) {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 行末の ; で文を区切る。-- で始まる行はコメントとして読み飛ばす
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
+
+// CreateMigration は全方言のディレクトリに次の番号の up/down ファイルを作り、作成したパスを返す
+func CreateMigration(dir, name string) ([]string, error) {
+	name = strings.Trim(migrationNamePattern.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, ErrInvalidMigrationName
+	}
+
+	version := 0
+	for _, dialect := range migrationDialects {
+		migrations, err := LoadMigrations(os.DirFS(dir), ".", dialect)
+		if err != nil && !errors.Is(err, ErrUnsupportedDialect) {
+			return nil, err
+		}
+		if len(migrations) > 0 && migrations[len(migrations)-1].Version > version {
+			version = migrations[len(migrations)-1].Version
+		}
+	}
+	version++
+
+	var created []string
+	for _, dialect := range migrationDialects {
+		if err := os.MkdirAll(filepath.Join(dir, dialect), 0o755); err != nil {
+			return created, err
+		}
+		for _, direction := range []string{"up", "down"} {
+			file := filepath.Join(dir, dialect, fmt.Sprintf("%04d_%s.%s.sql", version, name, direction))
+			body := fmt.Sprintf("-- %04d_%s (%s) %s\n", version, name, dialect, direction)
+			if err := os.WriteFile(file, []byte(body), 0o644); err != nil {
+				return created, err
+			}
+			created = append(created, file)
+		}
+	}
+	return created, nil
+}
@@ -0,0 +1,165 @@
+package database_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/infrastructure/database"
+)
+
+func openSQLite(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "migrate.sqlite")), &gorm.Config{})
+	assert.Nil(t, err)
+	return db
+}
+
+func TestMigratorUpDown(t *testing.T) {
+	db := openSQLite(t)
+	migrator, err := database.NewMigrator(db)
+	assert.Nil(t, err)
+
+	pending, err := migrator.Pending(context.Background())
+	assert.Nil(t, err)
+	assert.NotEmpty(t, pending)
+
+	applied, err := migrator.Up()
+	assert.Nil(t, err)
+	assert.Equal(t, pending, applied)
+
+	pending, err = migrator.Pending(context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, pending)
+	assert.True(t, db.Migrator().HasTable("users"))
+	assert.True(t, db.Migrator().HasTable("tasks"))
+
+	// 2 回目は何も適用しない
+	applied, err = migrator.Up()
+	assert.Nil(t, err)
+	assert.Empty(t, applied)
+
+	statuses, err := migrator.Status()
+	assert.Nil(t, err)
+	for _, status := range statuses {
+		assert.NotNil(t, status.AppliedAt)
+	}
+
+	last := statuses[len(statuses)-1]
+	rolledBack, err := migrator.Down(1)
+	assert.Nil(t, err)
+	assert.Len(t, rolledBack, 1)
+	assert.Equal(t, last.Version, rolledBack[0].Version)
+
+	statuses, err = migrator.Status()
+	assert.Nil(t, err)
+	assert.Nil(t, statuses[len(statuses)-1].AppliedAt)
+
+	pending, err = migrator.Pending(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, rolledBack, pending)
+
+	rolledBack, err = migrator.Down(len(statuses))
+	assert.Nil(t, err)
+	assert.Len(t, rolledBack, len(statuses)-1)
+	assert.False(t, db.Migrator().HasTable("users"))
+	assert.False(t, db.Migrator().HasTable("tasks"))
+
+	_, err = migrator.Down(0)
+	assert.ErrorIs(t, err, database.ErrInvalidMigrationStep)
+}
+
+func TestMigratorUpgradesExistingTasks(t *testing.T) {
+	db := openSQLite(t)
+	// 旧 init.sql で作成したテーブル。説明やステータスの列はない
+	assert.Nil(t, db.Exec(`CREATE TABLE users (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		email VARCHAR(255) NOT NULL UNIQUE,
+		password VARCHAR(255) NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`).Error)
+	assert.Nil(t, db.Exec(`CREATE TABLE tasks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title VARCHAR(255) NOT NULL,
+		user_id INTEGER NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	)`).Error)
+	assert.Nil(t, db.Exec("INSERT INTO users (email, password) VALUES ('old@example.com', 'hash')").Error)
+	assert.Nil(t, db.Exec("INSERT INTO tasks (title, user_id) VALUES ('old task', 1)").Error)
+
+	migrator, err := database.NewMigrator(db)
+	assert.Nil(t, err)
+	_, err = migrator.Up()
+	assert.Nil(t, err)
+
+	for _, column := range []string{"description", "status", "priority", "due_at", "completed_at"} {
+		assert.True(t, db.Migrator().HasColumn("tasks", column), column)
+	}
+	var task struct {
+		Title    string
+		Status   string
+		Priority int
+	}
+	assert.Nil(t, db.Table("tasks").First(&task).Error)
+	assert.Equal(t, "old task", task.Title)
+	assert.Equal(t, "todo", task.Status)
+	assert.Equal(t, 2, task.Priority)
+}
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/sqlite/0002_second.up.sql":   {Data: []byte("CREATE TABLE b (id INTEGER);")},
+		"m/sqlite/0002_second.down.sql": {Data: []byte("DROP TABLE b;")},
+		"m/sqlite/0001_first.up.sql":    {Data: []byte("CREATE TABLE a (id INTEGER);")},
+		"m/sqlite/0001_first.down.sql":  {Data: []byte("DROP TABLE a;")},
+	}
+	migrations, err := database.LoadMigrations(fsys, "m", "sqlite")
+	assert.Nil(t, err)
+	assert.Len(t, migrations, 2)
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Equal(t, "first", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE b;", migrations[1].Down)
+
+	_, err = database.LoadMigrations(fsys, "m", "oracle")
+	assert.ErrorIs(t, err, database.ErrUnsupportedDialect)
+
+	// down がないものは読み込めない
+	fsys["m/sqlite/0003_third.up.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+	_, err = database.LoadMigrations(fsys, "m", "sqlite")
+	assert.NotNil(t, err)
+}
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, dialect := range []string{"mysql", "postgres", "sqlite"} {
+		assert.Nil(t, os.MkdirAll(filepath.Join(dir, dialect), 0o755))
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, dialect, "0007_existing.up.sql"), []byte("SELECT 1;"), 0o644))
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, dialect, "0007_existing.down.sql"), []byte("SELECT 1;"), 0o644))
+	}
+
+	files, err := database.CreateMigration(dir, "Add Task-Tags")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "mysql", "0008_add_task_tags.up.sql"),
+		filepath.Join(dir, "mysql", "0008_add_task_tags.down.sql"),
+		filepath.Join(dir, "postgres", "0008_add_task_tags.up.sql"),
+		filepath.Join(dir, "postgres", "0008_add_task_tags.down.sql"),
+		filepath.Join(dir, "sqlite", "0008_add_task_tags.up.sql"),
+		filepath.Join(dir, "sqlite", "0008_add_task_tags.down.sql"),
+	}, files)
+
+	migrations, err := database.LoadMigrations(os.DirFS(dir), ".", "mysql")
+	assert.Nil(t, err)
+	assert.Len(t, migrations, 2)
+
+	_, err = database.CreateMigration(dir, "!!!")
+	assert.ErrorIs(t, err, database.ErrInvalidMigrationName)
+}
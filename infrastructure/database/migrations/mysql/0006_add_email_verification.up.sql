@@ -0,0 +1,15 @@
+ALTER TABLE users ADD COLUMN email_verified_at DATETIME(3) NULL;
+-- 確認の仕組みより前に登録したユーザーは確認済みとして扱う
+UPDATE users SET email_verified_at = created_at;
+
+-- token_hash は確認トークンの SHA-256。トークン自体はメールでだけ送る
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at DATETIME(3) NOT NULL,
+    used_at DATETIME(3) NULL,
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    INDEX idx_email_verification_tokens_user_id (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,34 @@
+-- secret は認証アプリと共有する TOTP の秘密鍵。confirmed_at が NULL の間は登録の途中で、ログインには使わない
+-- last_used_step は最後に受け付けたコードのステップ。同じコードを 2 回使えないようにする
+CREATE TABLE IF NOT EXISTS totp_credentials (
+    user_id INT PRIMARY KEY,
+    secret VARCHAR(64) NOT NULL,
+    confirmed_at DATETIME(3) NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) DEFAULT CHARSET = utf8mb4;
+
+-- code_hash はリカバリーコードの SHA-256。コード自体は登録の完了時に 1 度だけ返す
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    used_at DATETIME(3) NULL,
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    UNIQUE KEY uq_recovery_codes_user_id_code_hash (user_id, code_hash),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) DEFAULT CHARSET = utf8mb4;
+
+-- パスワードの確認が済み、2 要素目の入力を待っているログイン
+CREATE TABLE IF NOT EXISTS login_challenges (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at DATETIME(3) NOT NULL,
+    used_at DATETIME(3) NULL,
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    INDEX idx_login_challenges_user_id (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) DEFAULT CHARSET = utf8mb4;
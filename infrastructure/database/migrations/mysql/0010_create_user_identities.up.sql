@@ -0,0 +1,28 @@
+-- 外部の OpenID Provider のアカウントとユーザーの連携。provider は設定での名前、subject は ID トークンの sub
+-- 1 人のユーザーが連携できるのは provider ごとに 1 つのアカウントだけ
+CREATE TABLE IF NOT EXISTS user_identities (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    provider VARCHAR(64) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    UNIQUE KEY uq_user_identities_provider_subject (provider, subject),
+    UNIQUE KEY uq_user_identities_user_id_provider (user_id, provider),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) DEFAULT CHARSET = utf8mb4;
+
+-- OpenID Provider の認可エンドポイントに送った、ログイン・連携の途中の状態。state_hash は state の SHA-256
+-- nonce と code_verifier はコールバックで ID トークンの検証と認可コードの交換に使う
+-- user_id は連携の場合だけ入る
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    state_hash CHAR(64) NOT NULL UNIQUE,
+    provider VARCHAR(64) NOT NULL,
+    nonce VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    user_id INT NULL,
+    expires_at DATETIME(3) NOT NULL,
+    created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) DEFAULT CHARSET = utf8mb4;
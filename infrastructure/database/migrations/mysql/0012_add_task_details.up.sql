@@ -0,0 +1,6 @@
+ALTER TABLE tasks
+    ADD COLUMN description TEXT AFTER title,
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'todo' AFTER description,
+    ADD COLUMN priority INT NOT NULL DEFAULT 2 AFTER status,
+    ADD COLUMN due_at DATETIME(3) NULL AFTER priority,
+    ADD COLUMN completed_at DATETIME(3) NULL AFTER due_at;
@@ -0,0 +1,15 @@
+-- token_hash はリフレッシュトークンの SHA-256。トークン自体は保存しない
+-- family_id はログインごとの値で、ローテーションしても引き継ぐ
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id VARCHAR(64) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ(3) NOT NULL,
+    used_at TIMESTAMPTZ(3) NULL,
+    revoked_at TIMESTAMPTZ(3) NULL,
+    created_at TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
@@ -0,0 +1,14 @@
+-- id はログインごとに発行し、アクセストークンの jti・refresh_tokens.family_id と同じ値を使う
+-- expires_at は最後に発行したリフレッシュトークンの有効期限で、過ぎたセッションは一覧に出さない
+CREATE TABLE IF NOT EXISTS sessions (
+    id VARCHAR(64) NOT NULL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ(3) NOT NULL,
+    revoked_at TIMESTAMPTZ(3) NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id);
@@ -0,0 +1,15 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMPTZ(3) NULL;
+-- 確認の仕組みより前に登録したユーザーは確認済みとして扱う
+UPDATE users SET email_verified_at = created_at;
+
+-- token_hash は確認トークンの SHA-256。トークン自体はメールでだけ送る
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ(3) NOT NULL,
+    used_at TIMESTAMPTZ(3) NULL,
+    created_at TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id ON email_verification_tokens (user_id);
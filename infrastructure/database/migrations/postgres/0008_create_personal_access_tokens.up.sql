@@ -0,0 +1,15 @@
+-- token_hash はアクセストークンの SHA-256。トークン自体は作成時に 1 回だけ返す
+-- scopes は許可するスコープを空白区切りで並べたもの
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    scopes VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ(3) NULL,
+    last_used_at TIMESTAMPTZ(3) NULL,
+    revoked_at TIMESTAMPTZ(3) NULL,
+    created_at TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_user_id ON personal_access_tokens (user_id);
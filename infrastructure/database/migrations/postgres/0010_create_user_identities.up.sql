@@ -0,0 +1,26 @@
+-- 外部の OpenID Provider のアカウントとユーザーの連携。provider は設定での名前、subject は ID トークンの sub
+-- 1 人のユーザーが連携できるのは provider ごとに 1 つのアカウントだけ
+CREATE TABLE IF NOT EXISTS user_identities (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(64) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject),
+    UNIQUE (user_id, provider)
+);
+
+-- OpenID Provider の認可エンドポイントに送った、ログイン・連携の途中の状態。state_hash は state の SHA-256
+-- nonce と code_verifier はコールバックで ID トークンの検証と認可コードの交換に使う
+-- user_id は連携の場合だけ入る
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+    id SERIAL PRIMARY KEY,
+    state_hash CHAR(64) NOT NULL UNIQUE,
+    provider VARCHAR(64) NOT NULL,
+    nonce VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    user_id INTEGER NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ(3) NOT NULL,
+    created_at TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
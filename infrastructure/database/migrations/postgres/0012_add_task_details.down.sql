@@ -0,0 +1,6 @@
+ALTER TABLE tasks
+    DROP COLUMN completed_at,
+    DROP COLUMN due_at,
+    DROP COLUMN priority,
+    DROP COLUMN status,
+    DROP COLUMN description;
@@ -0,0 +1,6 @@
+ALTER TABLE tasks
+    ADD COLUMN description TEXT,
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'todo',
+    ADD COLUMN priority INTEGER NOT NULL DEFAULT 2,
+    ADD COLUMN due_at TIMESTAMPTZ(3) NULL,
+    ADD COLUMN completed_at TIMESTAMPTZ(3) NULL;
@@ -0,0 +1,16 @@
+ALTER TABLE users ADD COLUMN email_verified_at DATETIME NULL;
+-- 確認の仕組みより前に登録したユーザーは確認済みとして扱う
+UPDATE users SET email_verified_at = created_at;
+
+-- token_hash は確認トークンの SHA-256。トークン自体はメールでだけ送る
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id ON email_verification_tokens (user_id);
@@ -0,0 +1,22 @@
+-- ログインに失敗した回数を、アカウント（kind = account、identifier はメールアドレス）と
+-- 接続元 IP アドレス（kind = ip）ごとに数える。locked_until まではログインを受け付けない
+CREATE TABLE IF NOT EXISTS login_attempts (
+    kind VARCHAR(16) NOT NULL,
+    identifier VARCHAR(255) NOT NULL,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failed_at DATETIME NOT NULL,
+    locked_until DATETIME NULL,
+    PRIMARY KEY (kind, identifier)
+);
+
+-- セキュリティに関わる出来事の記録。target は対象（account:メールアドレス など）
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    action VARCHAR(64) NOT NULL,
+    target VARCHAR(320) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    detail VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action_created_at ON audit_logs (action, created_at);
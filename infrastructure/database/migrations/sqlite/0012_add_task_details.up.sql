@@ -0,0 +1,6 @@
+-- SQLite の ALTER TABLE は 1 文で 1 列しか追加できない
+ALTER TABLE tasks ADD COLUMN description TEXT;
+ALTER TABLE tasks ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'todo';
+ALTER TABLE tasks ADD COLUMN priority INTEGER NOT NULL DEFAULT 2;
+ALTER TABLE tasks ADD COLUMN due_at DATETIME NULL;
+ALTER TABLE tasks ADD COLUMN completed_at DATETIME NULL;
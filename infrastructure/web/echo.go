@@ -3,23 +3,36 @@ package web
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
 
+	"go-todo-app-clean-arch/adapter/controller/echo/handler"
 	"go-todo-app-clean-arch/adapter/controller/echo/router"
+	"go-todo-app-clean-arch/pkg/config"
+	"go-todo-app-clean-arch/usecase"
 )
 
 type EchoServer struct {
 	router     *echo.Echo
+	health     *handler.HealthHandler
 	host, port string
+	drainDelay time.Duration
 }
 
-func NewEchoServer(host, port string, db *gorm.DB) (Server, error) {
+func NewEchoServer(conf *config.Config, db *gorm.DB) (Server, error) {
+	checks, err := readinessChecks(db)
+	if err != nil {
+		return nil, err
+	}
+	health := handler.NewHealthHandler(conf.Web.ReadinessTimeout, checks...)
 	return &EchoServer{
-		router: router.NewEchoRouter(db),
-		host:   host,
-		port:   port,
+		router:     router.NewEchoRouter(db, conf, health),
+		health:     health,
+		host:       conf.Web.Host,
+		port:       conf.Web.Port,
+		drainDelay: conf.Web.ShutdownDrainDelay,
 	}, nil
 }
 
@@ -27,6 +40,18 @@ func (e *EchoServer) Start() error {
 	return e.router.Start(fmt.Sprintf("%s:%s", e.host, e.port))
 }
 
+// Shutdown は先に readiness を失敗させ、ロードバランサーが振り分けをやめるまで待ってから受付を止める
+// 受け付けたリクエストが送るメールは、送り終えるまで待つ
 func (e *EchoServer) Shutdown(ctx context.Context) error {
-	return e.router.Shutdown(ctx)
+	e.health.Drain()
+	timer := time.NewTimer(e.drainDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	if err := e.router.Shutdown(ctx); err != nil {
+		return err
+	}
+	return usecase.WaitBackground(ctx)
 }
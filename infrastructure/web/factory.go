@@ -3,8 +3,11 @@ package web
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/pkg/config"
 )
 
 var (
@@ -12,8 +15,7 @@ var (
 )
 
 const (
-	InstanceGin int = iota
-	InstanceEcho
+	FrameworkEcho = "echo"
 )
 
 type Server interface {
@@ -21,14 +23,14 @@ type Server interface {
 	Shutdown(ctx context.Context) error
 }
 
-func NewServer(instance int, db *gorm.DB) (Server, error) {
-	config := NewConfigWeb()
-	switch instance {
-	// case InstanceGin:
-	// 	return NewGinServer(config.Host, config.Port, config.CorsAllowOrigins, db)
-	case InstanceEcho:
-		return NewEchoServer(config.Host, config.Port, db)
+// NewServer は web.framework で指定されたフレームワークのサーバーを作成する
+func NewServer(conf *config.Config, db *gorm.DB) (Server, error) {
+	switch conf.Web.Framework {
+	// case FrameworkGin:
+	// 	return NewGinServer(conf, db)
+	case FrameworkEcho:
+		return NewEchoServer(conf, db)
 	default:
-		panic(errInvalidWebServerInstance)
+		return nil, fmt.Errorf("%w: %q", errInvalidWebServerInstance, conf.Web.Framework)
 	}
 }
@@ -0,0 +1,38 @@
+package web
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/adapter/controller/echo/handler"
+	"go-todo-app-clean-arch/infrastructure/database"
+)
+
+// readinessChecks は readiness で確認する依存先を返す
+// DB に接続できることと、バイナリに含まれるマイグレーションがすべて適用済みであることを確認する
+func readinessChecks(db *gorm.DB) ([]handler.HealthCheck, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return []handler.HealthCheck{
+		{Name: "database", Check: sqlDB.PingContext},
+		{Name: "migrations", Check: func(ctx context.Context) error {
+			pending, err := migrator.Pending(ctx)
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("%d migrations pending (next %04d_%s)", len(pending), pending[0].Version, pending[0].Name)
+			}
+			return nil
+		}},
+	}, nil
+}
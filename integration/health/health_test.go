@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -15,3 +16,29 @@ func TestPing(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 200, res.StatusCode)
 }
+
+func TestLive(t *testing.T) {
+	res, err := http.Get(pkg.GetEndpoint("/health/live"))
+	assert.Nil(t, err)
+	assert.Equal(t, 200, res.StatusCode)
+}
+
+func TestReady(t *testing.T) {
+	res, err := http.Get(pkg.GetEndpoint("/health/ready"))
+	assert.Nil(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, 200, res.StatusCode)
+
+	var body struct {
+		Status     string `json:"status"`
+		Components map[string]struct {
+			Status    string  `json:"status"`
+			LatencyMs float64 `json:"latency_ms"`
+		} `json:"components"`
+	}
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+	assert.Equal(t, "ok", body.Status)
+	for _, name := range []string{"database", "migrations"} {
+		assert.Equal(t, "ok", body.Components[name].Status, name)
+	}
+}
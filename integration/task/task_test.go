@@ -2,27 +2,64 @@ package integration
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/oapi-codegen/nullable"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/stretchr/testify/suite"
 
-	"go-todo-app-clean-arch/adapter/controller/gin/presenter"
+	"go-todo-app-clean-arch/adapter/controller/echo/presenter"
 	"go-todo-app-clean-arch/pkg"
 )
 
 type TaskTestSuite struct {
 	suite.Suite
+	apiClient *presenter.ClientWithResponses
 }
 
 func TestTaskSuite(t *testing.T) {
 	suite.Run(t, new(TaskTestSuite))
 }
 
+// SetupSuite はテスト用のユーザーを作ってログインし、アクセストークンを Bearer ヘッダーで送るクライアントを作る
+func (suite *TaskTestSuite) SetupSuite() {
+	baseEndpoint := pkg.GetEndpoint("/api/v1")
+	client, err := presenter.NewClientWithResponses(baseEndpoint)
+	suite.Require().NoError(err)
+
+	email := openapi_types.Email(fmt.Sprintf("integration-%d@example.com", time.Now().UnixNano()))
+	password := "integration test password"
+	signupResponse, err := client.CreateUserWithResponse(context.Background(), presenter.CreateUserJSONRequestBody{
+		Email:    email,
+		Password: password,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(http.StatusCreated, signupResponse.StatusCode())
+
+	returnTokens := true
+	loginResponse, err := client.LoginUserWithResponse(context.Background(), presenter.LoginUserJSONRequestBody{
+		Email:        email,
+		Password:     password,
+		ReturnTokens: &returnTokens,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(http.StatusOK, loginResponse.StatusCode())
+	suite.Require().NotNil(loginResponse.JSON200.Tokens)
+
+	accessToken := loginResponse.JSON200.Tokens.AuthToken
+	suite.apiClient, err = presenter.NewClientWithResponses(baseEndpoint, presenter.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		return nil
+	}))
+	suite.Require().NoError(err)
+}
+
 func (suite *TaskTestSuite) TestTaskCreateGetDelete() {
 	// Create
-	baseEndpoint := pkg.GetEndpoint("/api/v1")
-	apiClient, _ := presenter.NewClientWithResponses(baseEndpoint)
+	apiClient := suite.apiClient
 	createResponse, err := apiClient.CreateTaskWithResponse(context.Background(), presenter.CreateTaskJSONRequestBody{
 		Title: "test title",
 	})
@@ -49,6 +86,26 @@ func (suite *TaskTestSuite) TestTaskCreateGetDelete() {
 	suite.Assert().Equal(http.StatusOK, updateResponse.StatusCode())
 	suite.Assert().Nil(err)
 	suite.Assert().Equal("test title updated", updateResponse.JSON200.Title)
+
+	// null を送ると説明と期限を消せる
+	dueAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	updateResponse, err = apiClient.UpdateTaskByIdWithResponse(context.Background(), getResponse.JSON200.Id, presenter.UpdateTaskByIdJSONRequestBody{
+		Description: nullable.NewNullableWithValue("description"),
+		DueAt:       nullable.NewNullableWithValue(dueAt),
+	})
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(http.StatusOK, updateResponse.StatusCode())
+	suite.Assert().Equal("description", updateResponse.JSON200.Description)
+	suite.Assert().NotNil(updateResponse.JSON200.DueAt)
+	updateResponse, err = apiClient.UpdateTaskByIdWithResponse(context.Background(), getResponse.JSON200.Id, presenter.UpdateTaskByIdJSONRequestBody{
+		Description: nullable.NewNullNullable[string](),
+		DueAt:       nullable.NewNullNullable[time.Time](),
+	})
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(http.StatusOK, updateResponse.StatusCode())
+	suite.Assert().Equal("", updateResponse.JSON200.Description)
+	suite.Assert().Nil(updateResponse.JSON200.DueAt)
+	suite.Assert().Equal("test title updated", updateResponse.JSON200.Title)
 	
 	// Delete
 	deleteResponse, err := apiClient.DeleteTaskByIdWithResponse(context.Background(), updateResponse.JSON200.Id)
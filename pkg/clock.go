@@ -0,0 +1,18 @@
+package pkg
+
+import "time"
+
+// Clock は現在時刻を返す。テストでは tester.NewMockClock で差し替える
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func NewClock() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
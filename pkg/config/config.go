@@ -0,0 +1,538 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config はサーバー・マイグレーションコマンドが使う設定をまとめたもの
+// 値は Load で既定値・設定ファイル・環境変数・コマンドライン引数の順に読み込む
+type Config struct {
+	Env      string
+	Database DatabaseConfig
+	Web      WebConfig
+	Auth     AuthConfig
+	Mail     MailConfig
+	Log      LogConfig
+}
+
+type DatabaseConfig struct {
+	Driver   string
+	Host     string
+	Port     string
+	Name     string
+	User     string
+	Password string
+	// SSLMode と SSLRootCert は PostgreSQL でのみ使う
+	SSLMode     string
+	SSLRootCert string
+	// 0 の場合は database/sql の既定値のまま
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	// ConnectTimeout は起動時に DB へ接続できるまで再試行する時間。0 の場合は 1 回だけ試す
+	ConnectTimeout time.Duration
+	MigrateOnStart bool
+}
+
+type WebConfig struct {
+	Framework        string
+	Host             string
+	Port             string
+	CorsAllowOrigins []string
+	CookieDomain     string
+	// ValidateResponses はレスポンスを openapi.yaml と照合する開発・テスト用の設定
+	ValidateResponses bool
+	// DebugEndpoints を有効にすると /debug 以下でコネクションプールの統計などを返す
+	DebugEndpoints bool
+	// ReadinessTimeout は /health/ready で各依存先を確認する制限時間
+	ReadinessTimeout time.Duration
+	// RequestTimeout は 1 リクエストの制限時間。超えると実行中の DB への問い合わせを中断する
+	RequestTimeout time.Duration
+	// ShutdownDrainDelay は終了時に readiness を失敗させてから受付を止めるまでの時間
+	ShutdownDrainDelay time.Duration
+}
+
+type AuthConfig struct {
+	// JWTSigningKeyFile はアクセストークン（JWT）に署名する RSA か Ed25519 の秘密鍵（PEM）
+	// JWTVerificationKeyFiles は入れ替えた古い鍵など、検証にだけ使う鍵（PEM、秘密鍵でも公開鍵でもよい）
+	JWTSigningKeyFile       string
+	JWTVerificationKeyFiles []string
+	// JWTSecret は JWTSigningKeyFile がない場合に HS256 で署名する秘密（開発用）
+	// JWTSigningKeyFile がある場合は、移行前に発行したトークンの検証にだけ使う
+	JWTSecret string
+	// JWTTTL はアクセストークンの有効期間。期限が切れたらリフレッシュトークンで再発行する
+	JWTTTL          time.Duration
+	RefreshTokenTTL time.Duration
+	// PasswordResetURL はパスワード再設定メールのリンク先。?token= を付けて送る
+	PasswordResetURL string
+	PasswordResetTTL time.Duration
+	// PasswordResetResendInterval は同じユーザーに再設定メールを再送できるようになるまでの間隔
+	PasswordResetResendInterval time.Duration
+	// EmailVerificationURL はメールアドレス確認メールのリンク先。?token= を付けて送る
+	EmailVerificationURL string
+	EmailVerificationTTL time.Duration
+	// EmailVerificationResendInterval は確認メールを再送できるようになるまでの間隔
+	EmailVerificationResendInterval time.Duration
+	// EmailChangeURL はメールアドレス変更の確認メールのリンク先。?token= を付けて新しいアドレスに送る
+	EmailChangeURL string
+	EmailChangeTTL time.Duration
+	// EmailChangeResendInterval は同じユーザーが次のメールアドレス変更を要求できるようになるまでの間隔
+	EmailChangeResendInterval time.Duration
+	// UnverifiedPolicy はメールアドレスが未確認のユーザーの扱い
+	// limit の場合は UnverifiedTaskLimit 件までタスクを作成でき、block の場合はログインできない
+	UnverifiedPolicy    string
+	UnverifiedTaskLimit int
+	// TOTPIssuer は認証アプリにアカウントの発行者として表示する名前
+	TOTPIssuer string
+	// LoginChallengeTTL はパスワードの確認後、2 要素目を入力できる時間
+	LoginChallengeTTL time.Duration
+	// LoginAttemptStore はログインの失敗の回数を保存する場所（database、memory）
+	// memory はサーバーごとに数えるため、複数台で動かす場合は database にする
+	LoginAttemptStore string
+	// LoginAccountThreshold・LoginIPThreshold はロックするまでの失敗の回数。0 の場合はロックしない
+	LoginAccountThreshold int
+	LoginIPThreshold      int
+	LoginLockoutDuration  time.Duration
+	// LoginBackoffBase は失敗が続いたアカウントを待たせる時間の初期値。失敗するごとに倍にする
+	LoginBackoffBase time.Duration
+	// LoginFailureWindow は最後の失敗から回数を数え直すまでの時間
+	LoginFailureWindow time.Duration
+	// PasswordMinLength は文字数、PasswordMaxBytes は UTF-8 でのバイト数（bcrypt の制限で 72 まで）
+	PasswordMinLength int
+	PasswordMaxBytes  int
+	// BreachedPasswordsFile は漏洩したパスワードの SHA-1 を 1 行に 1 つ書いたファイル。空の場合は確かめない
+	BreachedPasswordsFile string
+	// PasswordHasher は新しく保存するパスワードのハッシュのアルゴリズム（argon2id、bcrypt）
+	// 違うアルゴリズム・パラメータのハッシュは、ログインに成功したときに作り直す
+	PasswordHasher string
+	// Argon2Memory は KiB で表す
+	Argon2Memory  int
+	Argon2Time    int
+	Argon2Threads int
+	BcryptCost    int
+	// OIDCProviders は OpenID Connect でログインできるプロバイダー。キーは URL に使うプロバイダーの名前
+	OIDCProviders map[string]OIDCProviderConfig
+	// OIDCCallbackURL は各プロバイダーに登録するコールバック（/api/v1/auth/oidc/callback）の URL
+	OIDCCallbackURL string
+	// OIDCReturnURL はログイン・連携の結果を受け取るフロントエンドのページ
+	OIDCReturnURL string
+	// OIDCLoginTTL はプロバイダーでのログインを始めてから、コールバックまでに許す時間
+	OIDCLoginTTL time.Duration
+}
+
+// OIDCProviderConfig は 1 つの OpenID Provider の設定
+type OIDCProviderConfig struct {
+	// Issuer は発行者の URL。Issuer + /.well-known/openid-configuration から設定を取得する
+	Issuer   string
+	ClientID string
+	// ClientSecret が空の場合は公開クライアントとして PKCE だけで認可コードを交換する
+	ClientSecret string
+	// Scopes は openid に加えて要求するスコープ
+	Scopes []string
+}
+
+// MailConfig はメールの送信方法
+// Driver が log の場合はログに、file の場合は Dir にメールを書き出す（開発・テスト用）
+type MailConfig struct {
+	Driver       string
+	From         string
+	Dir          string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+}
+
+type LogConfig struct {
+	Level string
+	File  string
+}
+
+func Default() *Config {
+	return &Config{
+		Env: "development",
+		Database: DatabaseConfig{
+			Driver:   "mysql",
+			Host:     "localhost",
+			User:     "app",
+			Password: "password",
+			SSLMode:  "disable",
+			// DB より先にサーバーのコンテナが起動しても待てるようにする
+			ConnectTimeout: 30 * time.Second,
+		},
+		Web: WebConfig{
+			Framework:          "echo",
+			Host:               "0.0.0.0",
+			Port:               "8080",
+			CorsAllowOrigins:   []string{"http://localhost:3000"},
+			ReadinessTimeout:   2 * time.Second,
+			RequestTimeout:     30 * time.Second,
+			ShutdownDrainDelay: 5 * time.Second,
+		},
+		Auth: AuthConfig{
+			JWTTTL:                      15 * time.Minute,
+			RefreshTokenTTL:             30 * 24 * time.Hour,
+			PasswordResetURL:            "http://localhost:3000/password/reset",
+			PasswordResetTTL:            time.Hour,
+			PasswordResetResendInterval: time.Minute,
+			// 確認用の API を直接開かせる
+			EmailVerificationURL:            "http://localhost:8080/api/v1/auth/verify",
+			EmailVerificationTTL:            24 * time.Hour,
+			EmailVerificationResendInterval: time.Minute,
+			EmailChangeURL:                  "http://localhost:8080/api/v1/auth/email/confirm",
+			EmailChangeTTL:                  24 * time.Hour,
+			EmailChangeResendInterval:       time.Minute,
+			UnverifiedPolicy:                "limit",
+			UnverifiedTaskLimit:             10,
+			TOTPIssuer:                      "ToDo App",
+			LoginChallengeTTL:               5 * time.Minute,
+			LoginAttemptStore:               "database",
+			LoginAccountThreshold:           10,
+			LoginIPThreshold:                50,
+			LoginLockoutDuration:            15 * time.Minute,
+			LoginBackoffBase:                time.Second,
+			LoginFailureWindow:              15 * time.Minute,
+			PasswordMinLength:               8,
+			PasswordMaxBytes:                72,
+			// OWASP Password Storage Cheat Sheet の推奨値
+			PasswordHasher: "argon2id",
+			Argon2Memory:   19 * 1024,
+			Argon2Time:     2,
+			Argon2Threads:  1,
+			BcryptCost:     10,
+			// ローカルで動かすサーバーとフロントエンドの開発サーバー
+			OIDCCallbackURL: "http://localhost:8080/api/v1/auth/oidc/callback",
+			OIDCReturnURL:   "http://localhost:3000/oidc/callback",
+			OIDCLoginTTL:    10 * time.Minute,
+		},
+		Mail: MailConfig{
+			Driver:   "log",
+			From:     "no-reply@localhost",
+			SMTPPort: "587",
+		},
+	}
+}
+
+func (c *Config) IsDevelopment() bool {
+	return c.Env == "development"
+}
+
+// 他の設定によって既定値が変わる項目を埋める
+func (c *Config) applyDerivedDefaults() {
+	if c.Database.Port == "" {
+		switch c.Database.Driver {
+		case "mysql":
+			c.Database.Port = "3306"
+		case "postgres":
+			c.Database.Port = "5432"
+		}
+	}
+	if c.Database.Name == "" {
+		c.Database.Name = "api_database"
+		if c.Database.Driver == "sqlite" {
+			c.Database.Name = "api_database.sqlite"
+		}
+	}
+	if c.Log.Level == "" {
+		c.Log.Level = "info"
+		if c.IsDevelopment() {
+			c.Log.Level = "debug"
+		}
+	}
+}
+
+// Validate はすべての項目を検証し、問題をまとめて返す
+func (c *Config) Validate() error {
+	var problems []error
+	if c.Env == "" {
+		problems = append(problems, errors.New("app.env must not be empty"))
+	}
+	problems = append(problems, c.Database.problems()...)
+	problems = append(problems, c.Web.problems()...)
+	problems = append(problems, c.Auth.problems()...)
+	problems = append(problems, c.Mail.problems()...)
+	problems = append(problems, c.Log.problems()...)
+	return errors.Join(problems...)
+}
+
+// Validate は DB に関する項目だけを検証する（マイグレーションコマンド用）
+func (c *DatabaseConfig) Validate() error {
+	return errors.Join(c.problems()...)
+}
+
+func (c *DatabaseConfig) problems() []error {
+	var problems []error
+	switch c.Driver {
+	case "sqlite":
+		if c.Name == "" {
+			problems = append(problems, errors.New("database.name must not be empty"))
+		}
+	case "mysql", "postgres":
+		if c.Host == "" {
+			problems = append(problems, errors.New("database.host must not be empty"))
+		}
+		if err := validatePort(c.Port); err != nil {
+			problems = append(problems, fmt.Errorf("database.port %w", err))
+		}
+		if c.Name == "" {
+			problems = append(problems, errors.New("database.name must not be empty"))
+		}
+		if c.User == "" {
+			problems = append(problems, errors.New("database.user must not be empty"))
+		}
+	default:
+		problems = append(problems, fmt.Errorf("database.driver must be one of mysql, postgres, sqlite (got %q)", c.Driver))
+	}
+	if c.Driver == "postgres" {
+		switch c.SSLMode {
+		case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+		default:
+			problems = append(problems, fmt.Errorf("database.sslmode %q is not a valid PostgreSQL sslmode", c.SSLMode))
+		}
+	}
+	if c.MaxOpenConns < 0 {
+		problems = append(problems, errors.New("database.max_open_conns must not be negative"))
+	}
+	if c.MaxIdleConns < 0 {
+		problems = append(problems, errors.New("database.max_idle_conns must not be negative"))
+	}
+	if c.MaxOpenConns > 0 && c.MaxIdleConns > c.MaxOpenConns {
+		problems = append(problems, errors.New("database.max_idle_conns must not exceed database.max_open_conns"))
+	}
+	if c.ConnMaxLifetime < 0 {
+		problems = append(problems, errors.New("database.conn_max_lifetime must not be negative"))
+	}
+	if c.ConnMaxIdleTime < 0 {
+		problems = append(problems, errors.New("database.conn_max_idle_time must not be negative"))
+	}
+	if c.ConnectTimeout < 0 {
+		problems = append(problems, errors.New("database.connect_timeout must not be negative"))
+	}
+	return problems
+}
+
+func (c *WebConfig) problems() []error {
+	var problems []error
+	// gin 版のサーバーは現在ビルドに含めていない
+	if c.Framework != "echo" {
+		problems = append(problems, fmt.Errorf("web.framework must be echo (got %q)", c.Framework))
+	}
+	if err := validatePort(c.Port); err != nil {
+		problems = append(problems, fmt.Errorf("web.port %w", err))
+	}
+	if c.ReadinessTimeout <= 0 {
+		problems = append(problems, errors.New("web.readiness_timeout must be positive"))
+	}
+	if c.RequestTimeout <= 0 {
+		problems = append(problems, errors.New("web.request_timeout must be positive"))
+	}
+	if c.ShutdownDrainDelay < 0 {
+		problems = append(problems, errors.New("web.shutdown_drain_delay must not be negative"))
+	}
+	for _, origin := range c.CorsAllowOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Errorf("web.cors_allow_origins contains an invalid origin %q", origin))
+		}
+	}
+	return problems
+}
+
+func (c *AuthConfig) problems() []error {
+	var problems []error
+	if c.JWTSecret == "" && c.JWTSigningKeyFile == "" {
+		problems = append(problems, errors.New("auth.jwt_secret must not be empty when auth.jwt_signing_key_file is not set"))
+	}
+	if c.JWTSigningKeyFile == "" && len(c.JWTVerificationKeyFiles) > 0 {
+		problems = append(problems, errors.New("auth.jwt_verification_key_files requires auth.jwt_signing_key_file"))
+	}
+	if c.JWTTTL <= 0 {
+		problems = append(problems, errors.New("auth.jwt_ttl must be positive"))
+	}
+	if c.RefreshTokenTTL <= c.JWTTTL {
+		problems = append(problems, errors.New("auth.refresh_token_ttl must be longer than auth.jwt_ttl"))
+	}
+	if u, err := url.Parse(c.PasswordResetURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Errorf("auth.password_reset_url must be an http(s) URL (got %q)", c.PasswordResetURL))
+	}
+	if c.PasswordResetTTL <= 0 {
+		problems = append(problems, errors.New("auth.password_reset_ttl must be positive"))
+	}
+	if c.PasswordResetResendInterval < 0 {
+		problems = append(problems, errors.New("auth.password_reset_resend_interval must not be negative"))
+	}
+	if u, err := url.Parse(c.EmailVerificationURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Errorf("auth.email_verification_url must be an http(s) URL (got %q)", c.EmailVerificationURL))
+	}
+	if c.EmailVerificationTTL <= 0 {
+		problems = append(problems, errors.New("auth.email_verification_ttl must be positive"))
+	}
+	if c.EmailVerificationResendInterval < 0 {
+		problems = append(problems, errors.New("auth.email_verification_resend_interval must not be negative"))
+	}
+	if u, err := url.Parse(c.EmailChangeURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Errorf("auth.email_change_url must be an http(s) URL (got %q)", c.EmailChangeURL))
+	}
+	if c.EmailChangeTTL <= 0 {
+		problems = append(problems, errors.New("auth.email_change_ttl must be positive"))
+	}
+	if c.EmailChangeResendInterval < 0 {
+		problems = append(problems, errors.New("auth.email_change_resend_interval must not be negative"))
+	}
+	if c.UnverifiedPolicy != "limit" && c.UnverifiedPolicy != "block" {
+		problems = append(problems, fmt.Errorf("auth.unverified_policy must be limit or block (got %q)", c.UnverifiedPolicy))
+	}
+	if c.UnverifiedTaskLimit < 0 {
+		problems = append(problems, errors.New("auth.unverified_task_limit must not be negative"))
+	}
+	// otpauth:// URI のラベルでは ":" が発行者とアカウントの区切りになる
+	if c.TOTPIssuer == "" || strings.Contains(c.TOTPIssuer, ":") {
+		problems = append(problems, fmt.Errorf("auth.totp_issuer must be non-empty and must not contain \":\" (got %q)", c.TOTPIssuer))
+	}
+	if c.LoginChallengeTTL <= 0 {
+		problems = append(problems, errors.New("auth.login_challenge_ttl must be positive"))
+	}
+	if c.LoginAttemptStore != "database" && c.LoginAttemptStore != "memory" {
+		problems = append(problems, fmt.Errorf("auth.login_attempt_store must be database or memory (got %q)", c.LoginAttemptStore))
+	}
+	if c.LoginAccountThreshold < 0 {
+		problems = append(problems, errors.New("auth.login_account_threshold must not be negative"))
+	}
+	if c.LoginIPThreshold < 0 {
+		problems = append(problems, errors.New("auth.login_ip_threshold must not be negative"))
+	}
+	if c.LoginLockoutDuration <= 0 {
+		problems = append(problems, errors.New("auth.login_lockout_duration must be positive"))
+	}
+	if c.LoginBackoffBase < 0 {
+		problems = append(problems, errors.New("auth.login_backoff_base must not be negative"))
+	}
+	if c.LoginFailureWindow <= 0 {
+		problems = append(problems, errors.New("auth.login_failure_window must be positive"))
+	}
+	if c.PasswordMinLength < 1 {
+		problems = append(problems, errors.New("auth.password_min_length must be at least 1"))
+	}
+	// bcrypt は 72 バイトを超えるパスワードをハッシュ化できない
+	if c.PasswordMaxBytes < c.PasswordMinLength || c.PasswordMaxBytes > 72 {
+		problems = append(problems, fmt.Errorf("auth.password_max_bytes must be between auth.password_min_length and 72 (got %d)", c.PasswordMaxBytes))
+	}
+	if c.PasswordHasher != "argon2id" && c.PasswordHasher != "bcrypt" {
+		problems = append(problems, fmt.Errorf("auth.password_hasher must be argon2id or bcrypt (got %q)", c.PasswordHasher))
+	}
+	if c.Argon2Threads < 1 || c.Argon2Threads > 255 {
+		problems = append(problems, fmt.Errorf("auth.argon2_threads must be between 1 and 255 (got %d)", c.Argon2Threads))
+	}
+	// argon2 はスレッドごとに 8 KiB 以上のメモリを必要とする
+	if c.Argon2Memory < 8*max(c.Argon2Threads, 1) {
+		problems = append(problems, fmt.Errorf("auth.argon2_memory must be at least 8 KiB per thread (got %d)", c.Argon2Memory))
+	}
+	if c.Argon2Time < 1 {
+		problems = append(problems, errors.New("auth.argon2_time must be at least 1"))
+	}
+	if c.BcryptCost < 4 || c.BcryptCost > 31 {
+		problems = append(problems, fmt.Errorf("auth.bcrypt_cost must be between 4 and 31 (got %d)", c.BcryptCost))
+	}
+	if u, err := url.Parse(c.OIDCCallbackURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Errorf("auth.oidc_callback_url must be an http(s) URL (got %q)", c.OIDCCallbackURL))
+	}
+	if u, err := url.Parse(c.OIDCReturnURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Errorf("auth.oidc_return_url must be an http(s) URL (got %q)", c.OIDCReturnURL))
+	}
+	if c.OIDCLoginTTL <= 0 {
+		problems = append(problems, errors.New("auth.oidc_login_ttl must be positive"))
+	}
+	names := make([]string, 0, len(c.OIDCProviders))
+	for name := range c.OIDCProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		problems = append(problems, c.OIDCProviders[name].problems(name)...)
+	}
+	return problems
+}
+
+// oidcProvider は name の設定を返す。まだなければ既定のスコープで追加する
+func (c *AuthConfig) oidcProvider(name string) OIDCProviderConfig {
+	if c.OIDCProviders == nil {
+		c.OIDCProviders = map[string]OIDCProviderConfig{}
+	}
+	p, ok := c.OIDCProviders[name]
+	if !ok {
+		// メールアドレスで既存のアカウントと連携するため email を要求する
+		p = OIDCProviderConfig{Scopes: []string{"email", "profile"}}
+		c.OIDCProviders[name] = p
+	}
+	return p
+}
+
+// oidcProviderName はプロバイダーの名前として使える文字列。callback は /api/v1/auth/oidc/callback と重なるので使えない
+var oidcProviderName = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+func (c OIDCProviderConfig) problems(name string) []error {
+	key := "auth.oidc_providers." + name
+	if !oidcProviderName.MatchString(name) || name == "callback" {
+		return []error{fmt.Errorf("%s: provider names must be 1-64 lowercase letters, digits, - or _ and must not be callback", key)}
+	}
+	var problems []error
+	if u, err := url.Parse(c.Issuer); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Errorf("%s.issuer must be an http(s) URL (got %q)", key, c.Issuer))
+	}
+	if c.ClientID == "" {
+		problems = append(problems, fmt.Errorf("%s.client_id must not be empty", key))
+	}
+	return problems
+}
+
+func (c *MailConfig) problems() []error {
+	var problems []error
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		problems = append(problems, fmt.Errorf("mail.from must be an email address (got %q)", c.From))
+	}
+	switch c.Driver {
+	case "log":
+	case "file":
+		if c.Dir == "" {
+			problems = append(problems, errors.New("mail.dir must not be empty when mail.driver is file"))
+		}
+	case "smtp":
+		if c.SMTPHost == "" {
+			problems = append(problems, errors.New("mail.smtp_host must not be empty when mail.driver is smtp"))
+		}
+		if err := validatePort(c.SMTPPort); err != nil {
+			problems = append(problems, fmt.Errorf("mail.smtp_port %w", err))
+		}
+	default:
+		problems = append(problems, fmt.Errorf("mail.driver must be one of log, file, smtp (got %q)", c.Driver))
+	}
+	return problems
+}
+
+func (c *LogConfig) problems() []error {
+	switch c.Level {
+	case "debug", "info", "warn", "error":
+		return nil
+	}
+	return []error{fmt.Errorf("log.level must be one of debug, info, warn, error (got %q)", c.Level)}
+}
+
+func validatePort(port string) error {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("must be a number between 1 and 65535 (got %q)", port)
+	}
+	return nil
+}
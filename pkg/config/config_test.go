@@ -0,0 +1,203 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 実行環境の環境変数に影響されないよう、設定項目の環境変数をすべて消す
+func unsetEnv(t *testing.T) {
+	for _, s := range append(settings, setting{env: ConfigFileEnv}, setting{env: OIDCProvidersEnv}) {
+		t.Setenv(s.env, "")
+		os.Unsetenv(s.env)
+	}
+}
+
+func load(t *testing.T, args ...string) (*Config, error) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return Load(fs, args)
+}
+
+func writeFile(t *testing.T, name, body string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(path, []byte(body), 0o644))
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	unsetEnv(t)
+	c, err := load(t)
+	assert.Nil(t, err)
+	assert.Equal(t, "mysql", c.Database.Driver)
+	assert.Equal(t, "3306", c.Database.Port)
+	assert.Equal(t, "api_database", c.Database.Name)
+	assert.Equal(t, "echo", c.Web.Framework)
+	assert.Equal(t, []string{"http://localhost:3000"}, c.Web.CorsAllowOrigins)
+	assert.Equal(t, 15*time.Minute, c.Auth.JWTTTL)
+	assert.Equal(t, "debug", c.Log.Level)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	unsetEnv(t)
+	path := writeFile(t, "config.yaml", `
+app:
+  env: production
+database:
+  driver: postgres
+  port: 6543
+  max_open_conns: 20
+web:
+  port: 9000
+  cors_allow_origins:
+    - https://a.example.com
+    - https://b.example.com
+auth:
+  jwt_ttl: 1h
+`)
+	t.Setenv(ConfigFileEnv, path)
+	t.Setenv("DB_PORT", "7000")
+	t.Setenv("WEB_PORT", "9001")
+
+	c, err := load(t, "-web-port", "9100", "-database-migrate-on-start")
+	assert.Nil(t, err)
+	assert.True(t, c.Database.MigrateOnStart)
+	// 設定ファイル < 環境変数 < フラグ
+	assert.Equal(t, "postgres", c.Database.Driver)
+	assert.Equal(t, "7000", c.Database.Port)
+	assert.Equal(t, "9100", c.Web.Port)
+	assert.Equal(t, 20, c.Database.MaxOpenConns)
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, c.Web.CorsAllowOrigins)
+	assert.Equal(t, time.Hour, c.Auth.JWTTTL)
+	// development 以外の既定のログレベル
+	assert.Equal(t, "info", c.Log.Level)
+
+	// -config は CONFIG_FILE より優先する
+	other := writeFile(t, "other.toml", `
+[database]
+driver = "sqlite"
+`)
+	c, err = load(t, "-config", other)
+	assert.Nil(t, err)
+	assert.Equal(t, "sqlite", c.Database.Driver)
+	assert.Equal(t, "api_database.sqlite", c.Database.Name)
+}
+
+func TestLoadReportsAllProblems(t *testing.T) {
+	unsetEnv(t)
+	path := writeFile(t, "config.toml", `
+[database]
+max_open_conns = "many"
+hots = "typo"
+`)
+	t.Setenv("JWT_TTL", "forever")
+
+	_, err := load(t, "-config", path, "-database-migrate-on-start=maybe")
+	assert.NotNil(t, err)
+	assert.ErrorContains(t, err, "database.max_open_conns")
+	assert.ErrorContains(t, err, `unknown setting "database.hots"`)
+	assert.ErrorContains(t, err, "auth.jwt_ttl (environment)")
+	assert.ErrorContains(t, err, "database.migrate_on_start (flags)")
+
+	_, err = load(t, "-config", filepath.Join(t.TempDir(), "config.json"))
+	assert.ErrorContains(t, err, "config file")
+}
+
+func TestLoadOIDCProviders(t *testing.T) {
+	unsetEnv(t)
+	path := writeFile(t, "config.yaml", `
+auth:
+  oidc_providers:
+    corp:
+      issuer: https://idp.example.com
+      client_id: todo-app
+      scopes: [email]
+    google:
+      issuer: https://accounts.google.com
+      client_id: from-file
+      clinet_secret: typo
+`)
+	t.Setenv(OIDCProvidersEnv, "google,gitlab-self")
+	t.Setenv("OIDC_GOOGLE_CLIENT_ID", "from-env")
+	t.Setenv("OIDC_GOOGLE_CLIENT_SECRET", "secret")
+
+	c, err := load(t, "-config", path)
+	assert.ErrorContains(t, err, `unknown setting "auth.oidc_providers.google.clinet_secret"`)
+	assert.Equal(t, OIDCProviderConfig{Issuer: "https://idp.example.com", ClientID: "todo-app", Scopes: []string{"email"}}, c.Auth.OIDCProviders["corp"])
+	// 環境変数は項目ごとに設定ファイルより優先する
+	assert.Equal(t, OIDCProviderConfig{
+		Issuer: "https://accounts.google.com", ClientID: "from-env", ClientSecret: "secret", Scopes: []string{"email", "profile"},
+	}, c.Auth.OIDCProviders["google"])
+	// OIDC_PROVIDERS に挙げただけのプロバイダーも検証の対象になる
+	assert.Contains(t, c.Auth.OIDCProviders, "gitlab-self")
+
+	c.Auth.OIDCProviders["callback"] = OIDCProviderConfig{Issuer: "https://idp.example.com", ClientID: "x"}
+	err = errors.Join(c.Auth.problems()...)
+	assert.ErrorContains(t, err, "auth.oidc_providers.gitlab-self.issuer")
+	assert.ErrorContains(t, err, "auth.oidc_providers.gitlab-self.client_id")
+	assert.ErrorContains(t, err, "auth.oidc_providers.callback: provider names")
+	assert.NotContains(t, err.Error(), "auth.oidc_providers.google")
+}
+
+func TestValidate(t *testing.T) {
+	unsetEnv(t)
+	t.Setenv("JWT_SECRET", "secret")
+	c, err := load(t)
+	assert.Nil(t, err)
+	assert.Nil(t, c.Validate())
+
+	c.Database.Driver = "oracle"
+	c.Database.MaxOpenConns = 5
+	c.Database.MaxIdleConns = 10
+	c.Database.ConnMaxLifetime = -time.Second
+	c.Web.Framework = "gin"
+	c.Web.Port = "http"
+	c.Web.CorsAllowOrigins = []string{"localhost:3000"}
+	c.Auth.JWTSecret = ""
+	c.Auth.JWTVerificationKeyFiles = []string{"old.pem"}
+	c.Auth.RefreshTokenTTL = time.Minute
+	c.Auth.PasswordResetURL = "/password/reset"
+	c.Auth.PasswordResetResendInterval = -time.Minute
+	c.Auth.EmailChangeTTL = 0
+	c.Auth.EmailChangeResendInterval = -time.Minute
+	c.Auth.UnverifiedPolicy = "deny"
+	c.Auth.UnverifiedTaskLimit = -1
+	c.Auth.TOTPIssuer = "ToDo:App"
+	c.Auth.LoginAttemptStore = "redis"
+	c.Auth.LoginLockoutDuration = 0
+	c.Auth.PasswordMaxBytes = 100
+	c.Auth.PasswordHasher = "scrypt"
+	c.Auth.BcryptCost = 3
+	c.Auth.OIDCReturnURL = "/oidc/callback"
+	c.Auth.OIDCLoginTTL = 0
+	c.Mail.Driver = "smtp"
+	c.Mail.From = "no-reply"
+	c.Log.Level = "trace"
+
+	err = c.Validate()
+	for _, key := range []string{
+		"database.driver", "database.max_idle_conns", "database.conn_max_lifetime", "web.framework", "web.port",
+		"web.cors_allow_origins", "auth.jwt_secret", "auth.jwt_verification_key_files", "auth.refresh_token_ttl", "auth.password_reset_url",
+		"auth.password_reset_resend_interval", "auth.email_change_ttl", "auth.email_change_resend_interval", "auth.unverified_policy",
+		"auth.unverified_task_limit", "auth.totp_issuer",
+		"auth.login_attempt_store", "auth.login_lockout_duration", "auth.password_max_bytes",
+		"auth.password_hasher", "auth.bcrypt_cost", "auth.oidc_return_url", "auth.oidc_login_ttl",
+		"mail.from", "mail.smtp_host", "log.level",
+	} {
+		assert.ErrorContains(t, err, key)
+	}
+
+	c = Default()
+	c.Database.Driver = "postgres"
+	c.Database.Port = "5432"
+	c.Database.Name = "api_database"
+	c.Database.SSLMode = "on"
+	assert.ErrorContains(t, c.Database.Validate(), "database.sslmode")
+}
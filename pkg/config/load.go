@@ -0,0 +1,376 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv は設定ファイルのパスを指定する環境変数。-config フラグが優先する
+const ConfigFileEnv = "CONFIG_FILE"
+
+// OIDCProvidersEnv は環境変数で設定する OpenID Provider の名前（カンマ区切り）
+// 各プロバイダーの項目は OIDC_<名前>_ISSUER のように、名前を大文字にし - を _ にした環境変数で指定する
+const OIDCProvidersEnv = "OIDC_PROVIDERS"
+
+// oidcProvidersPrefix は設定ファイルでプロバイダーごとの項目を書くキーの接頭辞
+// auth.oidc_providers.<名前>.issuer のように、名前ごとに oidcProviderFields の項目を持つ
+const oidcProvidersPrefix = "auth.oidc_providers."
+
+var oidcProviderFields = []string{"issuer", "client_id", "client_secret", "scopes"}
+
+// setting は 1 つの設定項目の読み込み元をまとめたもの
+// key は設定ファイル上の名前で、フラグ名は key の . と _ を - に置き換えたもの
+type setting struct {
+	key    string
+	env    string
+	usage  string
+	isBool bool
+	set    func(c *Config, value string) error
+}
+
+func (s setting) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(s.key)
+}
+
+var settings = []setting{
+	stringSetting("app.env", "APP_ENV", "environment name (development enables Swagger UI and debug logging)", func(c *Config) *string { return &c.Env }),
+
+	stringSetting("database.driver", "DB_DRIVER", "database driver (mysql, postgres, sqlite)", func(c *Config) *string { return &c.Database.Driver }),
+	stringSetting("database.host", "DB_HOST", "database host", func(c *Config) *string { return &c.Database.Host }),
+	stringSetting("database.port", "DB_PORT", "database port (default 3306 for mysql, 5432 for postgres)", func(c *Config) *string { return &c.Database.Port }),
+	stringSetting("database.name", "DB_NAME", "database name, or file name for sqlite", func(c *Config) *string { return &c.Database.Name }),
+	stringSetting("database.user", "DB_USER", "database user", func(c *Config) *string { return &c.Database.User }),
+	stringSetting("database.password", "DB_PASSWORD", "database password", func(c *Config) *string { return &c.Database.Password }),
+	stringSetting("database.sslmode", "DB_SSLMODE", "PostgreSQL sslmode", func(c *Config) *string { return &c.Database.SSLMode }),
+	stringSetting("database.sslrootcert", "DB_SSLROOTCERT", "PostgreSQL root certificate file", func(c *Config) *string { return &c.Database.SSLRootCert }),
+	intSetting("database.max_open_conns", "DB_MAX_OPEN_CONNS", "maximum number of open connections (0 means unlimited)", func(c *Config) *int { return &c.Database.MaxOpenConns }),
+	intSetting("database.max_idle_conns", "DB_MAX_IDLE_CONNS", "maximum number of idle connections (0 keeps the driver default)", func(c *Config) *int { return &c.Database.MaxIdleConns }),
+	durationSetting("database.conn_max_lifetime", "DB_CONN_MAX_LIFETIME", "maximum time a connection may be reused (0 means forever)", func(c *Config) *time.Duration { return &c.Database.ConnMaxLifetime }),
+	durationSetting("database.conn_max_idle_time", "DB_CONN_MAX_IDLE_TIME", "maximum time a connection may be idle (0 means forever)", func(c *Config) *time.Duration { return &c.Database.ConnMaxIdleTime }),
+	durationSetting("database.connect_timeout", "DB_CONNECT_TIMEOUT", "how long to keep retrying the first connection at startup (0 tries once)", func(c *Config) *time.Duration { return &c.Database.ConnectTimeout }),
+	boolSetting("database.migrate_on_start", "DB_MIGRATE_ON_START", "apply pending migrations when the server starts", func(c *Config) *bool { return &c.Database.MigrateOnStart }),
+
+	stringSetting("web.framework", "WEB_FRAMEWORK", "web framework (echo)", func(c *Config) *string { return &c.Web.Framework }),
+	stringSetting("web.host", "WEB_HOST", "address the server listens on", func(c *Config) *string { return &c.Web.Host }),
+	stringSetting("web.port", "WEB_PORT", "port the server listens on", func(c *Config) *string { return &c.Web.Port }),
+	listSetting("web.cors_allow_origins", "WEB_CORS_ALLOW_ORIGINS", "comma separated origins allowed by CORS", func(c *Config) *[]string { return &c.Web.CorsAllowOrigins }),
+	stringSetting("web.cookie_domain", "WEB_COOKIE_DOMAIN", "domain of the token and CSRF cookies", func(c *Config) *string { return &c.Web.CookieDomain }),
+	boolSetting("web.validate_responses", "OPENAPI_VALIDATE_RESPONSES", "validate responses against openapi.yaml", func(c *Config) *bool { return &c.Web.ValidateResponses }),
+	durationSetting("web.readiness_timeout", "WEB_READINESS_TIMEOUT", "time limit for each dependency checked by /health/ready", func(c *Config) *time.Duration { return &c.Web.ReadinessTimeout }),
+	durationSetting("web.request_timeout", "WEB_REQUEST_TIMEOUT", "time limit for each request, after which running database queries are cancelled", func(c *Config) *time.Duration { return &c.Web.RequestTimeout }),
+	durationSetting("web.shutdown_drain_delay", "WEB_SHUTDOWN_DRAIN_DELAY", "how long readiness fails before the listener closes on shutdown", func(c *Config) *time.Duration { return &c.Web.ShutdownDrainDelay }),
+	boolSetting("web.debug_endpoints", "WEB_DEBUG_ENDPOINTS", "serve connection pool statistics under /debug", func(c *Config) *bool { return &c.Web.DebugEndpoints }),
+
+	stringSetting("auth.jwt_signing_key_file", "JWT_SIGNING_KEY_FILE", "PEM file of the RSA or Ed25519 private key that signs JWTs", func(c *Config) *string { return &c.Auth.JWTSigningKeyFile }),
+	listSetting("auth.jwt_verification_key_files", "JWT_VERIFICATION_KEY_FILES", "comma separated PEM files of retired keys still accepted for JWTs", func(c *Config) *[]string { return &c.Auth.JWTVerificationKeyFiles }),
+	stringSetting("auth.jwt_secret", "JWT_SECRET", "HS256 secret used to sign JWTs when auth.jwt_signing_key_file is not set", func(c *Config) *string { return &c.Auth.JWTSecret }),
+	durationSetting("auth.jwt_ttl", "JWT_TTL", "lifetime of access tokens (JWT) (e.g. 15m)", func(c *Config) *time.Duration { return &c.Auth.JWTTTL }),
+	durationSetting("auth.refresh_token_ttl", "REFRESH_TOKEN_TTL", "lifetime of refresh tokens, extended on every refresh (e.g. 720h)", func(c *Config) *time.Duration { return &c.Auth.RefreshTokenTTL }),
+	stringSetting("auth.password_reset_url", "PASSWORD_RESET_URL", "page linked from password reset emails (?token= is appended)", func(c *Config) *string { return &c.Auth.PasswordResetURL }),
+	durationSetting("auth.password_reset_ttl", "PASSWORD_RESET_TTL", "lifetime of password reset tokens (e.g. 1h)", func(c *Config) *time.Duration { return &c.Auth.PasswordResetTTL }),
+	durationSetting("auth.password_reset_resend_interval", "PASSWORD_RESET_RESEND_INTERVAL", "minimum interval between password reset emails to the same user (e.g. 1m)", func(c *Config) *time.Duration { return &c.Auth.PasswordResetResendInterval }),
+	stringSetting("auth.email_verification_url", "EMAIL_VERIFICATION_URL", "page linked from email verification emails (?token= is appended)", func(c *Config) *string { return &c.Auth.EmailVerificationURL }),
+	durationSetting("auth.email_verification_ttl", "EMAIL_VERIFICATION_TTL", "lifetime of email verification tokens (e.g. 24h)", func(c *Config) *time.Duration { return &c.Auth.EmailVerificationTTL }),
+	durationSetting("auth.email_verification_resend_interval", "EMAIL_VERIFICATION_RESEND_INTERVAL", "minimum interval between verification emails to the same user (e.g. 1m)", func(c *Config) *time.Duration { return &c.Auth.EmailVerificationResendInterval }),
+	stringSetting("auth.email_change_url", "EMAIL_CHANGE_URL", "page linked from email change confirmations sent to the new address (?token= is appended)", func(c *Config) *string { return &c.Auth.EmailChangeURL }),
+	durationSetting("auth.email_change_ttl", "EMAIL_CHANGE_TTL", "lifetime of email change confirmation tokens (e.g. 24h)", func(c *Config) *time.Duration { return &c.Auth.EmailChangeTTL }),
+	durationSetting("auth.email_change_resend_interval", "EMAIL_CHANGE_RESEND_INTERVAL", "minimum interval between email change requests from the same user (e.g. 1m)", func(c *Config) *time.Duration { return &c.Auth.EmailChangeResendInterval }),
+	stringSetting("auth.unverified_policy", "UNVERIFIED_POLICY", "how users with unverified email addresses are treated (limit, block)", func(c *Config) *string { return &c.Auth.UnverifiedPolicy }),
+	intSetting("auth.unverified_task_limit", "UNVERIFIED_TASK_LIMIT", "number of tasks users with unverified email addresses can create when auth.unverified_policy is limit", func(c *Config) *int { return &c.Auth.UnverifiedTaskLimit }),
+	stringSetting("auth.totp_issuer", "TOTP_ISSUER", "issuer name shown in authenticator apps", func(c *Config) *string { return &c.Auth.TOTPIssuer }),
+	durationSetting("auth.login_challenge_ttl", "LOGIN_CHALLENGE_TTL", "time allowed to enter the second factor after the password (e.g. 5m)", func(c *Config) *time.Duration { return &c.Auth.LoginChallengeTTL }),
+	stringSetting("auth.login_attempt_store", "LOGIN_ATTEMPT_STORE", "where failed login attempts are counted (database, memory)", func(c *Config) *string { return &c.Auth.LoginAttemptStore }),
+	intSetting("auth.login_account_threshold", "LOGIN_ACCOUNT_THRESHOLD", "failed logins before an account is locked (0 disables)", func(c *Config) *int { return &c.Auth.LoginAccountThreshold }),
+	intSetting("auth.login_ip_threshold", "LOGIN_IP_THRESHOLD", "failed logins before a client IP address is locked (0 disables)", func(c *Config) *int { return &c.Auth.LoginIPThreshold }),
+	durationSetting("auth.login_lockout_duration", "LOGIN_LOCKOUT_DURATION", "how long a locked account or IP address cannot log in (e.g. 15m)", func(c *Config) *time.Duration { return &c.Auth.LoginLockoutDuration }),
+	durationSetting("auth.login_backoff_base", "LOGIN_BACKOFF_BASE", "initial delay after repeated failed logins, doubled on each failure (0 disables)", func(c *Config) *time.Duration { return &c.Auth.LoginBackoffBase }),
+	durationSetting("auth.login_failure_window", "LOGIN_FAILURE_WINDOW", "failed logins older than this are forgotten (e.g. 15m)", func(c *Config) *time.Duration { return &c.Auth.LoginFailureWindow }),
+	intSetting("auth.password_min_length", "PASSWORD_MIN_LENGTH", "minimum number of characters in a password", func(c *Config) *int { return &c.Auth.PasswordMinLength }),
+	intSetting("auth.password_max_bytes", "PASSWORD_MAX_BYTES", "maximum length of a password in UTF-8 bytes (at most 72)", func(c *Config) *int { return &c.Auth.PasswordMaxBytes }),
+	stringSetting("auth.breached_passwords_file", "BREACHED_PASSWORDS_FILE", "file of SHA-1 hashes of breached passwords to reject (empty disables)", func(c *Config) *string { return &c.Auth.BreachedPasswordsFile }),
+	stringSetting("auth.password_hasher", "PASSWORD_HASHER", "algorithm for new password hashes (argon2id, bcrypt); older hashes are upgraded on login", func(c *Config) *string { return &c.Auth.PasswordHasher }),
+	intSetting("auth.argon2_memory", "ARGON2_MEMORY", "argon2id memory in KiB", func(c *Config) *int { return &c.Auth.Argon2Memory }),
+	intSetting("auth.argon2_time", "ARGON2_TIME", "argon2id number of passes", func(c *Config) *int { return &c.Auth.Argon2Time }),
+	intSetting("auth.argon2_threads", "ARGON2_THREADS", "argon2id degree of parallelism", func(c *Config) *int { return &c.Auth.Argon2Threads }),
+	intSetting("auth.bcrypt_cost", "BCRYPT_COST", "bcrypt cost when auth.password_hasher is bcrypt", func(c *Config) *int { return &c.Auth.BcryptCost }),
+	stringSetting("auth.oidc_callback_url", "OIDC_CALLBACK_URL", "URL of /api/v1/auth/oidc/callback registered with OpenID Connect providers", func(c *Config) *string { return &c.Auth.OIDCCallbackURL }),
+	stringSetting("auth.oidc_return_url", "OIDC_RETURN_URL", "page the browser returns to after OpenID Connect login or linking", func(c *Config) *string { return &c.Auth.OIDCReturnURL }),
+	durationSetting("auth.oidc_login_ttl", "OIDC_LOGIN_TTL", "time allowed to complete login at an OpenID Connect provider (e.g. 10m)", func(c *Config) *time.Duration { return &c.Auth.OIDCLoginTTL }),
+
+	stringSetting("mail.driver", "MAIL_DRIVER", "how emails are sent (log, file, smtp)", func(c *Config) *string { return &c.Mail.Driver }),
+	stringSetting("mail.from", "MAIL_FROM", "sender address of emails", func(c *Config) *string { return &c.Mail.From }),
+	stringSetting("mail.dir", "MAIL_DIR", "directory emails are written to when mail.driver is file", func(c *Config) *string { return &c.Mail.Dir }),
+	stringSetting("mail.smtp_host", "SMTP_HOST", "SMTP server host", func(c *Config) *string { return &c.Mail.SMTPHost }),
+	stringSetting("mail.smtp_port", "SMTP_PORT", "SMTP server port", func(c *Config) *string { return &c.Mail.SMTPPort }),
+	stringSetting("mail.smtp_username", "SMTP_USERNAME", "SMTP user (empty disables authentication)", func(c *Config) *string { return &c.Mail.SMTPUsername }),
+	stringSetting("mail.smtp_password", "SMTP_PASSWORD", "SMTP password", func(c *Config) *string { return &c.Mail.SMTPPassword }),
+
+	stringSetting("log.level", "LOG_LEVEL", "log level (debug, info, warn, error)", func(c *Config) *string { return &c.Log.Level }),
+	stringSetting("log.file", "LOG_FILE", "file logs are written to in addition to stderr", func(c *Config) *string { return &c.Log.File }),
+}
+
+// flagValue は指定された文字列をそのまま保持し、変換は set に任せる
+// bool の項目は -database-migrate-on-start のように値を省略できる
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (v *flagValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.value
+}
+
+func (v *flagValue) Set(value string) error {
+	v.value = value
+	return nil
+}
+
+func (v *flagValue) IsBoolFlag() bool {
+	return v.isBool
+}
+
+func stringSetting(key, env, usage string, field func(*Config) *string) setting {
+	return setting{key: key, env: env, usage: usage, set: func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}}
+}
+
+func intSetting(key, env, usage string, field func(*Config) *int) setting {
+	return setting{key: key, env: env, usage: usage, set: func(c *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("must be an integer (got %q)", value)
+		}
+		*field(c) = n
+		return nil
+	}}
+}
+
+func boolSetting(key, env, usage string, field func(*Config) *bool) setting {
+	return setting{key: key, env: env, usage: usage, isBool: true, set: func(c *Config, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("must be true or false (got %q)", value)
+		}
+		*field(c) = b
+		return nil
+	}}
+}
+
+func durationSetting(key, env, usage string, field func(*Config) *time.Duration) setting {
+	return setting{key: key, env: env, usage: usage, set: func(c *Config, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("must be a duration such as 30m or 24h (got %q)", value)
+		}
+		*field(c) = d
+		return nil
+	}}
+}
+
+func listSetting(key, env, usage string, field func(*Config) *[]string) setting {
+	return setting{key: key, env: env, usage: usage, set: func(c *Config, value string) error {
+		*field(c) = splitList(value)
+		return nil
+	}}
+}
+
+func splitList(value string) []string {
+	var list []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// Load は既定値・設定ファイル・環境変数・コマンドライン引数の順に設定を読み込み、後のものほど優先する
+// 設定項目のフラグと -config は fs に登録するので、呼び出し側は独自のフラグを先に登録しておき、
+// 残りの引数は fs.Args() で受け取る
+// 読み込めなかった項目はすべてまとめてエラーにする。値の妥当性は Validate で確認する
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	configFile := fs.String("config", "", "YAML or TOML config file (overrides $"+ConfigFileEnv+")")
+	flagValues := make(map[string]*flagValue, len(settings))
+	for _, s := range settings {
+		flagValues[s.key] = &flagValue{isBool: s.isBool}
+		fs.Var(flagValues[s.key], s.flagName(), fmt.Sprintf("%s ($%s)", s.usage, s.env))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	c := Default()
+	var problems []error
+
+	path := *configFile
+	if path == "" {
+		path = os.Getenv(ConfigFileEnv)
+	}
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			problems = append(problems, err)
+		}
+		problems = append(problems, apply(c, values, path)...)
+	}
+
+	envValues := map[string]string{}
+	for _, s := range settings {
+		if v, ok := os.LookupEnv(s.env); ok {
+			envValues[s.key] = v
+		}
+	}
+	// プロバイダーは名前ごとに項目があるため、settings とは別に読み込む（フラグでは指定できない）
+	providers := splitList(os.Getenv(OIDCProvidersEnv))
+	for _, name := range providers {
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		for _, field := range oidcProviderFields {
+			if v, ok := os.LookupEnv(prefix + strings.ToUpper(field)); ok {
+				envValues[oidcProvidersPrefix+name+"."+field] = v
+			}
+		}
+	}
+	problems = append(problems, apply(c, envValues, "environment")...)
+	// 項目の環境変数がなくても、名前を挙げたプロバイダーは Validate で検証する
+	for _, name := range providers {
+		c.Auth.oidcProvider(name)
+	}
+
+	// 明示的に指定されたフラグだけを反映する
+	flagSet := map[string]string{}
+	fs.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if s.flagName() == f.Name {
+				flagSet[s.key] = flagValues[s.key].value
+			}
+		}
+	})
+	problems = append(problems, apply(c, flagSet, "flags")...)
+
+	c.applyDerivedDefaults()
+	return c, errors.Join(problems...)
+}
+
+// apply は key ごとの値を設定に反映する。source はエラーメッセージで読み込み元を示すのに使う
+func apply(c *Config, values map[string]string, source string) []error {
+	var problems []error
+	known := map[string]bool{}
+	for _, s := range settings {
+		known[s.key] = true
+		value, ok := values[s.key]
+		if !ok {
+			continue
+		}
+		if err := s.set(c, value); err != nil {
+			problems = append(problems, fmt.Errorf("%s (%s): %w", s.key, source, err))
+		}
+	}
+
+	var rest []string
+	for key := range values {
+		if !known[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	for _, key := range rest {
+		if name, field, ok := oidcProviderKey(key); ok {
+			setOIDCProvider(c, name, field, values[key])
+			continue
+		}
+		problems = append(problems, fmt.Errorf("%s: unknown setting %q", source, key))
+	}
+	return problems
+}
+
+// oidcProviderKey は auth.oidc_providers.<名前>.<項目> のキーを名前と項目に分ける
+func oidcProviderKey(key string) (name, field string, ok bool) {
+	rest, ok := strings.CutPrefix(key, oidcProvidersPrefix)
+	if !ok {
+		return "", "", false
+	}
+	name, field, ok = strings.Cut(rest, ".")
+	if !ok || !slices.Contains(oidcProviderFields, field) {
+		return "", "", false
+	}
+	return name, field, true
+}
+
+func setOIDCProvider(c *Config, name, field, value string) {
+	p := c.Auth.oidcProvider(name)
+	switch field {
+	case "issuer":
+		p.Issuer = value
+	case "client_id":
+		p.ClientID = value
+	case "client_secret":
+		p.ClientSecret = value
+	case "scopes":
+		p.Scopes = splitList(value)
+	}
+	c.Auth.OIDCProviders[name] = p
+}
+
+// readFile は設定ファイルを読み込み、入れ子のキーを database.driver のような形に平らにする
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config file: %w", err)
+	}
+
+	var tree map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported extension %q (use .yaml, .yml or .toml)", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	flatten("", tree, values)
+	return values, nil
+}
+
+func flatten(prefix string, tree map[string]any, values map[string]string) {
+	for k, v := range tree {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch v := v.(type) {
+		case map[string]any:
+			flatten(key, v, values)
+		case []any:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[key] = strings.Join(items, ",")
+		case nil:
+			values[key] = ""
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+}
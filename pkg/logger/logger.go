@@ -11,18 +11,42 @@ var (
 	zapSugaredLogger *zap.SugaredLogger
 )
 
+// 設定を読み込むまでのログは環境変数だけを見て出力する
 func init() {
+	level := "info"
+	development := os.Getenv("APP_ENV") == "development"
+	if development {
+		level = "debug"
+	}
+	if err := Configure(level, os.Getenv("LOG_FILE"), development); err != nil {
+		panic(err)
+	}
+}
+
+// Configure はログレベル（debug, info, warn, error）と出力先を設定し直す
+// file を指定すると標準エラー出力に加えてファイルにも書き込む
+func Configure(level, file string, development bool) error {
+	atomicLevel, err := zap.ParseAtomicLevel(level)
+	if err != nil {
+		return err
+	}
+
 	cfg := zap.NewProductionConfig()
-	logFile := os.Getenv("APP_LOG_FILE")
-	if logFile != "" {
-		cfg.OutputPaths = []string{"stderr", logFile}
+	if development {
+		cfg = zap.NewDevelopmentConfig()
+	}
+	cfg.Level = atomicLevel
+	if file != "" {
+		cfg.OutputPaths = []string{"stderr", file}
 	}
 
-	ZapLogger = zap.Must(cfg.Build())
-	if os.Getenv("APP_ENV") == "development" {
-		ZapLogger = zap.Must(zap.NewDevelopment())
+	logger, err := cfg.Build()
+	if err != nil {
+		return err
 	}
+	ZapLogger = logger
 	zapSugaredLogger = ZapLogger.Sugar()
+	return nil
 }
 
 func Sync() {
@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go-todo-app-clean-arch/pkg/logger"
+)
+
+type logMailer struct {
+	from string
+}
+
+// NewLogMailer はメールを送らずにログへ出力する Mailer を返す（開発用）
+func NewLogMailer(from string) Mailer {
+	return &logMailer{from: from}
+}
+
+func (m *logMailer) Send(msg *Message) error {
+	logger.Info("Mail (not sent)", "from", m.from, "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
+
+type fileMailer struct {
+	from string
+	dir  string
+	seq  atomic.Int64
+}
+
+// NewFileMailer はメールを dir に 1 通ずつ .eml ファイルとして書き出す Mailer を返す（開発・テスト用）
+func NewFileMailer(from, dir string) (Mailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &fileMailer{from: from, dir: dir}, nil
+}
+
+func (m *fileMailer) Send(msg *Message) error {
+	now := time.Now()
+	// 送った順に並ぶよう、時刻と連番をファイル名にする
+	name := fmt.Sprintf("%s-%04d-%s.eml", now.Format("20060102T150405.000000"), m.seq.Add(1), sanitizeFileName(msg.To))
+	return os.WriteFile(filepath.Join(m.dir, name), format(m.from, msg, now), 0o644)
+}
+
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator || r < ' ' {
+			return '_'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"time"
+
+	"go-todo-app-clean-arch/pkg/config"
+)
+
+// Message はテキスト形式のメール
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(msg *Message) error
+}
+
+// New は mail.driver に応じた Mailer を返す
+func New(c *config.MailConfig) (Mailer, error) {
+	switch c.Driver {
+	case "log":
+		return NewLogMailer(c.From), nil
+	case "file":
+		return NewFileMailer(c.From, c.Dir)
+	case "smtp":
+		return NewSMTPMailer(SMTPConfig{
+			Host:     c.SMTPHost,
+			Port:     c.SMTPPort,
+			Username: c.SMTPUsername,
+			Password: c.SMTPPassword,
+			From:     c.From,
+		}), nil
+	}
+	return nil, fmt.Errorf("unknown mail driver %q", c.Driver)
+}
+
+// format は msg をヘッダー付きのメール本文（RFC 5322）にする
+func format(from string, msg *Message, date time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+	return b.Bytes()
+}
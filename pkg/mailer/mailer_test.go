@@ -0,0 +1,141 @@
+package mailer_test
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/pkg/config"
+	"go-todo-app-clean-arch/pkg/mailer"
+)
+
+var testMessage = &mailer.Message{
+	To:      "user@example.com",
+	Subject: "パスワードの再設定",
+	Body:    "Open the link below.\nhttp://localhost:3000/password/reset?token=abc\n",
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	m, err := mailer.New(&config.MailConfig{Driver: "file", From: "no-reply@example.com", Dir: dir})
+	assert.Nil(t, err)
+
+	assert.Nil(t, m.Send(testMessage))
+	assert.Nil(t, m.Send(testMessage))
+
+	files, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 2)
+	body, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	assert.Nil(t, err)
+	assert.Contains(t, string(body), "From: no-reply@example.com\r\n")
+	assert.Contains(t, string(body), "To: user@example.com\r\n")
+	assert.Contains(t, string(body), "Subject: =?utf-8?q?")
+	assert.Contains(t, string(body), "?token=abc")
+}
+
+func TestSMTPMailer(t *testing.T) {
+	server := startFakeSMTPServer(t)
+	host, port, _ := net.SplitHostPort(server.addr)
+	m := mailer.NewSMTPMailer(mailer.SMTPConfig{Host: host, Port: port, From: "no-reply@example.com"})
+
+	assert.Nil(t, m.Send(testMessage))
+	received := <-server.received
+	assert.Equal(t, "MAIL FROM:<no-reply@example.com> BODY=8BITMIME", received.from)
+	assert.Equal(t, "RCPT TO:<user@example.com>", received.rcpt)
+	assert.Contains(t, received.data, "To: user@example.com\r\n")
+	assert.Contains(t, received.data, "?token=abc\r\n")
+
+	// 接続できない場合はエラーを返す
+	server.listener.Close()
+	assert.NotNil(t, m.Send(testMessage))
+}
+
+func TestNewUnknownDriver(t *testing.T) {
+	_, err := mailer.New(&config.MailConfig{Driver: "sendmail"})
+	assert.ErrorContains(t, err, "sendmail")
+}
+
+type receivedMail struct {
+	from string
+	rcpt string
+	data string
+}
+
+type fakeSMTPServer struct {
+	addr     string
+	listener net.Listener
+	received chan receivedMail
+}
+
+// startFakeSMTPServer は 1 通ずつ受け取って記録するだけの SMTP サーバーを起動する
+func startFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeSMTPServer{addr: listener.Addr().String(), listener: listener, received: make(chan receivedMail, 1)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	var mail receivedMail
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "EHLO"):
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(line, "MAIL"):
+			mail.from = line
+			reply("250 OK")
+		case strings.HasPrefix(line, "RCPT"):
+			mail.rcpt = line
+			reply("250 OK")
+		case line == "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			mail.data = data.String()
+			reply("250 OK")
+		case line == "QUIT":
+			reply("221 bye")
+			s.received <- mail
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// keysMaxAge は取得した公開鍵を使い続ける時間。プロバイダーが鍵を外したら、これが過ぎると受け付けなくなる
+	keysMaxAge = time.Hour
+	// keysMinRefreshInterval は知らない kid のトークンで鍵を取り直す間隔の下限
+	// 偽のトークンを使って、プロバイダーに大量のリクエストを送らせないようにする
+	keysMinRefreshInterval = time.Minute
+)
+
+// keySet はプロバイダーの JWKS から読み込んだ署名の検証用の公開鍵
+type keySet struct {
+	keys      []publicKey
+	fetchedAt time.Time
+}
+
+type publicKey struct {
+	id string
+	// alg が空の鍵は、トークンの alg が鍵の種類に合えば使う
+	alg string
+	key crypto.PublicKey
+}
+
+// find は token の kid の鍵を返す。kid のないトークンは、鍵が 1 つだけの場合にその鍵で検証する
+func (s *keySet) find(token *jwt.Token) (*publicKey, bool) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" && len(s.keys) == 1 {
+		return &s.keys[0], true
+	}
+	for i := range s.keys {
+		if s.keys[i].id == kid && kid != "" {
+			return &s.keys[i], true
+		}
+	}
+	return nil, false
+}
+
+// publicKey は token の署名を検証する鍵を返す
+// 知らない kid の場合は、プロバイダーが鍵を入れ替えたとみなして JWKS を取り直す
+func (p *Provider) publicKey(ctx context.Context, m *metadata, token *jwt.Token) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	if p.keys == nil || now.Sub(p.keys.fetchedAt) >= keysMaxAge {
+		if err := p.fetchKeys(ctx, m, now); err != nil {
+			return nil, err
+		}
+	}
+	key, ok := p.keys.find(token)
+	if !ok && now.Sub(p.keys.fetchedAt) >= keysMinRefreshInterval {
+		if err := p.fetchKeys(ctx, m, now); err != nil {
+			return nil, err
+		}
+		key, ok = p.keys.find(token)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key id %v", ErrAuthenticationFailed, token.Header["kid"])
+	}
+	if key.alg != "" && key.alg != token.Method.Alg() {
+		return nil, fmt.Errorf("%w: key %s is for %s, not %s", ErrAuthenticationFailed, key.id, key.alg, token.Method.Alg())
+	}
+	return key.key, nil
+}
+
+// fetchKeys は JWKS を取得する。p.mu を持った状態で呼ぶ
+func (p *Provider) fetchKeys(ctx context.Context, m *metadata, now time.Time) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.JWKSURI, nil)
+	if err != nil {
+		return err
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	status, err := p.doJSON(req, &set)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("oidc: jwks_uri returned %d", status)
+	}
+
+	keys := &keySet{fetchedAt: now}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// 対応していない種類の鍵は読み飛ばし、他の鍵で検証できるようにする
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys.keys = append(keys.keys, publicKey{id: jwk.Kid, alg: jwk.Alg, key: key})
+	}
+	p.keys = keys
+	return nil
+}
+
+// jsonWebKey は JWK（RFC 7517）のうち、署名の検証に使う公開鍵の項目
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC・OKP
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil || !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("EC point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || k.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
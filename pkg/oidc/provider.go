@@ -0,0 +1,294 @@
+// Package oidc は OpenID Connect の Relying Party として、OpenID Provider の認可コードフロー（PKCE）を扱う
+// 設定は Discovery で取得し、ID トークンはプロバイダーの JWKS の公開鍵で検証する
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"go-todo-app-clean-arch/pkg"
+)
+
+// ErrAuthenticationFailed は認可コードの交換や ID トークンの検証に失敗したことを表す
+// プロバイダーとの通信の失敗とは区別し、ユーザーにはログインし直してもらう
+var ErrAuthenticationFailed = errors.New("oidc: authentication failed")
+
+const (
+	requestTimeout = 10 * time.Second
+	// maxResponseBytes はプロバイダーのレスポンスとして読み込む大きさの上限
+	maxResponseBytes = 1 << 20
+	// clockSkew はプロバイダーとの時計のずれとして許す時間
+	clockSkew = time.Minute
+)
+
+// signingMethods は ID トークンの署名として受け付けるアルゴリズム。none と HMAC は受け付けない
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// Config は 1 つの OpenID Provider の設定
+type Config struct {
+	// Issuer は OpenID Provider の発行者の URL。Issuer + /.well-known/openid-configuration から設定を取得する
+	Issuer   string
+	ClientID string
+	// ClientSecret が空の場合は公開クライアントとして、PKCE だけで認可コードを交換する
+	ClientSecret string
+	// Scopes に openid がなければ追加する
+	Scopes []string
+	// RedirectURL はプロバイダーに登録したコールバックの URL
+	RedirectURL string
+}
+
+// Identity は検証した ID トークンが表すプロバイダーのアカウント
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// metadata は Discovery（OpenID Connect Discovery 1.0）で取得するプロバイダーの設定
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type Provider struct {
+	config Config
+	client *http.Client
+	clock  pkg.Clock
+
+	// mu は metadata と keys を守る。metadata の取得中は持たない
+	mu       sync.Mutex
+	metadata *metadata
+	keys     *keySet
+}
+
+// NewProvider は Provider を返す。プロバイダーには最初に使うときに問い合わせるので、起動時には通信しない
+func NewProvider(config Config) *Provider {
+	scopes := []string{"openid"}
+	for _, scope := range config.Scopes {
+		if scope != "openid" {
+			scopes = append(scopes, scope)
+		}
+	}
+	config.Scopes = scopes
+	return &Provider{
+		config: config,
+		client: &http.Client{Timeout: requestTimeout},
+		clock:  pkg.NewClock(),
+	}
+}
+
+// AuthorizationURL はブラウザを送る認可エンドポイントの URL を返す
+// codeVerifier からは S256 の code_challenge を作り、codeVerifier 自体は認可コードの交換で送る
+func (p *Provider) AuthorizationURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	m, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(m.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("oidc: invalid authorization_endpoint: %w", err)
+	}
+	query := u.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// CodeChallenge は PKCE（RFC 7636）の S256 の code_challenge を返す
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Authenticate は認可コードを ID トークンに交換し、nonce を含めて検証する
+func (p *Provider) Authenticate(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error) {
+	rawIDToken, err := p.exchange(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	return p.VerifyIDToken(ctx, rawIDToken, nonce)
+}
+
+func (p *Provider) exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	m, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"code_verifier": {codeVerifier},
+		"client_id":     {p.config.ClientID},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// client_secret_basic（RFC 6749 2.3.1 により、ID とシークレットはフォームの形式でエンコードする）
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.doJSON(req, &body)
+	if err != nil {
+		return "", err
+	}
+	// 認可コードの誤り・期限切れなどは 400 で返る
+	if status >= 400 && status < 500 {
+		return "", fmt.Errorf("%w: token endpoint returned %d %s %s", ErrAuthenticationFailed, status, body.Error, body.ErrorDescription)
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("oidc: token endpoint returned %d", status)
+	}
+	if body.IDToken == "" {
+		return "", fmt.Errorf("%w: token response has no id_token", ErrAuthenticationFailed)
+	}
+	return body.IDToken, nil
+}
+
+// VerifyIDToken は ID トークンの署名・発行者・対象者・有効期限・nonce を検証する
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*Identity, error) {
+	m, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 鍵を取得できなかった場合は、トークンの誤りではなく通信の失敗として返す
+	var fetchErr error
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(signingMethods), jwt.WithoutClaimsValidation())
+	_, err = parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		key, err := p.publicKey(ctx, m, token)
+		if err != nil && !errors.Is(err, ErrAuthenticationFailed) {
+			fetchErr = err
+		}
+		return key, err
+	})
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAuthenticationFailed, err)
+	}
+
+	now := p.clock.Now()
+	switch {
+	case !claims.VerifyIssuer(m.Issuer, true):
+		return nil, fmt.Errorf("%w: unexpected issuer %v", ErrAuthenticationFailed, claims["iss"])
+	case !claims.VerifyAudience(p.config.ClientID, true):
+		return nil, fmt.Errorf("%w: unexpected audience %v", ErrAuthenticationFailed, claims["aud"])
+	case claims["azp"] != nil && claims["azp"] != p.config.ClientID:
+		return nil, fmt.Errorf("%w: unexpected authorized party %v", ErrAuthenticationFailed, claims["azp"])
+	case !claims.VerifyExpiresAt(now.Add(-clockSkew).Unix(), true):
+		return nil, fmt.Errorf("%w: id token has expired", ErrAuthenticationFailed)
+	case !claims.VerifyIssuedAt(now.Add(clockSkew).Unix(), true):
+		return nil, fmt.Errorf("%w: id token is issued in the future", ErrAuthenticationFailed)
+	}
+	if got, _ := claims["nonce"].(string); subtle.ConstantTimeCompare([]byte(got), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrAuthenticationFailed)
+	}
+
+	identity := &Identity{}
+	identity.Subject, _ = claims["sub"].(string)
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("%w: id token has no subject", ErrAuthenticationFailed)
+	}
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	// 文字列で返すプロバイダーもある
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+	return identity, nil
+}
+
+// discover はプロバイダーの設定を返す。取得できた設定は使い回し、失敗した場合は次の呼び出しで取り直す
+// プロバイダーの応答が遅くても他の呼び出しを止めないよう、取得はロックの外で行う
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	cached := p.metadata
+	p.mu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.config.Issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	m := &metadata{}
+	status, err := p.doJSON(req, m)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("oidc: discovery returned %d", status)
+	}
+	// 別の発行者の設定を使わないよう、設定した発行者と完全に一致することを確かめる
+	if m.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("oidc: discovery returned issuer %q, expected %q", m.Issuer, p.config.Issuer)
+	}
+	if m.AuthorizationEndpoint == "" || m.TokenEndpoint == "" || m.JWKSURI == "" {
+		return nil, errors.New("oidc: discovery document is missing an endpoint")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// 同時に取得した場合は、先に保存した設定を使い続ける
+	if p.metadata == nil {
+		p.metadata = m
+	}
+	return p.metadata, nil
+}
+
+// doJSON は req を送り、レスポンスの JSON を v に読み込んでステータスコードを返す
+// エラーのレスポンスも JSON で返るため、ステータスコードの判断は呼び出し側で行う
+func (p *Provider) doJSON(req *http.Request, v interface{}) (int, error) {
+	res, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("oidc: %w", err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxResponseBytes))
+	if err != nil {
+		return 0, fmt.Errorf("oidc: %w", err)
+	}
+	if err := json.Unmarshal(body, v); err != nil && res.StatusCode == http.StatusOK {
+		return 0, fmt.Errorf("oidc: invalid response from %s: %w", req.URL.Redacted(), err)
+	}
+	return res.StatusCode, nil
+}
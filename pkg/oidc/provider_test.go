@@ -0,0 +1,191 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-todo-app-clean-arch/pkg/tester"
+)
+
+const testRedirectURL = "http://localhost:8080/api/v1/auth/oidc/callback"
+
+func newTestProvider(t *testing.T) (*Provider, *tester.FakeOIDCProvider) {
+	fake := tester.NewFakeOIDCProvider("todo-app", "client-secret")
+	t.Cleanup(fake.Close)
+	return NewProvider(Config{
+		Issuer:       fake.Issuer(),
+		ClientID:     fake.ClientID,
+		ClientSecret: fake.ClientSecret,
+		Scopes:       []string{"email", "profile"},
+		RedirectURL:  testRedirectURL,
+	}), fake
+}
+
+// authorize は認可エンドポイントでログインし、コールバックに渡される認可コードを返す
+func authorize(t *testing.T, p *Provider, fake *tester.FakeOIDCProvider, state, nonce, verifier string) string {
+	authorizationURL, err := p.AuthorizationURL(context.Background(), state, nonce, verifier)
+	require.NoError(t, err)
+	callback, err := fake.Authorize(authorizationURL)
+	require.NoError(t, err)
+	assert.Equal(t, testRedirectURL, callback.Scheme+"://"+callback.Host+callback.Path)
+	assert.Equal(t, state, callback.Query().Get("state"))
+	return callback.Query().Get("code")
+}
+
+func TestAuthorizationURL(t *testing.T) {
+	p, fake := newTestProvider(t)
+	authorizationURL, err := p.AuthorizationURL(context.Background(), "state", "nonce", "verifier")
+	require.NoError(t, err)
+
+	u, err := url.Parse(authorizationURL)
+	require.NoError(t, err)
+	assert.Equal(t, fake.Issuer()+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	query := u.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "todo-app", query.Get("client_id"))
+	assert.Equal(t, testRedirectURL, query.Get("redirect_uri"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, "state", query.Get("state"))
+	assert.Equal(t, "nonce", query.Get("nonce"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	// code_verifier の SHA-256 を base64url（パディングなし）にしたもの
+	assert.Equal(t, "GA8oGub8JPoTWj0MQu3n61NYcQPr07XzybNpRYmvVd0", CodeChallenge("dBjftJeZ4CVP-mJ92K8XrlRRyuPAeRYKhQJW3dgdF7c"))
+}
+
+func TestAuthenticate(t *testing.T) {
+	p, fake := newTestProvider(t)
+	fake.SignIn(tester.FakeOIDCAccount{Subject: "user-1", Email: "user@example.com", EmailVerified: true})
+
+	code := authorize(t, p, fake, "state", "nonce", "verifier-verifier-verifier-verifier-verifier")
+	identity, err := p.Authenticate(context.Background(), code, "verifier-verifier-verifier-verifier-verifier", "nonce")
+	require.NoError(t, err)
+	assert.Equal(t, &Identity{Subject: "user-1", Email: "user@example.com", EmailVerified: true}, identity)
+
+	// 認可コードは 1 回しか使えない
+	_, err = p.Authenticate(context.Background(), code, "verifier-verifier-verifier-verifier-verifier", "nonce")
+	assert.ErrorIs(t, err, ErrAuthenticationFailed)
+}
+
+func TestAuthenticateRejects(t *testing.T) {
+	p, fake := newTestProvider(t)
+	verifier := "verifier-verifier-verifier-verifier-verifier"
+
+	// PKCE の code_verifier が違う（横取りした認可コードは使えない）
+	code := authorize(t, p, fake, "state", "nonce", verifier)
+	_, err := p.Authenticate(context.Background(), code, "another-verifier-another-verifier-another", "nonce")
+	assert.ErrorIs(t, err, ErrAuthenticationFailed)
+
+	// 認可を始めたときと nonce が違う
+	code = authorize(t, p, fake, "state", "nonce", verifier)
+	_, err = p.Authenticate(context.Background(), code, verifier, "another-nonce")
+	assert.ErrorIs(t, err, ErrAuthenticationFailed)
+
+	for name, modify := range map[string]func(jwt.MapClaims){
+		"wrong audience":   func(c jwt.MapClaims) { c["aud"] = "another-client" },
+		"wrong issuer":     func(c jwt.MapClaims) { c["iss"] = "https://attacker.example.com" },
+		"wrong azp":        func(c jwt.MapClaims) { c["aud"] = []string{"todo-app", "other"}; c["azp"] = "other" },
+		"expired":          func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-2 * time.Minute).Unix() },
+		"issued in future": func(c jwt.MapClaims) { c["iat"] = time.Now().Add(time.Hour).Unix() },
+		"no subject":       func(c jwt.MapClaims) { delete(c, "sub") },
+	} {
+		fake.ModifyClaims = modify
+		code := authorize(t, p, fake, "state", "nonce", verifier)
+		_, err := p.Authenticate(context.Background(), code, verifier, "nonce")
+		assert.ErrorIs(t, err, ErrAuthenticationFailed, name)
+	}
+}
+
+func TestVerifyIDTokenSignature(t *testing.T) {
+	p, fake := newTestProvider(t)
+	claims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss": fake.Issuer(), "sub": "user-1", "aud": "todo-app", "nonce": "nonce",
+			"iat": time.Now().Unix(), "exp": time.Now().Add(time.Minute).Unix(),
+		}
+	}
+	_, err := p.VerifyIDToken(context.Background(), fake.SignIDToken(claims()), "nonce")
+	require.NoError(t, err)
+
+	// 署名のないトークンと、クライアントシークレットを HMAC の鍵にしたトークンは受け付けない
+	unsigned, _ := jwt.NewWithClaims(jwt.SigningMethodNone, claims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	_, err = p.VerifyIDToken(context.Background(), unsigned, "nonce")
+	assert.ErrorIs(t, err, ErrAuthenticationFailed)
+	hmac, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims()).SignedString([]byte("client-secret"))
+	_, err = p.VerifyIDToken(context.Background(), hmac, "nonce")
+	assert.ErrorIs(t, err, ErrAuthenticationFailed)
+
+	// 他の鍵で署名したトークン
+	_, otherFake := newTestProvider(t)
+	_, err = p.VerifyIDToken(context.Background(), otherFake.SignIDToken(claims()), "nonce")
+	assert.ErrorIs(t, err, ErrAuthenticationFailed)
+}
+
+func TestKeyRotation(t *testing.T) {
+	p, fake := newTestProvider(t)
+	clock := time.Now()
+	p.clock = tester.NewMockClock(clock)
+	claims := jwt.MapClaims{
+		"iss": fake.Issuer(), "sub": "user-1", "aud": "todo-app", "nonce": "nonce",
+		"iat": clock.Unix(), "exp": clock.Add(10 * time.Minute).Unix(),
+	}
+	_, err := p.VerifyIDToken(context.Background(), fake.SignIDToken(claims), "nonce")
+	require.NoError(t, err)
+
+	// プロバイダーが鍵を入れ替えても、取り直す間隔が過ぎるまでは JWKS を取り直さない
+	fake.RotateKey()
+	_, err = p.VerifyIDToken(context.Background(), fake.SignIDToken(claims), "nonce")
+	assert.ErrorIs(t, err, ErrAuthenticationFailed)
+
+	p.clock = tester.NewMockClock(clock.Add(keysMinRefreshInterval))
+	_, err = p.VerifyIDToken(context.Background(), fake.SignIDToken(claims), "nonce")
+	assert.NoError(t, err)
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	fake := tester.NewFakeOIDCProvider("todo-app", "")
+	defer fake.Close()
+	// 末尾の / の有無も含めて、設定した発行者と一致しなければ使わない
+	p := NewProvider(Config{Issuer: fake.Issuer() + "/", ClientID: "todo-app", RedirectURL: testRedirectURL})
+	_, err := p.AuthorizationURL(context.Background(), "state", "nonce", "verifier")
+	assert.ErrorContains(t, err, "discovery returned issuer")
+	assert.NotErrorIs(t, err, ErrAuthenticationFailed)
+}
+
+func TestDiscoveryCanceled(t *testing.T) {
+	p, _ := newTestProvider(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// リクエストが取り消されたらプロバイダーに問い合わせず、失敗を覚えずに次の呼び出しで取り直す
+	_, err := p.AuthorizationURL(ctx, "state", "nonce", "verifier")
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = p.AuthorizationURL(context.Background(), "state", "nonce", "verifier")
+	assert.NoError(t, err)
+}
+
+func TestJSONWebKeyEC(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	jwk := jsonWebKey{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+	public, err := jwk.publicKey()
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(public))
+
+	jwk.Y = jwk.X
+	_, err = jwk.publicKey()
+	assert.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	"context"
+	"time"
+)
+
+// Backoff は Retry の待ち時間。失敗するたびに Multiplier 倍し、Max を上限とする
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+// Retry は fn が成功するか ctx が終わるまで、待ち時間を伸ばしながら fn を呼び出す
+// ctx が終わった場合は最後に fn が返したエラーを返す
+// onRetry は待つ前に呼ばれ、ログの出力などに使う
+func Retry(ctx context.Context, backoff Backoff, fn func() error, onRetry func(attempt int, wait time.Duration, err error)) error {
+	wait := backoff.Initial
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		// 次の待ち時間の間に期限が来る場合はそれ以上試さない
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return err
+		}
+		if onRetry != nil {
+			onRetry(attempt, wait, err)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		wait = time.Duration(float64(wait) * backoff.Multiplier)
+		if wait > backoff.Max {
+			wait = backoff.Max
+		}
+	}
+}
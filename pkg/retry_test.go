@@ -0,0 +1,54 @@
+package pkg_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/pkg"
+)
+
+var testBackoff = pkg.Backoff{Initial: time.Millisecond, Max: 4 * time.Millisecond, Multiplier: 2}
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	var waits []time.Duration
+	err := pkg.Retry(context.Background(), testBackoff, func() error {
+		calls++
+		if calls < 5 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}, func(attempt int, wait time.Duration, err error) {
+		waits = append(waits, wait)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 5, calls)
+	// 待ち時間は倍々に伸び、上限で止まる
+	assert.Equal(t, []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond}, waits)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	err := pkg.Retry(ctx, testBackoff, func() error {
+		calls++
+		return errors.New("connection refused")
+	}, nil)
+	assert.EqualError(t, err, "connection refused")
+	assert.Greater(t, calls, 1)
+
+	// 期限切れの ctx では 1 回だけ試す
+	calls = 0
+	err = pkg.Retry(ctx, testBackoff, func() error {
+		calls++
+		return errors.New("connection refused")
+	}, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,74 @@
+package security
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// breachedPrefixLength は k-anonymity で問い合わせに使うハッシュの先頭の長さ
+const breachedPrefixLength = 5
+
+// BreachedPasswords は漏洩したパスワードの SHA-1 の一覧
+// Have I Been Pwned の range API と同じく、ハッシュの先頭 5 文字ごとに残りの部分をまとめて持つ
+type BreachedPasswords struct {
+	ranges map[string]map[string]struct{}
+}
+
+// LoadBreachedPasswords は 1 行に 1 つ SHA-1（16 進数）を書いたファイルを読み込む
+func LoadBreachedPasswords(path string) (*BreachedPasswords, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadBreachedPasswords(f)
+}
+
+// ReadBreachedPasswords は LoadBreachedPasswords と同じ形式の一覧を r から読み込む
+// Pwned Passwords の一覧をそのまま使えるよう、ハッシュの後ろの ":件数" と空行は読み飛ばす
+func ReadBreachedPasswords(r io.Reader) (*BreachedPasswords, error) {
+	b := &BreachedPasswords{ranges: map[string]map[string]struct{}{}}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		hash, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if hash == "" {
+			continue
+		}
+		if _, err := hex.DecodeString(hash); err != nil || len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("line %d: %q is not a SHA-1 hash", line, hash)
+		}
+		hash = strings.ToUpper(hash)
+		prefix, suffix := hash[:breachedPrefixLength], hash[breachedPrefixLength:]
+		if b.ranges[prefix] == nil {
+			b.ranges[prefix] = map[string]struct{}{}
+		}
+		b.ranges[prefix][suffix] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Len は一覧に含まれるハッシュの数を返す
+func (b *BreachedPasswords) Len() int {
+	n := 0
+	for _, suffixes := range b.ranges {
+		n += len(suffixes)
+	}
+	return n
+}
+
+// IsBreached は password が一覧に含まれるかを返す
+// 手元の一覧を引くだけなので失敗しないが、外部のサービスに問い合わせる実装と同じ形にしている
+func (b *BreachedPasswords) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	_, ok := b.ranges[hash[:breachedPrefixLength]][hash[breachedPrefixLength:]]
+	return ok, nil
+}
@@ -0,0 +1,35 @@
+package security_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-todo-app-clean-arch/pkg/security"
+)
+
+func TestBreachedPasswords(t *testing.T) {
+	// SHA-1("password") と SHA-1("123456")。件数付き・小文字・空行も読めること
+	list := "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\n\n7c4a8d09ca3762af61e59520943dc26494f8941b\n"
+	breached, err := security.ReadBreachedPasswords(strings.NewReader(list))
+	require.NoError(t, err)
+	assert.Equal(t, 2, breached.Len())
+
+	for password, want := range map[string]bool{
+		"password":                   true,
+		"123456":                     true,
+		"Password":                   false,
+		"long-and-unique-passphrase": false,
+	} {
+		got, err := breached.IsBreached(password)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, password)
+	}
+}
+
+func TestReadBreachedPasswordsInvalid(t *testing.T) {
+	_, err := security.ReadBreachedPasswords(strings.NewReader("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8\nnot-a-hash\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
@@ -0,0 +1,271 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"go-todo-app-clean-arch/pkg/config"
+)
+
+// minRSAKeyBits は署名に使える RSA の鍵の長さの下限
+const minRSAKeyBits = 2048
+
+// jwtKey は JWT の署名・検証に使う 1 つの鍵
+type jwtKey struct {
+	id     string
+	method jwt.SigningMethod
+	// signKey が nil の鍵は検証にだけ使う
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// KeyRing は JWT に署名する鍵と、検証に使う鍵の一覧
+// 署名には active の鍵だけを使い、ヘッダーの kid で検証する鍵を選ぶ
+// 入れ替えた古い鍵は、発行済みのトークンが切れるまで検証にだけ使う
+type KeyRing struct {
+	active *jwtKey
+	keys   map[string]*jwtKey
+	// hmac は kid のない HS256 のトークンを検証する鍵。auth.jwt_secret で署名していた頃のトークンに使う
+	hmac *jwtKey
+}
+
+// NewKeyRing は設定から KeyRing を作る
+// auth.jwt_signing_key_file がない場合は auth.jwt_secret の HS256 で署名する（開発用）
+// ある場合も auth.jwt_secret が設定されていれば、移行中のトークンのために HS256 の検証を続ける
+func NewKeyRing(c *config.AuthConfig) (*KeyRing, error) {
+	if c.JWTSigningKeyFile == "" {
+		return NewHMACKeyRing([]byte(c.JWTSecret)), nil
+	}
+	active, err := loadPEM(c.JWTSigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := active.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: the signing key must be a private key", c.JWTSigningKeyFile)
+	}
+	var retired []crypto.PublicKey
+	for _, path := range c.JWTVerificationKeyFiles {
+		key, err := loadPEM(path)
+		if err != nil {
+			return nil, err
+		}
+		retired = append(retired, key)
+	}
+	k, err := NewSigningKeyRing(signer, retired...)
+	if err != nil {
+		return nil, err
+	}
+	if c.JWTSecret != "" {
+		k.hmac = newHMACKey([]byte(c.JWTSecret))
+	}
+	return k, nil
+}
+
+// NewHMACKeyRing は secret の HS256 で署名・検証する KeyRing を返す
+// 検証にも secret が要るため、JWKS では何も公開しない
+func NewHMACKeyRing(secret []byte) *KeyRing {
+	key := newHMACKey(secret)
+	return &KeyRing{active: key, keys: map[string]*jwtKey{}, hmac: key}
+}
+
+func newHMACKey(secret []byte) *jwtKey {
+	return &jwtKey{method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret}
+}
+
+// NewSigningKeyRing は active で署名し、active と retired で検証する KeyRing を返す
+// 鍵は RSA（RS256）か Ed25519（EdDSA）。retired は秘密鍵でも公開鍵でもよい
+func NewSigningKeyRing(active crypto.Signer, retired ...crypto.PublicKey) (*KeyRing, error) {
+	k := &KeyRing{keys: map[string]*jwtKey{}}
+	key, err := newAsymmetricKey(active.Public())
+	if err != nil {
+		return nil, err
+	}
+	key.signKey = active
+	k.active = key
+	k.keys[key.id] = key
+	for _, public := range retired {
+		if signer, ok := public.(crypto.Signer); ok {
+			public = signer.Public()
+		}
+		key, err := newAsymmetricKey(public)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := k.keys[key.id]; !ok {
+			k.keys[key.id] = key
+		}
+	}
+	return k, nil
+}
+
+func newAsymmetricKey(public crypto.PublicKey) (*jwtKey, error) {
+	id, err := KeyID(public)
+	if err != nil {
+		return nil, err
+	}
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA keys must be at least %d bits (got %d)", minRSAKeyBits, pub.N.BitLen())
+		}
+		return &jwtKey{id: id, method: jwt.SigningMethodRS256, verifyKey: pub}, nil
+	case ed25519.PublicKey:
+		return &jwtKey{id: id, method: jwt.SigningMethodEdDSA, verifyKey: pub}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %T", public)
+}
+
+// loadPEM は PEM 形式の秘密鍵（PKCS #8・PKCS #1）か公開鍵（PKIX・PKCS #1）を読み込む
+func loadPEM(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+	var key crypto.PublicKey
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// Sign は claims を active の鍵で署名し、ヘッダーに鍵の kid を付ける
+func (k *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.active.method, claims)
+	if k.active.id != "" {
+		token.Header["kid"] = k.active.id
+	}
+	return token.SignedString(k.active.signKey)
+}
+
+// Keyfunc は jwt.Parse に渡し、ヘッダーの kid の鍵で検証させる
+// alg が鍵の種類と違うトークンは拒否する（公開鍵を HMAC の秘密として使わせる攻撃を防ぐ）
+func (k *KeyRing) Keyfunc(token *jwt.Token) (interface{}, error) {
+	key := k.hmac
+	if kid, ok := token.Header["kid"].(string); ok {
+		key = k.keys[kid]
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown key id %v", token.Header["kid"])
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.verifyKey, nil
+}
+
+// JWK は JSON Web Key（RFC 7517）の公開鍵
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS は検証に使う公開鍵の一覧を返す。active の鍵を先頭にする
+func (k *KeyRing) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		if id != k.active.id {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if k.active.id != "" {
+		ids = append([]string{k.active.id}, ids...)
+	}
+	for _, id := range ids {
+		key := k.keys[id]
+		jwk, _ := publicJWK(key.verifyKey)
+		jwk.Use = "sig"
+		jwk.Alg = key.method.Alg()
+		jwk.Kid = key.id
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// publicJWK は公開鍵の JWK のうち、鍵そのものを表す項目だけを返す
+func publicJWK(public crypto.PublicKey) (JWK, error) {
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}, nil
+	case ed25519.PublicKey:
+		return JWK{Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(pub)}, nil
+	}
+	return JWK{}, errors.New("unsupported key type")
+}
+
+// KeyID は公開鍵の JWK Thumbprint（RFC 7638、SHA-256）を返す
+// 設定なしで鍵ごとに決まり、同じ鍵ならどのサーバーでも同じ kid になる
+func KeyID(public crypto.PublicKey) (string, error) {
+	jwk, err := publicJWK(public)
+	if err != nil {
+		return "", fmt.Errorf("unsupported key type %T", public)
+	}
+	// 必須の項目だけを辞書順に並べた JSON のハッシュ
+	var members interface{}
+	if jwk.Kty == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+	b, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,166 @@
+package security_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-todo-app-clean-arch/pkg/config"
+	"go-todo-app-clean-arch/pkg/security"
+)
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{"user_id": 1, "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+func signAndParse(t *testing.T, signer, verifier *security.KeyRing) (*jwt.Token, error) {
+	t.Helper()
+	signed, err := signer.Sign(testClaims())
+	require.NoError(t, err)
+	return jwt.Parse(signed, verifier.Keyfunc)
+}
+
+func TestKeyRingSignAndVerify(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for alg, key := range map[string]crypto.Signer{"EdDSA": edKey, "RS256": rsaKey} {
+		keys, err := security.NewSigningKeyRing(key)
+		require.NoError(t, err)
+		token, err := signAndParse(t, keys, keys)
+		require.NoError(t, err, alg)
+		assert.Equal(t, alg, token.Method.Alg())
+		kid, _ := security.KeyID(key.Public())
+		assert.Equal(t, kid, token.Header["kid"])
+	}
+}
+
+func TestKeyRingRotation(t *testing.T) {
+	_, oldKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, newKey, _ := ed25519.GenerateKey(rand.Reader)
+	before, err := security.NewSigningKeyRing(oldKey)
+	require.NoError(t, err)
+	after, err := security.NewSigningKeyRing(newKey, oldKey.Public())
+	require.NoError(t, err)
+
+	// 入れ替える前に発行したトークンも、入れ替えた後で検証できる
+	_, err = signAndParse(t, before, after)
+	assert.NoError(t, err)
+	_, err = signAndParse(t, after, after)
+	assert.NoError(t, err)
+	// 古い鍵しか知らない側は新しい鍵のトークンを受け付けない
+	_, err = signAndParse(t, after, before)
+	assert.ErrorContains(t, err, "unknown key id")
+
+	// JWKS は署名に使う鍵を先頭にし、古い鍵も公開する
+	set := after.JWKS()
+	require.Len(t, set.Keys, 2)
+	newID, _ := security.KeyID(newKey.Public())
+	oldID, _ := security.KeyID(oldKey.Public())
+	assert.Equal(t, newID, set.Keys[0].Kid)
+	assert.Equal(t, oldID, set.Keys[1].Kid)
+	assert.Equal(t, security.JWK{Kty: "OKP", Use: "sig", Alg: "EdDSA", Kid: newID, Crv: "Ed25519",
+		X: base64.RawURLEncoding.EncodeToString(newKey.Public().(ed25519.PublicKey))}, set.Keys[0])
+}
+
+func TestKeyRingRejectsAlgorithmConfusion(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keys, err := security.NewSigningKeyRing(rsaKey)
+	require.NoError(t, err)
+
+	// 公開鍵を HMAC の秘密として署名し、RSA の kid を付けたトークン
+	public, _ := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	kid, _ := security.KeyID(&rsaKey.PublicKey)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	forged.Header["kid"] = kid
+	signed, err := forged.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}))
+	require.NoError(t, err)
+	_, err = jwt.Parse(signed, keys.Keyfunc)
+	assert.ErrorContains(t, err, "unexpected signing method")
+
+	// kid のない HS256 のトークンは auth.jwt_secret がない限り受け付けない
+	_, err = signAndParse(t, security.NewHMACKeyRing([]byte("secret")), keys)
+	assert.Error(t, err)
+}
+
+func TestKeyRingRejectsWeakRSAKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	_, err = security.NewSigningKeyRing(rsaKey)
+	assert.ErrorContains(t, err, "at least 2048 bits")
+}
+
+func TestHMACKeyRing(t *testing.T) {
+	keys := security.NewHMACKeyRing([]byte("secret"))
+	token, err := signAndParse(t, keys, keys)
+	require.NoError(t, err)
+	assert.Equal(t, "HS256", token.Method.Alg())
+	assert.NotContains(t, token.Header, "kid")
+	_, err = signAndParse(t, keys, security.NewHMACKeyRing([]byte("other")))
+	assert.Error(t, err)
+	assert.Empty(t, keys.JWKS().Keys)
+}
+
+// RFC 7638 3.1 の例
+func TestKeyIDThumbprint(t *testing.T) {
+	n, err := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	require.NoError(t, err)
+	public := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}
+	kid, err := security.KeyID(public)
+	require.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", kid)
+}
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func TestNewKeyRing(t *testing.T) {
+	c := config.Default().Auth
+	c.JWTSecret = "legacy-secret"
+
+	// 署名鍵のファイルがなければ auth.jwt_secret の HS256
+	keys, err := security.NewKeyRing(&c)
+	require.NoError(t, err)
+	legacy, err := keys.Sign(testClaims())
+	require.NoError(t, err)
+
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(edKey)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	c.JWTSigningKeyFile = writePEM(t, "signing.pem", "PRIVATE KEY", der)
+	c.JWTVerificationKeyFiles = []string{writePEM(t, "retired.pem", "RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey))}
+	keys, err = security.NewKeyRing(&c)
+	require.NoError(t, err)
+
+	token, err := signAndParse(t, keys, keys)
+	require.NoError(t, err)
+	assert.Equal(t, "EdDSA", token.Method.Alg())
+	assert.Len(t, keys.JWKS().Keys, 2)
+	// 移行前に HS256 で発行したトークンも検証できる
+	_, err = jwt.Parse(legacy, keys.Keyfunc)
+	assert.NoError(t, err)
+
+	c.JWTVerificationKeyFiles = []string{filepath.Join(t.TempDir(), "missing.pem")}
+	_, err = security.NewKeyRing(&c)
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"go-todo-app-clean-arch/pkg/config"
+)
+
+const (
+	argon2idSaltLength = 16
+	argon2idKeyLength  = 32
+)
+
+// ErrUnknownPasswordHash は保存されているハッシュの形式が分からないことを表す
+var ErrUnknownPasswordHash = errors.New("unknown password hash format")
+
+// PasswordHasher はパスワードのハッシュ化と検証を行う
+// ハッシュはアルゴリズムとパラメータを含む PHC 形式（bcrypt は従来の $2a$ 形式）の文字列で表す
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify は encoded が password のハッシュかを確かめる。設定と違うアルゴリズムのハッシュも検証できる
+	Verify(password, encoded string) (bool, error)
+	// NeedsRehash は encoded が設定と違うアルゴリズム・パラメータで作られているかを返す
+	NeedsRehash(encoded string) bool
+}
+
+// NewPasswordHasher は auth.password_hasher に応じた PasswordHasher を返す
+func NewPasswordHasher(c *config.AuthConfig) (PasswordHasher, error) {
+	switch c.PasswordHasher {
+	case "argon2id":
+		return NewArgon2idHasher(Argon2idParams{
+			Memory:  uint32(c.Argon2Memory),
+			Time:    uint32(c.Argon2Time),
+			Threads: uint8(c.Argon2Threads),
+		}), nil
+	case "bcrypt":
+		return NewBcryptHasher(c.BcryptCost), nil
+	}
+	return nil, fmt.Errorf("unknown password hasher %q", c.PasswordHasher)
+}
+
+// verifyPassword は encoded の先頭からアルゴリズムを判断して password を検証する
+// encoded が空の場合はパスワードを設定していないユーザーなので、どのパスワードとも一致しない
+func verifyPassword(password, encoded string) (bool, error) {
+	switch {
+	case encoded == "":
+		return false, nil
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		return verifyArgon2id(password, encoded)
+	case isBcryptHash(encoded):
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	return false, ErrUnknownPasswordHash
+}
+
+func isBcryptHash(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+// Argon2idParams は argon2id のパラメータ。Memory は KiB で表す
+type Argon2idParams struct {
+	Memory  uint32
+	Time    uint32
+	Threads uint8
+}
+
+type argon2idHasher struct {
+	params Argon2idParams
+}
+
+func NewArgon2idHasher(params Argon2idParams) *argon2idHasher {
+	return &argon2idHasher{params: params}
+}
+
+// Hash は $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key> の形式でハッシュを返す
+func (h *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2idSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.params.Time, h.params.Memory, h.params.Threads, argon2idKeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.params.Memory, h.params.Time, h.params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *argon2idHasher) Verify(password, encoded string) (bool, error) {
+	return verifyPassword(password, encoded)
+}
+
+func (h *argon2idHasher) NeedsRehash(encoded string) bool {
+	params, _, key, err := decodeArgon2id(encoded)
+	return err != nil || params != h.params || len(key) != argon2idKeyLength
+}
+
+func verifyArgon2id(password, encoded string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+	actual := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(actual, key) == 1, nil
+}
+
+func decodeArgon2id(encoded string) (Argon2idParams, []byte, []byte, error) {
+	var params Argon2idParams
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2id version %q", parts[2])
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id parameters %q", parts[3])
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errors.New("invalid argon2id key")
+	}
+	return params, salt, key, nil
+}
+
+type bcryptHasher struct {
+	cost int
+}
+
+func NewBcryptHasher(cost int) *bcryptHasher {
+	return &bcryptHasher{cost: cost}
+}
+
+func (h *bcryptHasher) Hash(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	return string(bytes), err
+}
+
+func (h *bcryptHasher) Verify(password, encoded string) (bool, error) {
+	return verifyPassword(password, encoded)
+}
+
+func (h *bcryptHasher) NeedsRehash(encoded string) bool {
+	if !isBcryptHash(encoded) {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != h.cost
+}
@@ -0,0 +1,119 @@
+package security_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"go-todo-app-clean-arch/pkg/config"
+	"go-todo-app-clean-arch/pkg/security"
+)
+
+// テストを速くするため、どちらも最小に近いパラメータにする
+var testArgon2idParams = security.Argon2idParams{Memory: 64, Time: 1, Threads: 1}
+
+func TestArgon2idHasher(t *testing.T) {
+	hasher := security.NewArgon2idHasher(testArgon2idParams)
+	encoded, err := hasher.Hash("correct horse")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=1,p=1$"))
+
+	ok, err := hasher.Verify("correct horse", encoded)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = hasher.Verify("wrong horse", encoded)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// ソルトが毎回変わる
+	again, _ := hasher.Hash("correct horse")
+	assert.NotEqual(t, encoded, again)
+	assert.False(t, hasher.NeedsRehash(encoded))
+}
+
+// 他の実装で作ったハッシュも検証できること（参照実装の src/test.c のテストベクタ）
+func TestArgon2idVerifyPHC(t *testing.T) {
+	hasher := security.NewArgon2idHasher(testArgon2idParams)
+	ok, err := hasher.Verify("password", "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestBcryptHasher(t *testing.T) {
+	hasher := security.NewBcryptHasher(bcrypt.MinCost)
+	encoded, err := hasher.Hash("correct horse")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$2a$04$"))
+
+	ok, err := hasher.Verify("correct horse", encoded)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = hasher.Verify("wrong horse", encoded)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.False(t, hasher.NeedsRehash(encoded))
+}
+
+func TestNeedsRehash(t *testing.T) {
+	argon2id := security.NewArgon2idHasher(testArgon2idParams)
+	bcryptHasher := security.NewBcryptHasher(bcrypt.MinCost)
+	argon2idHash, _ := argon2id.Hash("password")
+	strongerHash, _ := security.NewArgon2idHasher(security.Argon2idParams{Memory: 128, Time: 1, Threads: 1}).Hash("password")
+	bcryptHash, _ := bcryptHasher.Hash("password")
+	costlierHash, _ := security.NewBcryptHasher(bcrypt.MinCost + 1).Hash("password")
+
+	// アルゴリズムやパラメータが違うハッシュも検証はでき、作り直しが必要と判断する
+	for name, tt := range map[string]struct {
+		hasher  security.PasswordHasher
+		encoded string
+		rehash  bool
+	}{
+		"argon2id same params":      {hasher: argon2id, encoded: argon2idHash, rehash: false},
+		"argon2id different params": {hasher: argon2id, encoded: strongerHash, rehash: true},
+		"argon2id from bcrypt":      {hasher: argon2id, encoded: bcryptHash, rehash: true},
+		"bcrypt same cost":          {hasher: bcryptHasher, encoded: bcryptHash, rehash: false},
+		"bcrypt different cost":     {hasher: bcryptHasher, encoded: costlierHash, rehash: true},
+		"bcrypt from argon2id":      {hasher: bcryptHasher, encoded: argon2idHash, rehash: true},
+	} {
+		ok, err := tt.hasher.Verify("password", tt.encoded)
+		assert.NoError(t, err, name)
+		assert.True(t, ok, name)
+		assert.Equal(t, tt.rehash, tt.hasher.NeedsRehash(tt.encoded), name)
+	}
+}
+
+func TestVerifyMalformedHash(t *testing.T) {
+	hasher := security.NewArgon2idHasher(testArgon2idParams)
+	_, err := hasher.Verify("password", "password")
+	assert.ErrorIs(t, err, security.ErrUnknownPasswordHash)
+	_, err = hasher.Verify("password", "$argon2id$v=19$m=64,t=1$c29tZXNhbHQ$aGFzaA")
+	assert.Error(t, err)
+	assert.True(t, hasher.NeedsRehash("password"))
+
+	// パスワードを設定していないユーザー
+	ok, err := hasher.Verify("", "")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestNewPasswordHasher(t *testing.T) {
+	c := config.Default().Auth
+	hasher, err := security.NewPasswordHasher(&c)
+	require.NoError(t, err)
+	encoded, err := hasher.Hash("password")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=19456,t=2,p=1$"))
+
+	c.PasswordHasher = "bcrypt"
+	c.BcryptCost = bcrypt.MinCost
+	hasher, err = security.NewPasswordHasher(&c)
+	require.NoError(t, err)
+	assert.False(t, hasher.NeedsRehash("$2a$04$abcdefghijklmnopqrstuu5Xvd3nnpk0PKAZ8RAwXZgWhPj4/aRm."))
+
+	c.PasswordHasher = "md5"
+	_, err = security.NewPasswordHasher(&c)
+	assert.Error(t, err)
+}
@@ -0,0 +1,28 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// opaqueTokenBytes は推測されないトークンに必要な乱数の長さ（256 bit）
+const opaqueTokenBytes = 32
+
+// NewOpaqueToken はリフレッシュトークンなどに使う、意味を持たないランダムな文字列を返す
+// URL や Cookie にそのまま入れられるよう base64url（パディングなし）で表す
+func NewOpaqueToken() (string, error) {
+	b := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken は DB に保存するためのトークンの SHA-256（16 進数）を返す
+// トークン自体が十分に長い乱数なので、パスワードと違いソルトや遅いハッシュは要らない
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,115 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP（RFC 6238）の設定。認証アプリの多くが対応している既定値（SHA-1・6 桁・30 秒）に合わせる
+const (
+	totpSecretBytes = 20
+	totpDigits      = 6
+	totpPeriod      = 30 * time.Second
+	// totpSkew は時計のずれを許す前後のステップ数
+	totpSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewTOTPSecret は認証アプリに登録する秘密鍵を base32（パディングなし）で返す
+func NewTOTPSecret() (string, error) {
+	b := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPStep は t が属するステップ（Unix 時間を 30 秒で割った値）を返す
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / int64(totpPeriod/time.Second)
+}
+
+// TOTPCode は secret の step におけるコードを返す
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// RFC 4226 の dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1_000_000), nil
+}
+
+// ValidateTOTP は code が now の前後 totpSkew ステップのいずれかのコードと一致するかを確かめ、一致したステップを返す
+// 同じコードの使い回しを防ぐため、呼び出し側で使ったステップを記録する
+func ValidateTOTP(secret, code string, now time.Time) (int64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	current := TOTPStep(now)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		expected, err := TOTPCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// TOTPURI は認証アプリに読み込ませる otpauth:// URI を返す
+// https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+func TOTPURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(int(totpPeriod/time.Second)))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// recoveryCodeLength はリカバリーコードの文字数（base32 で 50 bit）
+const recoveryCodeLength = 10
+
+// NewRecoveryCode は認証アプリを使えないときのための使い捨てのコードを返す
+// 書き写しやすいよう、base32 の 10 文字を 5 文字ずつハイフンで区切る
+func NewRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeLength*5/8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := totpEncoding.EncodeToString(b)
+	return code[:5] + "-" + code[5:], nil
+}
+
+// NormalizeRecoveryCode は入力されたリカバリーコードから区切りを除き、大文字にそろえる
+// 保存するハッシュはこの形式から計算する
+func NormalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,76 @@
+package security_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/pkg/security"
+)
+
+// RFC 6238 Appendix B の SHA-1 のテストベクタ（下 6 桁）
+func TestTOTPCode(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	for unix, want := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		code, err := security.TOTPCode(secret, security.TOTPStep(time.Unix(unix, 0)))
+		assert.Nil(t, err)
+		assert.Equal(t, want, code, unix)
+	}
+
+	_, err := security.TOTPCode("not base32!", 1)
+	assert.NotNil(t, err)
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := security.NewTOTPSecret()
+	assert.Nil(t, err)
+	assert.Len(t, secret, 32)
+
+	now := time.Date(2024, 1, 1, 0, 0, 15, 0, time.UTC)
+	current := security.TOTPStep(now)
+	code, _ := security.TOTPCode(secret, current)
+	step, ok := security.ValidateTOTP(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, current, step)
+
+	// 前後 1 ステップまでは時計のずれとして受け付ける
+	previous, _ := security.TOTPCode(secret, current-1)
+	step, ok = security.ValidateTOTP(secret, previous, now)
+	assert.True(t, ok)
+	assert.Equal(t, current-1, step)
+
+	stale, _ := security.TOTPCode(secret, current-2)
+	_, ok = security.ValidateTOTP(secret, stale, now)
+	assert.False(t, ok)
+	_, ok = security.ValidateTOTP(secret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := security.TOTPURI("ToDo App", "user@example.com", "JBSWY3DPEHPK3PXP")
+	parsed, err := url.Parse(uri)
+	assert.Nil(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/ToDo App:user@example.com", parsed.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", parsed.Query().Get("secret"))
+	assert.Equal(t, "ToDo App", parsed.Query().Get("issuer"))
+}
+
+func TestRecoveryCode(t *testing.T) {
+	code, err := security.NewRecoveryCode()
+	assert.Nil(t, err)
+	assert.Regexp(t, `^[A-Z2-7]{5}-[A-Z2-7]{5}$`, code)
+	assert.Equal(t, "ABCDE23456", security.NormalizeRecoveryCode("abcde-23456"))
+	assert.Equal(t, "ABCDE23456", security.NormalizeRecoveryCode("ABCDE 23456"))
+}
@@ -0,0 +1,88 @@
+package tester
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"gorm.io/gorm"
+
+	"go-todo-app-clean-arch/infrastructure/database"
+	"go-todo-app-clean-arch/pkg"
+)
+
+// DBSuite は TEST_DB_DRIVER（sqlite・mysql・postgres、既定は sqlite）で選んだ DB でテストする
+// 同じテストをすべての DB で実行し、挙動が揃っていることを確認するために使う
+// mysql・postgres は testcontainers でコンテナを起動する
+type DBSuite struct {
+	suite.Suite
+	DB       *gorm.DB
+	Driver   string
+	teardown func() error
+}
+
+func (suite *DBSuite) SetupSuite() {
+	suite.Driver = pkg.GetEnvDefault("TEST_DB_DRIVER", "sqlite")
+	instance, err := database.InstanceFromDriver(suite.Driver)
+	suite.Require().Nil(err)
+
+	switch instance {
+	case database.InstanceSQLite:
+		name := fmt.Sprintf("%s.unittest.sqlite", suite.T().Name())
+		suite.teardown = func() error { return removeSQLite(name) }
+		suite.DB, err = openSQLite(name)
+	case database.InstanceMySQL:
+		suite.DB, err = suite.startContainer(startMySQLContainer)
+	case database.InstancePostgres:
+		suite.DB, err = suite.startContainer(startPostgresContainer)
+	}
+	suite.Require().Nil(err)
+
+	// サーバーと同じマイグレーションでスキーマを作る
+	suite.Require().Nil(migrateUp(suite.DB))
+}
+
+func (suite *DBSuite) TearDownSuite() {
+	if suite.teardown == nil {
+		return
+	}
+	suite.Assert().Nil(suite.teardown())
+}
+
+func (suite *DBSuite) startContainer(start func(context.Context) (testcontainers.Container, error)) (*gorm.DB, error) {
+	ctx := context.Background()
+	container, err := start(ctx)
+	if err != nil {
+		return nil, err
+	}
+	suite.teardown = func() error { return container.Terminate(ctx) }
+	return database.NewDatabaseSQLFactory(testDatabaseConfig(suite.Driver))
+}
+
+// testDatabaseConfig はテスト用コンテナの接続先を返す
+// ポートが使われている場合などは DB_* の環境変数で変えられる
+func testDatabaseConfig(driver string) *database.Config {
+	defaultPort := "3306"
+	if driver == "postgres" {
+		defaultPort = "5432"
+	}
+	return &database.Config{
+		Driver:   driver,
+		Host:     pkg.GetEnvDefault("DB_HOST", "localhost"),
+		Port:     pkg.GetEnvDefault("DB_PORT", defaultPort),
+		Database: pkg.GetEnvDefault("DB_NAME", "api_database"),
+		User:     pkg.GetEnvDefault("DB_USER", "app"),
+		Password: pkg.GetEnvDefault("DB_PASSWORD", "password"),
+		SSLMode:  "disable",
+	}
+}
+
+func migrateUp(db *gorm.DB) error {
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	_, err = migrator.Up()
+	return err
+}
@@ -3,7 +3,6 @@ package tester
 import (
 	"context"
 	"fmt"
-	"go-todo-app-clean-arch/entity"
 	"go-todo-app-clean-arch/infrastructure/database"
 	"go-todo-app-clean-arch/pkg"
 	"time"
@@ -21,10 +20,9 @@ type DBMySQLSuite struct {
 	DB             *gorm.DB
 }
 
-func (suite *DBMySQLSuite) SetupTestContainers() (err error) {
-	configs := database.NewConfigMySQL()
-	pkg.WaitForPort(configs.Database, configs.Port, 10*time.Second)
-	suite.ctx = context.Background()
+func startMySQLContainer(ctx context.Context) (testcontainers.Container, error) {
+	configs := testDatabaseConfig("mysql")
+	pkg.WaitForPort(configs.Host, configs.Port, 10*time.Second)
 	req := testcontainers.ContainerRequest{
 		Image: "mysql:8.2",
 		Env: map[string]string{
@@ -37,11 +35,15 @@ func (suite *DBMySQLSuite) SetupTestContainers() (err error) {
 		WaitingFor:   wait.ForLog("port: 3306  MySQL Community Server"),
 	}
 
-	suite.mySQLContainer, err = testcontainers.GenericContainer(suite.ctx, testcontainers.GenericContainerRequest{
+	return testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: req,
 		Started:          true,
 	})
+}
 
+func (suite *DBMySQLSuite) SetupTestContainers() (err error) {
+	suite.ctx = context.Background()
+	suite.mySQLContainer, err = startMySQLContainer(suite.ctx)
 	suite.Assert().Nil(err)
 	return nil
 }
@@ -50,13 +52,11 @@ func (suite *DBMySQLSuite) SetupSuite() {
 	err := suite.SetupTestContainers()
 	suite.Assert().Nil(err)
 
-	db, err := database.NewDatabaseSQLFactory(database.InstanceMySQL)
+	db, err := database.NewDatabaseSQLFactory(testDatabaseConfig("mysql"))
 	suite.Assert().Nil(err)
 	suite.DB = db
-	for _, model := range entity.NewDomains() {
-		err = suite.DB.AutoMigrate(model)
-		suite.Assert().Nil(err)
-	}
+	// サーバーと同じマイグレーションでスキーマを作る
+	suite.Assert().Nil(migrateUp(suite.DB))
 }
 
 func (suite *DBMySQLSuite) TearDownSuite() {
@@ -0,0 +1,225 @@
+package tester
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"go-todo-app-clean-arch/pkg/security"
+)
+
+// FakeOIDCAccount は FakeOIDCProvider でログインしたことにするアカウント
+type FakeOIDCAccount struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+// FakeOIDCProvider はテスト用の OpenID Provider。Discovery・認可・トークン・JWKS のエンドポイントを同じプロセスで提供する
+// 認可エンドポイントはログイン画面を出さず、SignIn で決めたアカウントとしてすぐにコールバックへリダイレクトする
+type FakeOIDCProvider struct {
+	Server       *httptest.Server
+	ClientID     string
+	ClientSecret string
+	// ModifyClaims は発行する ID トークンのクレームを書き換える。不正なトークンのテストに使う
+	ModifyClaims func(claims jwt.MapClaims)
+
+	mu      sync.Mutex
+	keys    *security.KeyRing
+	account FakeOIDCAccount
+	codes   map[string]fakeAuthorization
+}
+
+type fakeAuthorization struct {
+	account       FakeOIDCAccount
+	nonce         string
+	redirectURI   string
+	codeChallenge string
+}
+
+func NewFakeOIDCProvider(clientID, clientSecret string) *FakeOIDCProvider {
+	f := &FakeOIDCProvider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		codes:        map[string]fakeAuthorization{},
+		account:      FakeOIDCAccount{Subject: "fake-subject", Email: "fake@example.com", EmailVerified: true},
+	}
+	f.RotateKey()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", f.discovery)
+	mux.HandleFunc("/authorize", f.authorize)
+	mux.HandleFunc("/token", f.token)
+	mux.HandleFunc("/jwks", f.jwks)
+	f.Server = httptest.NewServer(mux)
+	return f
+}
+
+func (f *FakeOIDCProvider) Close() {
+	f.Server.Close()
+}
+
+func (f *FakeOIDCProvider) Issuer() string {
+	return f.Server.URL
+}
+
+// SignIn は次の認可でログインしたことにするアカウントを決める
+func (f *FakeOIDCProvider) SignIn(account FakeOIDCAccount) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.account = account
+}
+
+// RotateKey は ID トークンに署名する鍵を新しいものに替え、JWKS からは古い鍵を外す
+func (f *FakeOIDCProvider) RotateKey() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	keys, err := security.NewSigningKeyRing(key)
+	if err != nil {
+		panic(err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.keys = keys
+}
+
+// SignIDToken は今の鍵で claims に署名する
+func (f *FakeOIDCProvider) SignIDToken(claims jwt.MapClaims) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	token, err := f.keys.Sign(claims)
+	if err != nil {
+		panic(err)
+	}
+	return token
+}
+
+// Authorize はブラウザの代わりに認可エンドポイントを開き、リダイレクト先（コールバックの URL）を返す
+func (f *FakeOIDCProvider) Authorize(authorizationURL string) (*url.URL, error) {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	res, err := client.Get(authorizationURL)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return res.Location()
+}
+
+func (f *FakeOIDCProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                f.Issuer(),
+		"authorization_endpoint":                f.Issuer() + "/authorize",
+		"token_endpoint":                        f.Issuer() + "/token",
+		"jwks_uri":                              f.Issuer() + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (f *FakeOIDCProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || query.Get("response_type") != "code" || query.Get("client_id") != f.ClientID ||
+		query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	code, err := security.NewOpaqueToken()
+	if err != nil {
+		panic(err)
+	}
+	f.mu.Lock()
+	f.codes[code] = fakeAuthorization{
+		account:       f.account,
+		nonce:         query.Get("nonce"),
+		redirectURI:   redirectURI.String(),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	f.mu.Unlock()
+
+	callback := redirectURI.Query()
+	callback.Set("code", code)
+	callback.Set("state", query.Get("state"))
+	redirectURI.RawQuery = callback.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (f *FakeOIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.Method != http.MethodPost {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if f.ClientSecret != "" {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != f.ClientID || secret != f.ClientSecret {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+			return
+		}
+	}
+
+	// 認可コードは 1 回しか使えない
+	code := r.PostForm.Get("code")
+	f.mu.Lock()
+	authorization, ok := f.codes[code]
+	delete(f.codes, code)
+	f.mu.Unlock()
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" ||
+		r.PostForm.Get("redirect_uri") != authorization.redirectURI ||
+		codeChallenge(r.PostForm.Get("code_verifier")) != authorization.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            f.Issuer(),
+		"sub":            authorization.account.Subject,
+		"aud":            f.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          authorization.nonce,
+		"email":          authorization.account.Email,
+		"email_verified": authorization.account.EmailVerified,
+	}
+	if f.ModifyClaims != nil {
+		f.ModifyClaims(claims)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": "fake-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     f.SignIDToken(claims),
+	})
+}
+
+func (f *FakeOIDCProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	writeJSON(w, http.StatusOK, f.keys.JWKS())
+}
+
+// codeChallenge は PKCE の S256 の code_challenge。pkg/oidc のテストから使うため、pkg/oidc には依存しない
+func codeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,33 @@
+package tester
+
+import (
+	"context"
+	"fmt"
+	"go-todo-app-clean-arch/pkg"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// startPostgresContainer は DBSuite が TEST_DB_DRIVER=postgres のときに使う PostgreSQL のコンテナを起動する
+func startPostgresContainer(ctx context.Context) (testcontainers.Container, error) {
+	configs := testDatabaseConfig("postgres")
+	pkg.WaitForPort(configs.Host, configs.Port, 10*time.Second)
+	req := testcontainers.ContainerRequest{
+		Image: "postgres:16",
+		Env: map[string]string{
+			"POSTGRES_DB":       configs.Database,
+			"POSTGRES_USER":     configs.User,
+			"POSTGRES_PASSWORD": configs.Password,
+		},
+		ExposedPorts: []string{fmt.Sprintf("%s:5432/tcp", configs.Port)},
+		// 初期化用の一時サーバーでも同じログが出るため 2 回目を待つ
+		WaitingFor: wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+	}
+
+	return testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+}
@@ -2,7 +2,6 @@ package tester
 
 import (
 	"fmt"
-	"go-todo-app-clean-arch/entity"
 	"go-todo-app-clean-arch/infrastructure/database"
 	"os"
 
@@ -16,21 +15,25 @@ type DBSQLiteSuite struct {
 	DBName string
 }
 
+func openSQLite(name string) (*gorm.DB, error) {
+	return database.NewDatabaseSQLFactory(&database.Config{Driver: "sqlite", Database: name})
+}
+
+func removeSQLite(name string) error {
+	return os.Remove(name)
+}
+
 func (suite *DBSQLiteSuite) SetupSuite() {
 	suite.DBName = fmt.Sprintf("%s.unittest.sqlite", suite.T().Name())
-	os.Setenv("DB_NAME", suite.DBName)
-	db, err := database.NewDatabaseSQLFactory(database.InstanceSQLite)
+	db, err := openSQLite(suite.DBName)
 	suite.Assert().Nil(err)
 	suite.DB = db
 
-	for _, model := range entity.NewDomains() {
-		err := suite.DB.AutoMigrate(model)
-		suite.Assert().Nil(err)
-	}
+	// サーバーと同じマイグレーションでスキーマを作る
+	suite.Assert().Nil(migrateUp(suite.DB))
 }
 
 func (suite *DBSQLiteSuite) TearDownSuite() {
-	err := os.Remove(suite.DBName)
+	err := removeSQLite(suite.DBName)
 	suite.Assert().Nil(err)
-	os.Unsetenv(suite.DBName)
 }
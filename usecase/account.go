@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/mailer"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+var (
+	ErrIncorrectCurrentPassword = apperror.NewValidation("incorrect password",
+		apperror.BodyField("/current_password", "the password is incorrect"))
+	ErrEmailUnchanged = apperror.NewValidation("email address is unchanged",
+		apperror.BodyField("/email", "must be different from the current email address"))
+	ErrEmailAlreadyInUse       = apperror.NewConflict("email address is already in use")
+	ErrInvalidEmailChangeToken = apperror.NewValidation("invalid or expired email change token",
+		apperror.ParamField("token", "the confirmation link is invalid or has expired"))
+)
+
+// EmailChangeConfig はメールアドレス変更の確認メールの設定
+// URL にはトークンをクエリ文字列 token として付けて、新しいアドレスに送る
+type EmailChangeConfig struct {
+	URL string
+	TTL time.Duration
+	// ResendInterval の間は同じユーザーからの次の変更を受け付けない
+	ResendInterval time.Duration
+}
+
+// ChangePassword は現在のパスワードを確かめてからパスワードを変える
+// パスワードを知った第三者が使い続けられないよう、リクエストしたセッション以外とパーソナルアクセストークンをすべて失効させる
+func (u *userUseCase) ChangePassword(ctx context.Context, currentPassword, newPassword string) error {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return err
+	}
+	user, err := u.userRepository.GetCurrentUser(ctx, principal.UserID)
+	if err != nil {
+		return err
+	}
+	// OpenID Connect だけで登録したユーザーはパスワードがないため、常に一致しない
+	if err := verifyCurrentPassword(ctx, u.loginThrottle, u.passwordHasher, user, currentPassword, ErrIncorrectCurrentPassword); err != nil {
+		return err
+	}
+	if err := u.passwordPolicy.Validate(newPassword, user.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := u.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := u.userRepository.ChangePassword(ctx, user.ID, user.Password, hashedPassword); err != nil {
+		return err
+	}
+	now := u.clock.Now()
+	if err := revokeSessions(ctx, u.sessionRepository, u.refreshTokenRepository, user.ID, principal.SessionID, now); err != nil {
+		return err
+	}
+	return u.personalAccessTokenRepository.RevokeAll(ctx, user.ID, now)
+}
+
+// RequestEmailChange は newEmail に確認メールを送る。リンクが開かれるまでメールアドレスは変えない
+// 他のアカウントが使っているかどうかは確認のときに確かめ、ここではアドレスの使用状況によらず同じ結果を返す
+func (u *userUseCase) RequestEmailChange(ctx context.Context, newEmail string) error {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return err
+	}
+	user, err := u.userRepository.GetCurrentUser(ctx, principal.UserID)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		return ErrEmailUnchanged
+	}
+	// 任意のアドレスに確認メールを送り続けられないよう、ユーザーごとに間隔を空ける
+	now := u.clock.Now()
+	latest, err := u.userRepository.FindLatestEmailChangeToken(ctx, user.ID)
+	if err != nil && !apperror.IsNotFound(err) {
+		return err
+	}
+	if latest != nil {
+		if wait := latest.CreatedAt.Add(u.emailChangeConfig.ResendInterval).Sub(now); wait > 0 {
+			return apperror.NewTooManyRequests("an email change was requested recently, try again later", wait)
+		}
+	}
+
+	token, err := security.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+	record := &entity.EmailChangeToken{
+		SingleUseToken: entity.SingleUseToken{
+			UserID:    user.ID,
+			TokenHash: security.HashToken(token),
+			ExpiresAt: now.Add(u.emailChangeConfig.TTL),
+			CreatedAt: now,
+		},
+		NewEmail: newEmail,
+	}
+	if err := u.userRepository.CreateEmailChangeToken(ctx, record); err != nil {
+		return err
+	}
+
+	u.send(&mailer.Message{
+		To:      newEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf("Someone asked to change the email address of an account to this address.\n\n"+
+			"Open the link below within %s to confirm the change:\n%s\n\n"+
+			"If you did not request this, you can ignore this email. The email address will not change.\n",
+			humanizeDuration(u.emailChangeConfig.TTL), linkWithToken(u.emailChangeConfig.URL, token)),
+	})
+	return nil
+}
+
+// ConfirmEmailChange は確認メールのトークンを使用済みにしてメールアドレスを変え、元のアドレスに知らせる
+func (u *userUseCase) ConfirmEmailChange(ctx context.Context, token string) error {
+	record, err := u.userRepository.FindEmailChangeToken(ctx, security.HashToken(token))
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return ErrInvalidEmailChangeToken
+		}
+		return err
+	}
+	now := u.clock.Now()
+	if !record.IsUsable(now) {
+		return ErrInvalidEmailChangeToken
+	}
+	user, err := u.userRepository.GetCurrentUser(ctx, record.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.userRepository.ChangeEmail(ctx, record, now); err != nil {
+		switch {
+		case errors.Is(err, gateway.ErrEmailChangeTokenAlreadyUsed):
+			return ErrInvalidEmailChangeToken
+		case apperror.IsConflict(err):
+			// 確認メールを送った後に、他のユーザーがそのアドレスで登録した
+			return ErrEmailAlreadyInUse
+		}
+		return err
+	}
+
+	u.send(&mailer.Message{
+		To:      user.Email,
+		Subject: "Your email address has been changed",
+		Body: fmt.Sprintf("The email address of your account has been changed to %s.\n\n"+
+			"If you did not make this change, sign in, change your password and revoke your other sessions.\n",
+			record.NewEmail),
+	})
+	return nil
+}
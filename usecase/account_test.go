@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/mailer"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+var testEmailChangeConfig = EmailChangeConfig{URL: "https://todo.example.com/email/confirm?lang=ja", TTL: 24 * time.Hour, ResendInterval: time.Minute}
+
+type AccountUseCaseSuite struct {
+	suite.Suite
+	userUseCase                       *userUseCase
+	mockUserRepository                *mockUserRepository
+	mockSessionRepository             *mockSessionRepository
+	mockRefreshTokenRepository        *mockRefreshTokenRepository
+	mockPersonalAccessTokenRepository *mockPersonalAccessTokenRepository
+	mockLoginThrottle                 *mockLoginThrottle
+	sent                              []*mailer.Message
+	now                               time.Time
+}
+
+func TestAccountUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(AccountUseCaseSuite))
+}
+
+func (suite *AccountUseCaseSuite) SetupTest() {
+	suite.mockUserRepository = NewMockUserRepository()
+	suite.mockSessionRepository = NewMockSessionRepository()
+	suite.mockRefreshTokenRepository = NewMockRefreshTokenRepository()
+	suite.mockPersonalAccessTokenRepository = NewMockPersonalAccessTokenRepository()
+	suite.mockLoginThrottle = newPassingLoginThrottle()
+	suite.userUseCase = NewUserUseCase(suite.mockUserRepository, suite.mockRefreshTokenRepository, suite.mockSessionRepository, suite.mockPersonalAccessTokenRepository, NewMockLoginChallengeRepository(),
+		NewMockEmailVerificationUseCase(), NewMockTwoFactorUseCase(), suite.mockLoginThrottle, testPasswordHasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
+	suite.now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.userUseCase.clock = tester.NewMockClock(suite.now)
+	// 送信を待たずに内容を確認できるよう、送るメールを記録する
+	suite.sent = nil
+	suite.userUseCase.send = func(msg *mailer.Message) { suite.sent = append(suite.sent, msg) }
+}
+
+func (suite *AccountUseCaseSuite) TestChangePassword() {
+	hashedPassword, _ := testPasswordHasher.Hash("current-password")
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com", Password: hashedPassword}, nil)
+	suite.mockUserRepository.On("ChangePassword", 7, hashedPassword, mock.AnythingOfType("string")).Return(nil)
+	suite.mockSessionRepository.On("ListActive", 7, suite.now).Return([]*entity.Session{{ID: "current"}, {ID: "phone"}}, nil)
+	suite.mockSessionRepository.On("Revoke", 7, mock.Anything, suite.now).Return(nil)
+	suite.mockRefreshTokenRepository.On("RevokeFamily", mock.Anything, suite.now).Return(nil)
+	suite.mockPersonalAccessTokenRepository.On("RevokeAll", 7, suite.now).Return(nil)
+
+	suite.Assert().Nil(suite.userUseCase.ChangePassword(sessionContext(7, "current"), "current-password", "new-password"))
+	hashed := suite.mockUserRepository.Calls[1].Arguments.String(2)
+	ok, _ := testPasswordHasher.Verify("new-password", hashed)
+	suite.Assert().True(ok)
+
+	// リクエストしたセッション以外を失効させる
+	suite.mockSessionRepository.AssertCalled(suite.T(), "Revoke", 7, "phone", suite.now)
+	suite.mockRefreshTokenRepository.AssertCalled(suite.T(), "RevokeFamily", "phone", suite.now)
+	suite.mockSessionRepository.AssertNotCalled(suite.T(), "Revoke", 7, "current", suite.now)
+	// パスワードを知った第三者が作ったかもしれないトークンも使えなくする
+	suite.mockPersonalAccessTokenRepository.AssertCalled(suite.T(), "RevokeAll", 7, suite.now)
+}
+
+func (suite *AccountUseCaseSuite) TestChangePasswordRejected() {
+	hashedPassword, _ := testPasswordHasher.Hash("current-password")
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com", Password: hashedPassword}, nil)
+
+	err := suite.userUseCase.ChangePassword(sessionContext(7, "current"), "wrong-password", "new-password")
+	suite.Assert().ErrorIs(err, ErrIncorrectCurrentPassword)
+	// 間違えたらログインの失敗と同じくアカウントごとに数える
+	suite.mockLoginThrottle.AssertCalled(suite.T(), "RecordFailure", "user@example.com", "")
+
+	// 新しいパスワードもポリシーを満たす必要がある
+	err = suite.userUseCase.ChangePassword(sessionContext(7, "current"), "current-password", "short")
+	suite.Assert().True(apperror.IsValidation(err))
+
+	// パーソナルアクセストークンではパスワードを変えられない
+	err = suite.userUseCase.ChangePassword(accessTokenContext(7, entity.AccessTokenScopes...), "current-password", "new-password")
+	suite.Assert().ErrorIs(err, ErrSessionRequired)
+	suite.mockUserRepository.AssertNotCalled(suite.T(), "ChangePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AccountUseCaseSuite) TestChangePasswordThrottled() {
+	hashedPassword, _ := testPasswordHasher.Hash("current-password")
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com", Password: hashedPassword}, nil)
+	suite.mockLoginThrottle = NewMockLoginThrottle()
+	suite.mockLoginThrottle.On("Check", "user@example.com", "").Return(apperror.NewTooManyRequests("too many failed login attempts", time.Minute))
+	suite.userUseCase.loginThrottle = suite.mockLoginThrottle
+
+	// 盗まれたセッションから総当たりされないよう、失敗が続いている間は確かめずに断る
+	err := suite.userUseCase.ChangePassword(sessionContext(7, "current"), "current-password", "new-password")
+	suite.Assert().True(apperror.IsTooManyRequests(err))
+	suite.mockUserRepository.AssertNotCalled(suite.T(), "ChangePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AccountUseCaseSuite) TestRequestEmailChange() {
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com"}, nil)
+	suite.mockUserRepository.On("FindLatestEmailChangeToken", 7).Return(nil, apperror.NewNotFound("email change token not found"))
+	suite.mockUserRepository.On("CreateEmailChangeToken", mock.AnythingOfType("*entity.EmailChangeToken")).Return(nil)
+
+	suite.Assert().Nil(suite.userUseCase.RequestEmailChange(sessionContext(7, "current"), "new@example.com"))
+	// 確認メールは新しいアドレスに送る
+	suite.Require().Len(suite.sent, 1)
+	suite.Assert().Equal("new@example.com", suite.sent[0].To)
+	suite.Assert().Contains(suite.sent[0].Body, "within 24 hours")
+
+	link := regexp.MustCompile(`https://\S+`).FindString(suite.sent[0].Body)
+	parsed, err := url.Parse(link)
+	suite.Require().Nil(err)
+	suite.Assert().Equal("ja", parsed.Query().Get("lang"))
+	token := parsed.Query().Get("token")
+	suite.Assert().NotEmpty(token)
+
+	saved := suite.mockUserRepository.Calls[2].Arguments.Get(0).(*entity.EmailChangeToken)
+	suite.Assert().Equal(7, saved.UserID)
+	suite.Assert().Equal("new@example.com", saved.NewEmail)
+	suite.Assert().Equal(security.HashToken(token), saved.TokenHash)
+	suite.Assert().Equal(suite.now.Add(24*time.Hour), saved.ExpiresAt)
+}
+
+func (suite *AccountUseCaseSuite) TestRequestEmailChangeTakenAddress() {
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com"}, nil)
+	suite.mockUserRepository.On("FindLatestEmailChangeToken", 7).Return(nil, apperror.NewNotFound("email change token not found"))
+	suite.mockUserRepository.On("CreateEmailChangeToken", mock.AnythingOfType("*entity.EmailChangeToken")).Return(nil)
+
+	// 他のアカウントのアドレスでも、登録されているかどうかがわからないよう同じように送る
+	suite.Assert().Nil(suite.userUseCase.RequestEmailChange(sessionContext(7, "current"), "taken@example.com"))
+	suite.Require().Len(suite.sent, 1)
+	suite.Assert().Equal("taken@example.com", suite.sent[0].To)
+	suite.mockUserRepository.AssertNotCalled(suite.T(), "FindByEmail", mock.Anything)
+}
+
+func (suite *AccountUseCaseSuite) TestRequestEmailChangeTooSoon() {
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com"}, nil)
+	suite.mockUserRepository.On("FindLatestEmailChangeToken", 7).Return(&entity.EmailChangeToken{SingleUseToken: entity.SingleUseToken{ID: 1, UserID: 7, CreatedAt: suite.now.Add(-20 * time.Second)}, NewEmail: "other@example.com"}, nil)
+
+	err := suite.userUseCase.RequestEmailChange(sessionContext(7, "current"), "new@example.com")
+	suite.Require().True(apperror.IsTooManyRequests(err))
+	suite.Assert().Equal(40*time.Second, apperror.RetryAfterOf(err))
+	suite.Assert().Empty(suite.sent)
+	suite.mockUserRepository.AssertNotCalled(suite.T(), "CreateEmailChangeToken", mock.Anything)
+}
+
+func (suite *AccountUseCaseSuite) TestRequestEmailChangeRejected() {
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com"}, nil)
+
+	err := suite.userUseCase.RequestEmailChange(sessionContext(7, "current"), "User@Example.com")
+	suite.Assert().ErrorIs(err, ErrEmailUnchanged)
+
+	err = suite.userUseCase.RequestEmailChange(accessTokenContext(7, entity.AccessTokenScopes...), "new@example.com")
+	suite.Assert().ErrorIs(err, ErrSessionRequired)
+
+	suite.Assert().Empty(suite.sent)
+	suite.mockUserRepository.AssertNotCalled(suite.T(), "CreateEmailChangeToken", mock.Anything)
+}
+
+func (suite *AccountUseCaseSuite) TestConfirmEmailChange() {
+	record := &entity.EmailChangeToken{SingleUseToken: entity.SingleUseToken{ID: 1, UserID: 7, ExpiresAt: suite.now.Add(time.Minute)}, NewEmail: "new@example.com"}
+	suite.mockUserRepository.On("FindEmailChangeToken", security.HashToken("token")).Return(record, nil)
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com"}, nil)
+	suite.mockUserRepository.On("ChangeEmail", record, suite.now).Return(nil)
+
+	suite.Assert().Nil(suite.userUseCase.ConfirmEmailChange(context.Background(), "token"))
+	// 乗っ取りに気づけるよう、元のアドレスに知らせる
+	suite.Require().Len(suite.sent, 1)
+	suite.Assert().Equal("user@example.com", suite.sent[0].To)
+	suite.Assert().Contains(suite.sent[0].Body, "new@example.com")
+}
+
+func (suite *AccountUseCaseSuite) TestConfirmEmailChangeInvalidToken() {
+	usedAt := suite.now.Add(-time.Minute)
+	raced := &entity.EmailChangeToken{SingleUseToken: entity.SingleUseToken{ID: 3, UserID: 7, ExpiresAt: suite.now.Add(time.Minute)}, NewEmail: "new@example.com"}
+	taken := &entity.EmailChangeToken{SingleUseToken: entity.SingleUseToken{ID: 4, UserID: 7, ExpiresAt: suite.now.Add(time.Minute)}, NewEmail: "taken@example.com"}
+	suite.mockUserRepository.On("FindEmailChangeToken", security.HashToken("unknown")).Return(nil, apperror.NewNotFound("email change token not found"))
+	suite.mockUserRepository.On("FindEmailChangeToken", security.HashToken("expired")).Return(&entity.EmailChangeToken{SingleUseToken: entity.SingleUseToken{ID: 1, ExpiresAt: suite.now}}, nil)
+	suite.mockUserRepository.On("FindEmailChangeToken", security.HashToken("used")).Return(&entity.EmailChangeToken{SingleUseToken: entity.SingleUseToken{ID: 2, ExpiresAt: suite.now.Add(time.Minute), UsedAt: &usedAt}}, nil)
+	// 同時に使われて、先に別のリクエストがメールアドレスを変えた場合
+	suite.mockUserRepository.On("FindEmailChangeToken", security.HashToken("raced")).Return(raced, nil)
+	suite.mockUserRepository.On("ChangeEmail", raced, suite.now).Return(gateway.ErrEmailChangeTokenAlreadyUsed)
+	// 確認メールを送った後に、他のユーザーがそのアドレスで登録した場合
+	suite.mockUserRepository.On("FindEmailChangeToken", security.HashToken("taken")).Return(taken, nil)
+	suite.mockUserRepository.On("ChangeEmail", taken, suite.now).Return(apperror.NewConflict("user already exists"))
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com"}, nil)
+
+	for _, token := range []string{"unknown", "expired", "used", "raced"} {
+		err := suite.userUseCase.ConfirmEmailChange(context.Background(), token)
+		suite.Assert().ErrorIs(err, ErrInvalidEmailChangeToken, token)
+	}
+	err := suite.userUseCase.ConfirmEmailChange(context.Background(), "taken")
+	suite.Assert().ErrorIs(err, ErrEmailAlreadyInUse)
+	suite.Assert().Empty(suite.sent)
+}
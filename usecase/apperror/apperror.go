@@ -0,0 +1,162 @@
+// Package apperror はユースケース層で扱うドメインエラーを定義する
+// gateway は DB のエラーをここの型に変換し、コントローラはエラーの種類から HTTP ステータスを決める
+package apperror
+
+import (
+	"errors"
+	"time"
+)
+
+type Kind int
+
+const (
+	KindUnknown Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindTooManyRequests
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not_found"
+	case KindConflict:
+		return "conflict"
+	case KindValidation:
+		return "validation"
+	case KindUnauthorized:
+		return "unauthorized"
+	case KindForbidden:
+		return "forbidden"
+	case KindTooManyRequests:
+		return "too_many_requests"
+	}
+	return "unknown"
+}
+
+// FieldError は入力値のどこが不正かを表す
+// リクエストボディの項目は Pointer（JSON Pointer）、クエリ・パスパラメータは Parameter で示す
+type FieldError struct {
+	Pointer   string
+	Parameter string
+	Detail    string
+}
+
+func BodyField(pointer, detail string) FieldError {
+	return FieldError{Pointer: pointer, Detail: detail}
+}
+
+func ParamField(parameter, detail string) FieldError {
+	return FieldError{Parameter: parameter, Detail: detail}
+}
+
+// Error の Message はクライアントにそのまま返してよい文言にする
+// DB ドライバのエラーなど内部の情報は Err に保持し、ログにだけ出す
+type Error struct {
+	Kind    Kind
+	Message string
+	Fields  []FieldError
+	Err     error
+	// RetryAfter は KindTooManyRequests で、次に試せるようになるまでの時間
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+func Wrap(kind Kind, message string, err error) *Error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+func NewNotFound(message string) *Error {
+	return New(KindNotFound, message)
+}
+
+func NewConflict(message string) *Error {
+	return New(KindConflict, message)
+}
+
+func NewValidation(message string, fields ...FieldError) *Error {
+	return &Error{Kind: KindValidation, Message: message, Fields: fields}
+}
+
+func NewUnauthorized(message string) *Error {
+	return New(KindUnauthorized, message)
+}
+
+func NewForbidden(message string) *Error {
+	return New(KindForbidden, message)
+}
+
+func NewTooManyRequests(message string, retryAfter time.Duration) *Error {
+	return &Error{Kind: KindTooManyRequests, Message: message, RetryAfter: retryAfter}
+}
+
+// KindOf は err の連鎖の中で最初に見つかった Error の種類を返す
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return KindUnknown
+}
+
+// RetryAfterOf は err の連鎖の中で最初に見つかった Error の RetryAfter を返す
+func RetryAfterOf(err error) time.Duration {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.RetryAfter
+	}
+	return 0
+}
+
+// FieldsOf は err の連鎖の中にある Error の FieldError をすべて集める
+func FieldsOf(err error) []FieldError {
+	var fields []FieldError
+	for err != nil {
+		if appErr, ok := err.(*Error); ok {
+			fields = append(fields, appErr.Fields...)
+		}
+		err = errors.Unwrap(err)
+	}
+	return fields
+}
+
+func IsNotFound(err error) bool {
+	return KindOf(err) == KindNotFound
+}
+
+func IsConflict(err error) bool {
+	return KindOf(err) == KindConflict
+}
+
+func IsValidation(err error) bool {
+	return KindOf(err) == KindValidation
+}
+
+func IsUnauthorized(err error) bool {
+	return KindOf(err) == KindUnauthorized
+}
+
+func IsForbidden(err error) bool {
+	return KindOf(err) == KindForbidden
+}
+
+func IsTooManyRequests(err error) bool {
+	return KindOf(err) == KindTooManyRequests
+}
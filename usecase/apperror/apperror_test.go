@@ -0,0 +1,59 @@
+package apperror_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+func TestKindOf(t *testing.T) {
+	cause := errors.New("duplicate entry")
+	err := fmt.Errorf("signup: %w", apperror.Wrap(apperror.KindConflict, "email already registered", cause))
+
+	assert.Equal(t, apperror.KindConflict, apperror.KindOf(err))
+	assert.True(t, apperror.IsConflict(err))
+	assert.False(t, apperror.IsNotFound(err))
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "signup: email already registered: duplicate entry", err.Error())
+
+	assert.Equal(t, apperror.KindUnknown, apperror.KindOf(cause))
+	assert.Equal(t, apperror.KindUnknown, apperror.KindOf(nil))
+}
+
+func TestSentinelIdentity(t *testing.T) {
+	sentinel := apperror.NewValidation("invalid task status")
+	err := apperror.Wrap(apperror.KindValidation, "unknown status \"x\"", sentinel)
+
+	assert.ErrorIs(t, err, sentinel)
+	assert.True(t, apperror.IsValidation(err))
+	assert.Equal(t, "validation", apperror.KindValidation.String())
+}
+
+func TestFieldsOf(t *testing.T) {
+	sentinel := apperror.NewValidation("invalid task query")
+	err := &apperror.Error{
+		Kind:    apperror.KindValidation,
+		Message: "limit must be between 1 and 100",
+		Fields:  []apperror.FieldError{apperror.ParamField("limit", "must be between 1 and 100")},
+		Err:     sentinel,
+	}
+	status := apperror.NewValidation("invalid task status", apperror.BodyField("/status", "unknown status"))
+
+	assert.Equal(t, []apperror.FieldError{{Parameter: "limit", Detail: "must be between 1 and 100"}}, apperror.FieldsOf(err))
+	assert.Equal(t, []apperror.FieldError{{Pointer: "/status", Detail: "unknown status"}}, apperror.FieldsOf(fmt.Errorf("create: %w", status)))
+	assert.Nil(t, apperror.FieldsOf(errors.New("boom")))
+}
+
+func TestRetryAfterOf(t *testing.T) {
+	err := fmt.Errorf("login: %w", apperror.NewTooManyRequests("too many failed login attempts", 30*time.Second))
+
+	assert.True(t, apperror.IsTooManyRequests(err))
+	assert.Equal(t, "too_many_requests", apperror.KindOf(err).String())
+	assert.Equal(t, 30*time.Second, apperror.RetryAfterOf(err))
+	assert.Equal(t, time.Duration(0), apperror.RetryAfterOf(errors.New("boom")))
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg"
+	"go-todo-app-clean-arch/pkg/mailer"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// メールアドレスが未確認のユーザーの扱い
+const (
+	// UnverifiedPolicyLimit はログインを許し、作れるタスクの数だけを制限する
+	UnverifiedPolicyLimit = "limit"
+	// UnverifiedPolicyBlock は確認が済むまでログインさせない
+	UnverifiedPolicyBlock = "block"
+)
+
+var (
+	ErrInvalidEmailVerificationToken = apperror.NewValidation("invalid or expired email verification token",
+		apperror.ParamField("token", "the verification link is invalid or has expired"))
+	ErrEmailNotVerified = apperror.NewForbidden("email address is not verified")
+)
+
+// EmailVerificationConfig は確認メールの設定
+// URL にはトークンをクエリ文字列 token として付けて送る
+type EmailVerificationConfig struct {
+	URL string
+	TTL time.Duration
+	// ResendInterval の間は確認メールを再送しない
+	ResendInterval time.Duration
+}
+
+// EmailVerificationPolicy は未確認のユーザーに許す操作
+type EmailVerificationPolicy struct {
+	Mode string
+	// TaskLimit は Mode が UnverifiedPolicyLimit のときに作れるタスクの数
+	TaskLimit int
+}
+
+type EmailVerificationUseCase interface {
+	SendVerification(ctx context.Context, user *entity.User) error
+	Verify(ctx context.Context, token string) error
+	Resend(ctx context.Context, email string) error
+}
+
+type emailVerificationUseCase struct {
+	userRepository                   gateway.UserRepository
+	emailVerificationTokenRepository gateway.EmailVerificationTokenRepository
+	config                           EmailVerificationConfig
+	clock                            pkg.Clock
+	// send はメールを送る。テストでは送ったメールを記録するように差し替える
+	send func(msg *mailer.Message)
+}
+
+func NewEmailVerificationUseCase(
+	userRepository gateway.UserRepository,
+	emailVerificationTokenRepository gateway.EmailVerificationTokenRepository,
+	m mailer.Mailer,
+	config EmailVerificationConfig,
+) *emailVerificationUseCase {
+	return &emailVerificationUseCase{
+		userRepository:                   userRepository,
+		emailVerificationTokenRepository: emailVerificationTokenRepository,
+		config:                           config,
+		clock:                            pkg.NewClock(),
+		send:                             sendAsync(m),
+	}
+}
+
+// SendVerification は user に確認メールを送る
+func (u *emailVerificationUseCase) SendVerification(ctx context.Context, user *entity.User) error {
+	token, err := security.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+	now := u.clock.Now()
+	record := &entity.EmailVerificationToken{
+		SingleUseToken: entity.SingleUseToken{
+			UserID:    user.ID,
+			TokenHash: security.HashToken(token),
+			ExpiresAt: now.Add(u.config.TTL),
+			CreatedAt: now,
+		},
+	}
+	if err := u.emailVerificationTokenRepository.Create(ctx, record); err != nil {
+		return err
+	}
+
+	u.send(&mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Thank you for signing up.\n\n"+
+			"Open the link below within %s to verify your email address:\n%s\n\n"+
+			"If you did not sign up, you can ignore this email.\n",
+			humanizeDuration(u.config.TTL), linkWithToken(u.config.URL, token)),
+	})
+	return nil
+}
+
+// Verify は確認トークンを使用済みにし、メールアドレスを確認済みにする
+func (u *emailVerificationUseCase) Verify(ctx context.Context, token string) error {
+	record, err := u.emailVerificationTokenRepository.FindByHash(ctx, security.HashToken(token))
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return ErrInvalidEmailVerificationToken
+		}
+		return err
+	}
+	now := u.clock.Now()
+	if !record.IsUsable(now) {
+		return ErrInvalidEmailVerificationToken
+	}
+
+	if err := u.emailVerificationTokenRepository.Verify(ctx, record, now); err != nil {
+		if errors.Is(err, gateway.ErrEmailVerificationTokenAlreadyUsed) {
+			return ErrInvalidEmailVerificationToken
+		}
+		return err
+	}
+	return nil
+}
+
+// Resend は未確認のユーザーに確認メールを送り直す
+// アカウントの有無が分からないよう、登録されていない・確認済み・再送の間隔内の場合も何もせずに成功を返す
+func (u *emailVerificationUseCase) Resend(ctx context.Context, email string) error {
+	user, err := u.userRepository.FindByEmail(ctx, email)
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	latest, err := u.emailVerificationTokenRepository.FindLatest(ctx, user.ID)
+	if err != nil && !apperror.IsNotFound(err) {
+		return err
+	}
+	if latest != nil && u.clock.Now().Sub(latest.CreatedAt) < u.config.ResendInterval {
+		return nil
+	}
+	return u.SendVerification(ctx, user)
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/mailer"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type mockEmailVerificationTokenRepository struct {
+	mock.Mock
+}
+
+func NewMockEmailVerificationTokenRepository() *mockEmailVerificationTokenRepository {
+	return new(mockEmailVerificationTokenRepository)
+}
+
+func (m *mockEmailVerificationTokenRepository) Create(ctx context.Context, token *entity.EmailVerificationToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *mockEmailVerificationTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.EmailVerificationToken, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.EmailVerificationToken), args.Error(1)
+}
+
+func (m *mockEmailVerificationTokenRepository) FindLatest(ctx context.Context, userID int) (*entity.EmailVerificationToken, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.EmailVerificationToken), args.Error(1)
+}
+
+func (m *mockEmailVerificationTokenRepository) Verify(ctx context.Context, token *entity.EmailVerificationToken, now time.Time) error {
+	args := m.Called(token, now)
+	return args.Error(0)
+}
+
+type mockEmailVerificationUseCase struct {
+	mock.Mock
+}
+
+func NewMockEmailVerificationUseCase() *mockEmailVerificationUseCase {
+	return new(mockEmailVerificationUseCase)
+}
+
+func (m *mockEmailVerificationUseCase) SendVerification(ctx context.Context, user *entity.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *mockEmailVerificationUseCase) Verify(ctx context.Context, token string) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *mockEmailVerificationUseCase) Resend(ctx context.Context, email string) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+var testEmailVerificationConfig = EmailVerificationConfig{
+	URL:            "https://todo.example.com/api/v1/auth/verify",
+	TTL:            24 * time.Hour,
+	ResendInterval: time.Minute,
+}
+
+type EmailVerificationUseCaseSuite struct {
+	suite.Suite
+	useCase                              *emailVerificationUseCase
+	mockUserRepository                   *mockUserRepository
+	mockEmailVerificationTokenRepository *mockEmailVerificationTokenRepository
+	sent                                 []*mailer.Message
+	now                                  time.Time
+}
+
+func TestEmailVerificationUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(EmailVerificationUseCaseSuite))
+}
+
+func (suite *EmailVerificationUseCaseSuite) SetupTest() {
+	suite.mockUserRepository = NewMockUserRepository()
+	suite.mockEmailVerificationTokenRepository = NewMockEmailVerificationTokenRepository()
+	suite.useCase = NewEmailVerificationUseCase(suite.mockUserRepository, suite.mockEmailVerificationTokenRepository,
+		mailer.NewLogMailer("no-reply@example.com"), testEmailVerificationConfig)
+	suite.now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.useCase.clock = tester.NewMockClock(suite.now)
+	suite.sent = nil
+	suite.useCase.send = func(msg *mailer.Message) { suite.sent = append(suite.sent, msg) }
+}
+
+func (suite *EmailVerificationUseCaseSuite) TestSendVerification() {
+	suite.mockEmailVerificationTokenRepository.On("Create", mock.AnythingOfType("*entity.EmailVerificationToken")).Return(nil)
+
+	suite.Assert().Nil(suite.useCase.SendVerification(context.Background(), &entity.User{ID: 7, Email: "user@example.com"}))
+	suite.Require().Len(suite.sent, 1)
+	suite.Assert().Equal("user@example.com", suite.sent[0].To)
+	suite.Assert().Contains(suite.sent[0].Body, "within 24 hours")
+
+	link := regexp.MustCompile(`https://\S+`).FindString(suite.sent[0].Body)
+	parsed, err := url.Parse(link)
+	suite.Require().Nil(err)
+	suite.Assert().Equal("/api/v1/auth/verify", parsed.Path)
+	token := parsed.Query().Get("token")
+	suite.Assert().NotEmpty(token)
+
+	saved := suite.mockEmailVerificationTokenRepository.Calls[0].Arguments.Get(0).(*entity.EmailVerificationToken)
+	suite.Assert().Equal(7, saved.UserID)
+	suite.Assert().Equal(security.HashToken(token), saved.TokenHash)
+	suite.Assert().Equal(suite.now.Add(24*time.Hour), saved.ExpiresAt)
+	suite.Assert().Equal(suite.now, saved.CreatedAt)
+}
+
+func (suite *EmailVerificationUseCaseSuite) TestVerify() {
+	record := &entity.EmailVerificationToken{SingleUseToken: entity.SingleUseToken{ID: 1, UserID: 7, ExpiresAt: suite.now.Add(time.Minute)}}
+	suite.mockEmailVerificationTokenRepository.On("FindByHash", security.HashToken("token")).Return(record, nil)
+	suite.mockEmailVerificationTokenRepository.On("Verify", record, suite.now).Return(nil)
+
+	suite.Assert().Nil(suite.useCase.Verify(context.Background(), "token"))
+	suite.mockEmailVerificationTokenRepository.AssertCalled(suite.T(), "Verify", record, suite.now)
+}
+
+func (suite *EmailVerificationUseCaseSuite) TestVerifyInvalidToken() {
+	usedAt := suite.now.Add(-time.Minute)
+	raced := &entity.EmailVerificationToken{SingleUseToken: entity.SingleUseToken{ID: 3, UserID: 7, ExpiresAt: suite.now.Add(time.Minute)}}
+	suite.mockEmailVerificationTokenRepository.On("FindByHash", security.HashToken("unknown")).Return(nil, apperror.NewNotFound("email verification token not found"))
+	suite.mockEmailVerificationTokenRepository.On("FindByHash", security.HashToken("expired")).Return(&entity.EmailVerificationToken{SingleUseToken: entity.SingleUseToken{ID: 1, ExpiresAt: suite.now}}, nil)
+	suite.mockEmailVerificationTokenRepository.On("FindByHash", security.HashToken("used")).Return(&entity.EmailVerificationToken{SingleUseToken: entity.SingleUseToken{ID: 2, ExpiresAt: suite.now.Add(time.Minute), UsedAt: &usedAt}}, nil)
+	suite.mockEmailVerificationTokenRepository.On("FindByHash", security.HashToken("raced")).Return(raced, nil)
+	suite.mockEmailVerificationTokenRepository.On("Verify", raced, suite.now).Return(gateway.ErrEmailVerificationTokenAlreadyUsed)
+
+	for _, token := range []string{"unknown", "expired", "used", "raced"} {
+		err := suite.useCase.Verify(context.Background(), token)
+		suite.Assert().ErrorIs(err, ErrInvalidEmailVerificationToken, token)
+		suite.Assert().True(apperror.IsValidation(err))
+	}
+}
+
+func (suite *EmailVerificationUseCaseSuite) TestResend() {
+	verifiedAt := suite.now.Add(-time.Hour)
+	suite.mockUserRepository.On("FindByEmail", "new@example.com").Return(&entity.User{ID: 7, Email: "new@example.com"}, nil)
+	suite.mockUserRepository.On("FindByEmail", "recent@example.com").Return(&entity.User{ID: 8, Email: "recent@example.com"}, nil)
+	suite.mockUserRepository.On("FindByEmail", "verified@example.com").Return(&entity.User{ID: 9, Email: "verified@example.com", EmailVerifiedAt: &verifiedAt}, nil)
+	suite.mockUserRepository.On("FindByEmail", "missing@example.com").Return(nil, apperror.NewNotFound("user not found"))
+	suite.mockEmailVerificationTokenRepository.On("FindLatest", 7).Return(&entity.EmailVerificationToken{SingleUseToken: entity.SingleUseToken{UserID: 7, CreatedAt: suite.now.Add(-time.Minute)}}, nil)
+	suite.mockEmailVerificationTokenRepository.On("FindLatest", 8).Return(&entity.EmailVerificationToken{SingleUseToken: entity.SingleUseToken{UserID: 8, CreatedAt: suite.now.Add(-59 * time.Second)}}, nil)
+	suite.mockEmailVerificationTokenRepository.On("Create", mock.AnythingOfType("*entity.EmailVerificationToken")).Return(nil)
+
+	// 再送の間隔が過ぎていれば送り直す
+	suite.Assert().Nil(suite.useCase.Resend(context.Background(), "new@example.com"))
+	suite.Require().Len(suite.sent, 1)
+	suite.Assert().Equal("new@example.com", suite.sent[0].To)
+
+	// 間隔内・確認済み・未登録の場合は何もせずに成功を返す
+	for _, email := range []string{"recent@example.com", "verified@example.com", "missing@example.com"} {
+		suite.Assert().Nil(suite.useCase.Resend(context.Background(), email), email)
+	}
+	suite.Assert().Len(suite.sent, 1)
+	suite.mockEmailVerificationTokenRepository.AssertNumberOfCalls(suite.T(), "Create", 1)
+}
+
+func (suite *EmailVerificationUseCaseSuite) TestResendError() {
+	suite.mockUserRepository.On("FindByEmail", "user@example.com").Return(nil, errors.New("connection refused"))
+
+	suite.Assert().EqualError(suite.useCase.Resend(context.Background(), "user@example.com"), "connection refused")
+	suite.Assert().Empty(suite.sent)
+}
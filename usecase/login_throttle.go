@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg"
+	"go-todo-app-clean-arch/pkg/logger"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+const (
+	// この回数までの失敗では待たせない。入力ミスで困らないようにするため
+	loginFreeFailures = 3
+	// login_attempts.identifier の長さ
+	maxLoginAttemptIdentifierLength = 255
+)
+
+// LoginThrottleConfig はパスワードでのログインの試行を制限する設定
+type LoginThrottleConfig struct {
+	// AccountThreshold・IPThreshold はロックするまでの失敗の回数。0 の場合はロックしない
+	AccountThreshold int
+	IPThreshold      int
+	LockoutDuration  time.Duration
+	// BackoffBase は loginFreeFailures を超えた最初の失敗の後に待たせる時間。失敗するごとに倍にする
+	// 0 の場合は待たせない
+	BackoffBase time.Duration
+	// FailureWindow は失敗を覚えておく時間。最後の失敗からこれだけ経つと数え直す
+	FailureWindow time.Duration
+}
+
+// LoginThrottle はパスワードの総当たりを防ぐため、アカウントと接続元ごとにログインの失敗を数える
+type LoginThrottle interface {
+	// Check は email・ip からのログインを今受け付けてよいかを確認する
+	// 待つ必要がある場合は TooManyRequests を返す
+	Check(ctx context.Context, email, ip string) error
+	// RecordFailure はログインの失敗を数え、上限に達したアカウント・接続元をロックする
+	RecordFailure(ctx context.Context, email, ip string) error
+	// RecordSuccess はアカウントの失敗の記録を消す。接続元の記録は残す
+	RecordSuccess(ctx context.Context, email string) error
+}
+
+type loginThrottle struct {
+	loginAttemptRepository gateway.LoginAttemptRepository
+	auditLogRepository     gateway.AuditLogRepository
+	config                 LoginThrottleConfig
+	clock                  pkg.Clock
+}
+
+func NewLoginThrottle(loginAttemptRepository gateway.LoginAttemptRepository, auditLogRepository gateway.AuditLogRepository, config LoginThrottleConfig) *loginThrottle {
+	return &loginThrottle{
+		loginAttemptRepository: loginAttemptRepository,
+		auditLogRepository:     auditLogRepository,
+		config:                 config,
+		clock:                  pkg.NewClock(),
+	}
+}
+
+// loginAttemptKey は失敗を数える単位。threshold はロックするまでの回数
+// 接続元は社内ネットワークなどで共有されるため、失敗ごとに待たせるのはアカウントだけにする
+type loginAttemptKey struct {
+	kind       string
+	identifier string
+	threshold  int
+	backoff    bool
+}
+
+func (k loginAttemptKey) String() string {
+	return k.kind + ":" + k.identifier
+}
+
+func (t *loginThrottle) keys(email, ip string) []loginAttemptKey {
+	// 大文字・小文字を変えて試されても同じアカウントとして数える
+	identifier := strings.ToLower(strings.TrimSpace(email))
+	if len(identifier) > maxLoginAttemptIdentifierLength {
+		identifier = identifier[:maxLoginAttemptIdentifierLength]
+	}
+	keys := []loginAttemptKey{{kind: entity.LoginAttemptAccount, identifier: identifier, threshold: t.config.AccountThreshold, backoff: true}}
+	if ip != "" {
+		keys = append(keys, loginAttemptKey{kind: entity.LoginAttemptIP, identifier: ip, threshold: t.config.IPThreshold})
+	}
+	return keys
+}
+
+func (t *loginThrottle) Check(ctx context.Context, email, ip string) error {
+	now := t.clock.Now()
+	var wait time.Duration
+	for _, key := range t.keys(email, ip) {
+		attempt, err := t.loginAttemptRepository.Find(ctx, key.kind, key.identifier)
+		if err != nil {
+			if apperror.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if attempt.IsLocked(now) {
+			wait = max(wait, attempt.LockedUntil.Sub(now))
+		}
+		if key.backoff && now.Sub(attempt.LastFailedAt) < t.config.FailureWindow {
+			wait = max(wait, attempt.LastFailedAt.Add(t.backoff(attempt.Failures)).Sub(now))
+		}
+	}
+	if wait > 0 {
+		return apperror.NewTooManyRequests("too many failed login attempts, try again later", wait)
+	}
+	return nil
+}
+
+// backoff は failures 回失敗した後、次に試せるようになるまでの時間を返す
+func (t *loginThrottle) backoff(failures int) time.Duration {
+	if failures <= loginFreeFailures || t.config.BackoffBase <= 0 {
+		return 0
+	}
+	wait := t.config.BackoffBase
+	for i := loginFreeFailures + 1; i < failures; i++ {
+		wait *= 2
+		// ロックより長くは待たせない。倍にし続けて桁あふれしないようにする
+		if wait >= t.config.LockoutDuration {
+			return t.config.LockoutDuration
+		}
+	}
+	return min(wait, t.config.LockoutDuration)
+}
+
+func (t *loginThrottle) RecordFailure(ctx context.Context, email, ip string) error {
+	now := t.clock.Now()
+	for _, key := range t.keys(email, ip) {
+		attempt, err := t.loginAttemptRepository.RecordFailure(ctx, key.kind, key.identifier, now, t.config.FailureWindow)
+		if err != nil {
+			return err
+		}
+		if key.threshold <= 0 || attempt.Failures < key.threshold {
+			continue
+		}
+		if err := t.lock(ctx, key, ip, attempt.Failures, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *loginThrottle) lock(ctx context.Context, key loginAttemptKey, ip string, failures int, now time.Time) error {
+	if err := t.loginAttemptRepository.Lock(ctx, key.kind, key.identifier, now.Add(t.config.LockoutDuration)); err != nil {
+		return err
+	}
+	detail := fmt.Sprintf("locked for %s after %d failed login attempts", t.config.LockoutDuration, failures)
+	logger.Warn("Login locked", "target", key.String(), "ip_address", ip, "failures", failures)
+	return t.auditLogRepository.Create(ctx, &entity.AuditLog{
+		Action:    entity.AuditLoginLocked,
+		Target:    key.String(),
+		IPAddress: ip,
+		Detail:    detail,
+		CreatedAt: now,
+	})
+}
+
+func (t *loginThrottle) RecordSuccess(ctx context.Context, email string) error {
+	account := t.keys(email, "")[0]
+	return t.loginAttemptRepository.Reset(ctx, account.kind, account.identifier)
+}
+
+// verifyCurrentPassword はログインしているユーザーが入力した今のパスワードを確かめる
+// 盗まれたセッションから総当たりされないよう、ログインと同じくアカウントごとに失敗を数えて制限する
+// 間違っている場合は incorrect を返す
+func verifyCurrentPassword(ctx context.Context, throttle LoginThrottle, passwordHasher security.PasswordHasher, user *entity.User, password string, incorrect error) error {
+	if err := throttle.Check(ctx, user.Email, ""); err != nil {
+		return err
+	}
+	ok, err := passwordHasher.Verify(password, user.Password)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if err := throttle.RecordFailure(ctx, user.Email, ""); err != nil {
+			return err
+		}
+		return incorrect
+	}
+	return throttle.RecordSuccess(ctx, user.Email)
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/mailer"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type mockLoginThrottle struct {
+	mock.Mock
+}
+
+func NewMockLoginThrottle() *mockLoginThrottle {
+	return new(mockLoginThrottle)
+}
+
+// newPassingLoginThrottle は常にログインを受け付ける LoginThrottle を返す
+func newPassingLoginThrottle() *mockLoginThrottle {
+	m := NewMockLoginThrottle()
+	m.On("Check", mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("RecordFailure", mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("RecordSuccess", mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func (m *mockLoginThrottle) Check(ctx context.Context, email, ip string) error {
+	args := m.Called(email, ip)
+	return args.Error(0)
+}
+
+func (m *mockLoginThrottle) RecordFailure(ctx context.Context, email, ip string) error {
+	args := m.Called(email, ip)
+	return args.Error(0)
+}
+
+func (m *mockLoginThrottle) RecordSuccess(ctx context.Context, email string) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+type mockAuditLogRepository struct {
+	mock.Mock
+}
+
+func NewMockAuditLogRepository() *mockAuditLogRepository {
+	return new(mockAuditLogRepository)
+}
+
+func (m *mockAuditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	args := m.Called(log)
+	return args.Error(0)
+}
+
+var testLoginThrottleConfig = LoginThrottleConfig{
+	AccountThreshold: 6,
+	IPThreshold:      10,
+	LockoutDuration:  15 * time.Minute,
+	BackoffBase:      time.Second,
+	FailureWindow:    15 * time.Minute,
+}
+
+type LoginThrottleSuite struct {
+	suite.Suite
+	throttle               *loginThrottle
+	mockAuditLogRepository *mockAuditLogRepository
+	now                    time.Time
+}
+
+func TestLoginThrottleSuite(t *testing.T) {
+	suite.Run(t, new(LoginThrottleSuite))
+}
+
+func (suite *LoginThrottleSuite) SetupTest() {
+	suite.mockAuditLogRepository = NewMockAuditLogRepository()
+	suite.mockAuditLogRepository.On("Create", mock.AnythingOfType("*entity.AuditLog")).Return(nil)
+	suite.throttle = NewLoginThrottle(gateway.NewMemoryLoginAttemptRepository(), suite.mockAuditLogRepository, testLoginThrottleConfig)
+	suite.now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.at(suite.now)
+}
+
+func (suite *LoginThrottleSuite) at(now time.Time) {
+	suite.throttle.clock = tester.NewMockClock(now)
+}
+
+func (suite *LoginThrottleSuite) fail(email, ip string, times int) {
+	for i := 0; i < times; i++ {
+		suite.Require().Nil(suite.throttle.RecordFailure(context.Background(), email, ip))
+	}
+}
+
+func (suite *LoginThrottleSuite) TestBackoff() {
+	// 3 回までは待たせない
+	suite.fail("user@example.com", "192.0.2.1", 3)
+	suite.Assert().Nil(suite.throttle.Check(context.Background(), "user@example.com", "192.0.2.1"))
+
+	// それ以降は 1 秒から倍にしていく
+	suite.fail("user@example.com", "192.0.2.1", 1)
+	err := suite.throttle.Check(context.Background(), "user@example.com", "192.0.2.1")
+	suite.Assert().True(apperror.IsTooManyRequests(err))
+	suite.Assert().Equal(time.Second, apperror.RetryAfterOf(err))
+	// 大文字・小文字や接続元を変えても同じアカウントとして待たせる
+	err = suite.throttle.Check(context.Background(), " User@Example.com", "198.51.100.1")
+	suite.Assert().Equal(time.Second, apperror.RetryAfterOf(err))
+
+	suite.at(suite.now.Add(time.Second))
+	suite.Assert().Nil(suite.throttle.Check(context.Background(), "user@example.com", "192.0.2.1"))
+	suite.fail("user@example.com", "192.0.2.1", 1)
+	suite.Assert().Equal(2*time.Second, apperror.RetryAfterOf(suite.throttle.Check(context.Background(), "user@example.com", "192.0.2.1")))
+
+	// 成功するとアカウントの記録は消える
+	suite.Assert().Nil(suite.throttle.RecordSuccess(context.Background(), "USER@example.com"))
+	suite.Assert().Nil(suite.throttle.Check(context.Background(), "user@example.com", "192.0.2.1"))
+	suite.mockAuditLogRepository.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *LoginThrottleSuite) TestBackoffLimit() {
+	suite.throttle.config.AccountThreshold = 0
+	suite.fail("user@example.com", "", 100)
+
+	// ロックしない設定でも、ロックの時間より長くは待たせない
+	err := suite.throttle.Check(context.Background(), "user@example.com", "")
+	suite.Assert().Equal(testLoginThrottleConfig.LockoutDuration, apperror.RetryAfterOf(err))
+}
+
+func (suite *LoginThrottleSuite) TestAccountLockout() {
+	suite.fail("user@example.com", "192.0.2.1", testLoginThrottleConfig.AccountThreshold)
+
+	err := suite.throttle.Check(context.Background(), "user@example.com", "198.51.100.1")
+	suite.Assert().True(apperror.IsTooManyRequests(err))
+	suite.Assert().Equal(15*time.Minute, apperror.RetryAfterOf(err))
+	suite.Assert().Nil(suite.throttle.Check(context.Background(), "other@example.com", "192.0.2.1"))
+
+	log := suite.mockAuditLogRepository.Calls[0].Arguments.Get(0).(*entity.AuditLog)
+	suite.Assert().Equal(entity.AuditLoginLocked, log.Action)
+	suite.Assert().Equal("account:user@example.com", log.Target)
+	suite.Assert().Equal("192.0.2.1", log.IPAddress)
+	suite.Assert().Equal(suite.now, log.CreatedAt)
+
+	// ロックが解けたら、失敗の回数も数え直す
+	suite.at(suite.now.Add(15 * time.Minute))
+	suite.Assert().Nil(suite.throttle.Check(context.Background(), "user@example.com", "198.51.100.1"))
+	suite.fail("user@example.com", "192.0.2.1", 1)
+	suite.Assert().Nil(suite.throttle.Check(context.Background(), "user@example.com", "198.51.100.1"))
+}
+
+func (suite *LoginThrottleSuite) TestIPLockout() {
+	// アカウントを変えながら試しても、接続元ごとに数える
+	for i := 0; i < testLoginThrottleConfig.IPThreshold; i++ {
+		suite.fail(string(rune('a'+i))+"@example.com", "192.0.2.1", 1)
+	}
+
+	err := suite.throttle.Check(context.Background(), "new@example.com", "192.0.2.1")
+	suite.Assert().True(apperror.IsTooManyRequests(err))
+	suite.Assert().Equal(15*time.Minute, apperror.RetryAfterOf(err))
+	suite.Assert().Nil(suite.throttle.Check(context.Background(), "new@example.com", "198.51.100.1"))
+
+	// ログインに成功しても接続元のロックは解けない
+	suite.Assert().Nil(suite.throttle.RecordSuccess(context.Background(), "new@example.com"))
+	suite.Assert().True(apperror.IsTooManyRequests(suite.throttle.Check(context.Background(), "new@example.com", "192.0.2.1")))
+
+	suite.mockAuditLogRepository.AssertNumberOfCalls(suite.T(), "Create", 1)
+	log := suite.mockAuditLogRepository.Calls[0].Arguments.Get(0).(*entity.AuditLog)
+	suite.Assert().Equal("ip:192.0.2.1", log.Target)
+}
+
+func (suite *UserUseCaseSuite) TestLoginThrottled() {
+	hashedPassword, _ := testPasswordHasher.Hash("password123")
+	mockUserRepository := NewMockUserRepository()
+	mockLoginThrottle := NewMockLoginThrottle()
+	suite.userUseCase = NewUserUseCase(mockUserRepository, NewMockRefreshTokenRepository(), NewMockSessionRepository(), NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(),
+		NewMockEmailVerificationUseCase(), NewMockTwoFactorUseCase(), mockLoginThrottle, testPasswordHasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
+	client := ClientInfo{IPAddress: "192.0.2.1"}
+
+	mockUserRepository.On("FindByEmail", "test@example.com").Return(&entity.User{ID: 1, Email: "test@example.com", Password: hashedPassword}, nil)
+	mockUserRepository.On("FindByEmail", "missing@example.com").Return(nil, apperror.NewNotFound("user not found"))
+	mockLoginThrottle.On("Check", "locked@example.com", "192.0.2.1").Return(apperror.NewTooManyRequests("too many failed login attempts", time.Minute))
+	mockLoginThrottle.On("Check", mock.Anything, "192.0.2.1").Return(nil)
+	mockLoginThrottle.On("RecordFailure", mock.Anything, "192.0.2.1").Return(nil)
+
+	// 待たせている間はパスワードを確かめない
+	_, err := suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: "locked@example.com", Password: "password123"}, client)
+	suite.Assert().True(apperror.IsTooManyRequests(err))
+	mockUserRepository.AssertNotCalled(suite.T(), "FindByEmail", "locked@example.com")
+
+	// パスワードの誤りも、存在しないアカウントも失敗として数える
+	_, err = suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: "test@example.com", Password: "wrong"}, client)
+	suite.Assert().ErrorIs(err, ErrInvalidCredentials)
+	_, err = suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: "missing@example.com", Password: "wrong"}, client)
+	suite.Assert().ErrorIs(err, ErrInvalidCredentials)
+	mockLoginThrottle.AssertCalled(suite.T(), "RecordFailure", "test@example.com", "192.0.2.1")
+	mockLoginThrottle.AssertCalled(suite.T(), "RecordFailure", "missing@example.com", "192.0.2.1")
+	mockLoginThrottle.AssertNotCalled(suite.T(), "RecordSuccess", mock.Anything)
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"go-todo-app-clean-arch/pkg/logger"
+	"go-todo-app-clean-arch/pkg/mailer"
+)
+
+// background はレスポンスの後に回した処理。停止するときに WaitBackground で終わるのを待つ
+var background sync.WaitGroup
+
+// goAsync は job をバックグラウンドで実行する
+func goAsync(job func()) {
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		job()
+	}()
+}
+
+// WaitBackground はメールの送信など、レスポンスの後に回した処理がすべて終わるまで待つ
+// 新しいリクエストを受け付けなくなってから呼ぶ。ctx が先に終わった場合は ctx のエラーを返す
+func WaitBackground(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		background.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sendAsync は msg をバックグラウンドで送る
+// 送信にかかる時間で登録済みのアドレスかが分からないよう、レスポンスを待たせない
+func sendAsync(m mailer.Mailer) func(msg *mailer.Message) {
+	return func(msg *mailer.Message) {
+		goAsync(func() {
+			if err := m.Send(msg); err != nil {
+				logger.Error("Failed to send email", "subject", msg.Subject, "error", err.Error())
+			}
+		})
+	}
+}
+
+// linkWithToken は base のクエリ文字列に token を加えた URL を返す
+func linkWithToken(base, token string) string {
+	link, err := url.Parse(base)
+	if err != nil {
+		// 設定の読み込み時に検証済み
+		return base + "?token=" + url.QueryEscape(token)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
+
+// humanizeDuration はメール本文向けに 1 hour・30 minutes のような表記にする
+func humanizeDuration(d time.Duration) string {
+	n, unit := int(d/time.Minute), "minute"
+	if d >= time.Hour && d%time.Hour == 0 {
+		n, unit = int(d/time.Hour), "hour"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", n, unit)
+}
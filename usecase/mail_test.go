@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/pkg/mailer"
+)
+
+// blockingMailer は release が閉じられるまで送信を終えない
+type blockingMailer struct {
+	release chan struct{}
+	sent    chan *mailer.Message
+}
+
+func (m *blockingMailer) Send(msg *mailer.Message) error {
+	<-m.release
+	m.sent <- msg
+	return nil
+}
+
+func TestWaitBackground(t *testing.T) {
+	m := &blockingMailer{release: make(chan struct{}), sent: make(chan *mailer.Message, 1)}
+	sendAsync(m)(&mailer.Message{To: "user@example.com"})
+
+	// 送信中の間は待ち、ctx が終わったらあきらめる
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, WaitBackground(ctx), context.DeadlineExceeded)
+
+	close(m.release)
+	assert.NoError(t, WaitBackground(context.Background()))
+	assert.Equal(t, "user@example.com", (<-m.sent).To)
+}
@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg"
+	"go-todo-app-clean-arch/pkg/logger"
+	"go-todo-app-clean-arch/pkg/oidc"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+var (
+	ErrUnknownOIDCProvider = apperror.NewNotFound("identity provider not found")
+	ErrInvalidOIDCState    = apperror.NewUnauthorized("login with the identity provider is invalid or has expired")
+	ErrOIDCLoginFailed     = apperror.NewUnauthorized("login with the identity provider failed")
+	ErrOIDCEmailRequired   = apperror.NewForbidden("the identity provider did not return an email address")
+	// ErrOIDCAccountExists は同じメールアドレスのアカウントがあるが、どちらかのメールアドレスが確認されていないため自動で連携しないことを表す
+	ErrOIDCAccountExists = apperror.NewConflict("an account with this email address already exists; " +
+		"log in with your password and link the identity provider from your account")
+	ErrIdentityAlreadyLinked = apperror.NewConflict("this identity provider account is already linked to another user")
+	ErrProviderAlreadyLinked = apperror.NewConflict("an account of this identity provider is already linked")
+	ErrLastSignInMethod      = apperror.NewConflict("cannot unlink the only way to log in; set a password with password reset first")
+)
+
+// OIDCProvider は OpenID Provider とのやり取り。oidc.Provider が実装する
+type OIDCProvider interface {
+	AuthorizationURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	Authenticate(ctx context.Context, code, codeVerifier, nonce string) (*oidc.Identity, error)
+}
+
+// LoginCompleter は本人であることを確かめたユーザーのログインを進める。userUseCase が実装する
+type LoginCompleter interface {
+	CompleteLogin(ctx context.Context, user *entity.User, client ClientInfo) (*LoginResult, error)
+}
+
+type OIDCConfig struct {
+	// Providers のキーは URL に使うプロバイダーの名前
+	Providers map[string]OIDCProvider
+	// LoginTTL はプロバイダーでのログインを始めてからコールバックまでに許す時間
+	LoginTTL time.Duration
+}
+
+// OIDCAuthorization は始めたログイン・連携。ブラウザを URL に送り、State はコールバックまでブラウザに持たせる
+type OIDCAuthorization struct {
+	URL       string
+	State     string
+	ExpiresAt time.Time
+}
+
+// OIDCCallbackResult はコールバックの結果。ログインの場合だけ Login を持つ
+type OIDCCallbackResult struct {
+	Provider string
+	Login    *LoginResult
+}
+
+type OIDCUseCase interface {
+	// Providers はログインに使えるプロバイダーの名前を返す
+	Providers() []string
+	StartLogin(ctx context.Context, provider string) (*OIDCAuthorization, error)
+	// StartLink・ListIdentities・Unlink は ctx の主体（Principal）の連携を扱い、ログインしたセッションでだけ使える
+	StartLink(ctx context.Context, provider string) (*OIDCAuthorization, error)
+	Callback(ctx context.Context, state, code string, client ClientInfo) (*OIDCCallbackResult, error)
+	ListIdentities(ctx context.Context) ([]*entity.UserIdentity, error)
+	Unlink(ctx context.Context, provider string) error
+}
+
+type oidcUseCase struct {
+	userRepository           gateway.UserRepository
+	userIdentityRepository   gateway.UserIdentityRepository
+	oidcLoginStateRepository gateway.OIDCLoginStateRepository
+	emailVerification        EmailVerificationUseCase
+	loginCompleter           LoginCompleter
+	config                   OIDCConfig
+	clock                    pkg.Clock
+}
+
+func NewOIDCUseCase(
+	userRepository gateway.UserRepository,
+	userIdentityRepository gateway.UserIdentityRepository,
+	oidcLoginStateRepository gateway.OIDCLoginStateRepository,
+	emailVerification EmailVerificationUseCase,
+	loginCompleter LoginCompleter,
+	config OIDCConfig,
+) *oidcUseCase {
+	return &oidcUseCase{
+		userRepository:           userRepository,
+		userIdentityRepository:   userIdentityRepository,
+		oidcLoginStateRepository: oidcLoginStateRepository,
+		emailVerification:        emailVerification,
+		loginCompleter:           loginCompleter,
+		config:                   config,
+		clock:                    pkg.NewClock(),
+	}
+}
+
+func (u *oidcUseCase) Providers() []string {
+	names := make([]string, 0, len(u.config.Providers))
+	for name := range u.config.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StartLogin は provider でのログインを始める
+func (u *oidcUseCase) StartLogin(ctx context.Context, provider string) (*OIDCAuthorization, error) {
+	return u.start(ctx, provider, nil)
+}
+
+// StartLink は ctx の主体に provider のアカウントを連携するため、provider でのログインを始める
+func (u *oidcUseCase) StartLink(ctx context.Context, provider string) (*OIDCAuthorization, error) {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID := principal.UserID
+	if _, ok := u.config.Providers[provider]; !ok {
+		return nil, ErrUnknownOIDCProvider
+	}
+	identities, err := u.userIdentityRepository.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			return nil, ErrProviderAlreadyLinked
+		}
+	}
+	return u.start(ctx, provider, &userID)
+}
+
+func (u *oidcUseCase) start(ctx context.Context, providerName string, userID *int) (*OIDCAuthorization, error) {
+	provider, ok := u.config.Providers[providerName]
+	if !ok {
+		return nil, ErrUnknownOIDCProvider
+	}
+	// state・nonce・code_verifier はそれぞれ別の乱数にする
+	var values [3]string
+	for i := range values {
+		v, err := security.NewOpaqueToken()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	state, nonce, codeVerifier := values[0], values[1], values[2]
+
+	authorizationURL, err := provider.AuthorizationURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	now := u.clock.Now()
+	record := &entity.OIDCLoginState{
+		StateHash:    security.HashToken(state),
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		UserID:       userID,
+		ExpiresAt:    now.Add(u.config.LoginTTL),
+		CreatedAt:    now,
+	}
+	if err := u.oidcLoginStateRepository.Create(ctx, record); err != nil {
+		return nil, err
+	}
+	return &OIDCAuthorization{URL: authorizationURL, State: state, ExpiresAt: record.ExpiresAt}, nil
+}
+
+// Callback はプロバイダーから戻った認可コードでログイン・連携を完了する
+// state は 1 回しか使えず、ログインを始めたときのプロバイダーと nonce・code_verifier で ID トークンを受け取る
+func (u *oidcUseCase) Callback(ctx context.Context, state, code string, client ClientInfo) (*OIDCCallbackResult, error) {
+	record, err := u.oidcLoginStateRepository.Consume(ctx, security.HashToken(state))
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return nil, ErrInvalidOIDCState
+		}
+		return nil, err
+	}
+	if !record.IsUsable(u.clock.Now()) {
+		return nil, ErrInvalidOIDCState
+	}
+	// ログインを始めた後で設定から外したプロバイダー
+	provider, ok := u.config.Providers[record.Provider]
+	if !ok {
+		return nil, ErrInvalidOIDCState
+	}
+
+	identity, err := provider.Authenticate(ctx, code, record.CodeVerifier, record.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrAuthenticationFailed) {
+			// 理由はユーザーに見せず、ログにだけ残す
+			logger.Warn("OpenID Connect authentication failed", "provider", record.Provider, "error", err.Error())
+			return nil, ErrOIDCLoginFailed
+		}
+		return nil, err
+	}
+
+	result := &OIDCCallbackResult{Provider: record.Provider}
+	if record.IsLink() {
+		if err := u.link(ctx, *record.UserID, record.Provider, identity); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	result.Login, err = u.login(ctx, record.Provider, identity, client)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// login は連携済みのユーザーとしてログインする。連携していない場合は、メールアドレスが同じユーザーに連携するか、新しいユーザーを作る
+func (u *oidcUseCase) login(ctx context.Context, provider string, identity *oidc.Identity, client ClientInfo) (*LoginResult, error) {
+	linked, err := u.userIdentityRepository.FindBySubject(ctx, provider, identity.Subject)
+	if err == nil {
+		user, err := u.userRepository.GetCurrentUser(ctx, linked.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return u.loginCompleter.CompleteLogin(ctx, user, client)
+	}
+	if !apperror.IsNotFound(err) {
+		return nil, err
+	}
+	if identity.Email == "" {
+		return nil, ErrOIDCEmailRequired
+	}
+
+	user, err := u.userRepository.FindByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		// 確認されていないメールアドレスで連携すると、他人のメールアドレスで作ったアカウントを乗っ取れてしまう
+		if !identity.EmailVerified || !user.IsEmailVerified() {
+			return nil, ErrOIDCAccountExists
+		}
+		if err := u.userIdentityRepository.Create(ctx, u.newIdentity(user.ID, provider, identity)); err != nil {
+			if apperror.IsConflict(err) {
+				return nil, ErrProviderAlreadyLinked
+			}
+			return nil, err
+		}
+	case apperror.IsNotFound(err):
+		if user, err = u.signup(ctx, provider, identity); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+	return u.loginCompleter.CompleteLogin(ctx, user, client)
+}
+
+// signup はプロバイダーのアカウントでユーザーを作る。パスワードは持たず、必要になったらパスワードの再設定で決める
+func (u *oidcUseCase) signup(ctx context.Context, provider string, identity *oidc.Identity) (*entity.User, error) {
+	user := &entity.User{Email: identity.Email}
+	if identity.EmailVerified {
+		now := u.clock.Now()
+		user.EmailVerifiedAt = &now
+	}
+	if err := u.userIdentityRepository.CreateWithUser(ctx, user, u.newIdentity(0, provider, identity)); err != nil {
+		// 同時に同じメールアドレスで登録された
+		if apperror.IsConflict(err) {
+			return nil, ErrOIDCAccountExists
+		}
+		return nil, err
+	}
+	if !user.IsEmailVerified() {
+		// Signup と同じく、確認メールを送れなくてもアカウントの作成は成功させる
+		if err := u.emailVerification.SendVerification(ctx, user); err != nil {
+			logger.Error("Failed to send verification email", "user_id", user.ID, "error", err.Error())
+		}
+	}
+	return user, nil
+}
+
+func (u *oidcUseCase) link(ctx context.Context, userID int, provider string, identity *oidc.Identity) error {
+	linked, err := u.userIdentityRepository.FindBySubject(ctx, provider, identity.Subject)
+	if err == nil {
+		if linked.UserID == userID {
+			return nil
+		}
+		return ErrIdentityAlreadyLinked
+	}
+	if !apperror.IsNotFound(err) {
+		return err
+	}
+	if err := u.userIdentityRepository.Create(ctx, u.newIdentity(userID, provider, identity)); err != nil {
+		if apperror.IsConflict(err) {
+			return ErrProviderAlreadyLinked
+		}
+		return err
+	}
+	return nil
+}
+
+func (u *oidcUseCase) newIdentity(userID int, provider string, identity *oidc.Identity) *entity.UserIdentity {
+	return &entity.UserIdentity{
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: u.clock.Now(),
+	}
+}
+
+func (u *oidcUseCase) ListIdentities(ctx context.Context) ([]*entity.UserIdentity, error) {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.userIdentityRepository.List(ctx, principal.UserID)
+}
+
+// Unlink は provider の連携を解除する。パスワードを持たないユーザーの最後の連携は、ログインできなくなるので解除しない
+func (u *oidcUseCase) Unlink(ctx context.Context, provider string) error {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return err
+	}
+	userID := principal.UserID
+	user, err := u.userRepository.GetCurrentUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.HasPassword() {
+		identities, err := u.userIdentityRepository.List(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(identities) == 1 && identities[0].Provider == provider {
+			return ErrLastSignInMethod
+		}
+	}
+	return u.userIdentityRepository.Delete(ctx, userID, provider)
+}
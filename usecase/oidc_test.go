@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/oidc"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type mockUserIdentityRepository struct {
+	mock.Mock
+}
+
+func NewMockUserIdentityRepository() *mockUserIdentityRepository {
+	return new(mockUserIdentityRepository)
+}
+
+func (m *mockUserIdentityRepository) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	args := m.Called(identity)
+	return args.Error(0)
+}
+
+func (m *mockUserIdentityRepository) CreateWithUser(ctx context.Context, user *entity.User, identity *entity.UserIdentity) error {
+	args := m.Called(user, identity)
+	return args.Error(0)
+}
+
+func (m *mockUserIdentityRepository) FindBySubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	args := m.Called(provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UserIdentity), args.Error(1)
+}
+
+func (m *mockUserIdentityRepository) List(ctx context.Context, userID int) ([]*entity.UserIdentity, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.UserIdentity), args.Error(1)
+}
+
+func (m *mockUserIdentityRepository) Delete(ctx context.Context, userID int, provider string) error {
+	args := m.Called(userID, provider)
+	return args.Error(0)
+}
+
+type mockOIDCLoginStateRepository struct {
+	mock.Mock
+}
+
+func NewMockOIDCLoginStateRepository() *mockOIDCLoginStateRepository {
+	return new(mockOIDCLoginStateRepository)
+}
+
+func (m *mockOIDCLoginStateRepository) Create(ctx context.Context, state *entity.OIDCLoginState) error {
+	args := m.Called(state)
+	return args.Error(0)
+}
+
+func (m *mockOIDCLoginStateRepository) Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error) {
+	args := m.Called(stateHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.OIDCLoginState), args.Error(1)
+}
+
+type mockLoginCompleter struct {
+	mock.Mock
+}
+
+func (m *mockLoginCompleter) CompleteLogin(ctx context.Context, user *entity.User, client ClientInfo) (*LoginResult, error) {
+	args := m.Called(user, client)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*LoginResult), args.Error(1)
+}
+
+var errIdentityNotFound = apperror.NewNotFound("user identity not found")
+
+// OIDCUseCaseSuite はプロセス内の FakeOIDCProvider を相手に、oidc.Provider を通してログイン・連携を行う
+type OIDCUseCaseSuite struct {
+	suite.Suite
+	useCase                      *oidcUseCase
+	fake                         *tester.FakeOIDCProvider
+	mockUserRepository           *mockUserRepository
+	mockUserIdentityRepository   *mockUserIdentityRepository
+	mockOIDCLoginStateRepository *mockOIDCLoginStateRepository
+	mockEmailVerificationUseCase *mockEmailVerificationUseCase
+	mockLoginCompleter           *mockLoginCompleter
+	now                          time.Time
+	client                       ClientInfo
+}
+
+func TestOIDCUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(OIDCUseCaseSuite))
+}
+
+func (suite *OIDCUseCaseSuite) SetupTest() {
+	suite.fake = tester.NewFakeOIDCProvider("todo-app", "client-secret")
+	provider := oidc.NewProvider(oidc.Config{
+		Issuer:       suite.fake.Issuer(),
+		ClientID:     suite.fake.ClientID,
+		ClientSecret: suite.fake.ClientSecret,
+		Scopes:       []string{"email"},
+		RedirectURL:  "http://localhost:8080/api/v1/auth/oidc/callback",
+	})
+	suite.mockUserRepository = NewMockUserRepository()
+	suite.mockUserIdentityRepository = NewMockUserIdentityRepository()
+	suite.mockOIDCLoginStateRepository = NewMockOIDCLoginStateRepository()
+	suite.mockEmailVerificationUseCase = NewMockEmailVerificationUseCase()
+	suite.mockLoginCompleter = new(mockLoginCompleter)
+	suite.useCase = NewOIDCUseCase(suite.mockUserRepository, suite.mockUserIdentityRepository, suite.mockOIDCLoginStateRepository,
+		suite.mockEmailVerificationUseCase, suite.mockLoginCompleter,
+		OIDCConfig{Providers: map[string]OIDCProvider{"company": provider}, LoginTTL: 10 * time.Minute})
+	suite.now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.useCase.clock = tester.NewMockClock(suite.now)
+	suite.client = ClientInfo{UserAgent: "test", IPAddress: "192.0.2.1"}
+}
+
+func (suite *OIDCUseCaseSuite) TearDownTest() {
+	suite.fake.Close()
+}
+
+// signIn は account としてプロバイダーでログインし、コールバックに渡される state と認可コードを返す
+// 保存した状態は Consume で 1 回だけ返すようにする
+func (suite *OIDCUseCaseSuite) signIn(account tester.FakeOIDCAccount, start func() (*OIDCAuthorization, error)) (string, string) {
+	var saved *entity.OIDCLoginState
+	suite.mockOIDCLoginStateRepository.On("Create", mock.AnythingOfType("*entity.OIDCLoginState")).
+		Run(func(args mock.Arguments) { saved = args.Get(0).(*entity.OIDCLoginState) }).Return(nil).Once()
+
+	authorization, err := start()
+	suite.Require().Nil(err)
+	suite.Assert().Equal(security.HashToken(authorization.State), saved.StateHash)
+	suite.Assert().Equal(suite.now.Add(10*time.Minute), authorization.ExpiresAt)
+	suite.mockOIDCLoginStateRepository.On("Consume", saved.StateHash).Return(saved, nil).Once()
+
+	suite.fake.SignIn(account)
+	callback, err := suite.fake.Authorize(authorization.URL)
+	suite.Require().Nil(err)
+	query := callback.Query()
+	suite.Require().Equal(authorization.State, query.Get("state"))
+	return query.Get("state"), query.Get("code")
+}
+
+func (suite *OIDCUseCaseSuite) signInToLogin(account tester.FakeOIDCAccount) (string, string) {
+	return suite.signIn(account, func() (*OIDCAuthorization, error) { return suite.useCase.StartLogin(context.Background(), "company") })
+}
+
+func (suite *OIDCUseCaseSuite) TestStartLogin() {
+	suite.Assert().Equal([]string{"company"}, suite.useCase.Providers())
+
+	_, err := suite.useCase.StartLogin(context.Background(), "unknown")
+	suite.Assert().ErrorIs(err, ErrUnknownOIDCProvider)
+
+	suite.mockOIDCLoginStateRepository.On("Create", mock.AnythingOfType("*entity.OIDCLoginState")).Return(nil)
+	authorization, err := suite.useCase.StartLogin(context.Background(), "company")
+	suite.Require().Nil(err)
+	saved := suite.mockOIDCLoginStateRepository.Calls[0].Arguments.Get(0).(*entity.OIDCLoginState)
+	suite.Assert().Equal("company", saved.Provider)
+	suite.Assert().Nil(saved.UserID)
+
+	// state はブラウザにだけ渡し、nonce と code_challenge は保存した値から作る
+	u, err := url.Parse(authorization.URL)
+	suite.Require().Nil(err)
+	suite.Assert().Equal(authorization.State, u.Query().Get("state"))
+	suite.Assert().Equal(saved.Nonce, u.Query().Get("nonce"))
+	suite.Assert().Equal(oidc.CodeChallenge(saved.CodeVerifier), u.Query().Get("code_challenge"))
+	suite.Assert().NotEqual(authorization.State, saved.Nonce)
+}
+
+func (suite *OIDCUseCaseSuite) TestLoginLinkedUser() {
+	user := &entity.User{ID: 7, Email: "user@example.com"}
+	result := &LoginResult{Tokens: &TokenPair{AccessToken: "access"}}
+	suite.mockUserIdentityRepository.On("FindBySubject", "company", "sub-1").
+		Return(&entity.UserIdentity{UserID: 7, Provider: "company", Subject: "sub-1"}, nil)
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(user, nil)
+	suite.mockLoginCompleter.On("CompleteLogin", user, suite.client).Return(result, nil)
+
+	// プロバイダー側でメールアドレスが変わっていても、sub で同じアカウントとみなす
+	state, code := suite.signInToLogin(tester.FakeOIDCAccount{Subject: "sub-1", Email: "changed@example.com", EmailVerified: true})
+	got, err := suite.useCase.Callback(context.Background(), state, code, suite.client)
+	suite.Require().Nil(err)
+	suite.Assert().Equal(&OIDCCallbackResult{Provider: "company", Login: result}, got)
+	suite.mockUserRepository.AssertNotCalled(suite.T(), "FindByEmail", mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestLoginLinksVerifiedEmail() {
+	verifiedAt := suite.now.Add(-time.Hour)
+	user := &entity.User{ID: 7, Email: "user@example.com", Password: "hash", EmailVerifiedAt: &verifiedAt}
+	suite.mockUserIdentityRepository.On("FindBySubject", "company", "sub-1").Return(nil, errIdentityNotFound)
+	suite.mockUserRepository.On("FindByEmail", "user@example.com").Return(user, nil)
+	suite.mockUserIdentityRepository.On("Create", mock.AnythingOfType("*entity.UserIdentity")).Return(nil)
+	suite.mockLoginCompleter.On("CompleteLogin", user, suite.client).Return(&LoginResult{}, nil)
+
+	state, code := suite.signInToLogin(tester.FakeOIDCAccount{Subject: "sub-1", Email: "user@example.com", EmailVerified: true})
+	_, err := suite.useCase.Callback(context.Background(), state, code, suite.client)
+	suite.Require().Nil(err)
+	suite.mockUserIdentityRepository.AssertCalled(suite.T(), "Create", &entity.UserIdentity{
+		UserID: 7, Provider: "company", Subject: "sub-1", Email: "user@example.com", CreatedAt: suite.now,
+	})
+}
+
+func (suite *OIDCUseCaseSuite) TestLoginRefusesUnverifiedEmail() {
+	verifiedAt := suite.now.Add(-time.Hour)
+	suite.mockUserIdentityRepository.On("FindBySubject", "company", mock.Anything).Return(nil, errIdentityNotFound)
+	suite.mockUserRepository.On("FindByEmail", "verified@example.com").
+		Return(&entity.User{ID: 7, Email: "verified@example.com", EmailVerifiedAt: &verifiedAt}, nil)
+	suite.mockUserRepository.On("FindByEmail", "unverified@example.com").
+		Return(&entity.User{ID: 8, Email: "unverified@example.com"}, nil)
+
+	for _, account := range []tester.FakeOIDCAccount{
+		// プロバイダーが確認していないメールアドレス
+		{Subject: "sub-1", Email: "verified@example.com", EmailVerified: false},
+		// 他人が先にパスワードで登録したかもしれない、確認されていないアカウント
+		{Subject: "sub-2", Email: "unverified@example.com", EmailVerified: true},
+	} {
+		state, code := suite.signInToLogin(account)
+		_, err := suite.useCase.Callback(context.Background(), state, code, suite.client)
+		suite.Assert().ErrorIs(err, ErrOIDCAccountExists, account.Email)
+	}
+	suite.mockUserIdentityRepository.AssertNotCalled(suite.T(), "Create", mock.Anything)
+	suite.mockLoginCompleter.AssertNotCalled(suite.T(), "CompleteLogin", mock.Anything, mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestLoginCreatesUser() {
+	suite.mockUserIdentityRepository.On("FindBySubject", "company", mock.Anything).Return(nil, errIdentityNotFound)
+	suite.mockUserRepository.On("FindByEmail", mock.Anything).Return(nil, apperror.NewNotFound("user not found"))
+	suite.mockUserIdentityRepository.On("CreateWithUser", mock.AnythingOfType("*entity.User"), mock.AnythingOfType("*entity.UserIdentity")).Return(nil)
+	suite.mockEmailVerificationUseCase.On("SendVerification", mock.AnythingOfType("*entity.User")).Return(nil)
+	suite.mockLoginCompleter.On("CompleteLogin", mock.AnythingOfType("*entity.User"), suite.client).Return(&LoginResult{}, nil)
+
+	state, code := suite.signInToLogin(tester.FakeOIDCAccount{Subject: "sub-1", Email: "verified@example.com", EmailVerified: true})
+	_, err := suite.useCase.Callback(context.Background(), state, code, suite.client)
+	suite.Require().Nil(err)
+	state, code = suite.signInToLogin(tester.FakeOIDCAccount{Subject: "sub-2", Email: "unverified@example.com", EmailVerified: false})
+	_, err = suite.useCase.Callback(context.Background(), state, code, suite.client)
+	suite.Require().Nil(err)
+
+	// パスワードは持たず、プロバイダーが確認したメールアドレスは確認済みにする
+	created := suite.mockUserIdentityRepository.Calls[1].Arguments.Get(0).(*entity.User)
+	suite.Assert().Equal(&entity.User{Email: "verified@example.com", EmailVerifiedAt: &suite.now}, created)
+	identity := suite.mockUserIdentityRepository.Calls[1].Arguments.Get(1).(*entity.UserIdentity)
+	suite.Assert().Equal("sub-1", identity.Subject)
+	// 確認されていないメールアドレスには確認メールを送る
+	suite.mockEmailVerificationUseCase.AssertNumberOfCalls(suite.T(), "SendVerification", 1)
+	suite.mockEmailVerificationUseCase.AssertCalled(suite.T(), "SendVerification", &entity.User{Email: "unverified@example.com"})
+}
+
+func (suite *OIDCUseCaseSuite) TestLoginWithoutEmail() {
+	suite.mockUserIdentityRepository.On("FindBySubject", "company", "sub-1").Return(nil, errIdentityNotFound)
+
+	state, code := suite.signInToLogin(tester.FakeOIDCAccount{Subject: "sub-1"})
+	_, err := suite.useCase.Callback(context.Background(), state, code, suite.client)
+	suite.Assert().ErrorIs(err, ErrOIDCEmailRequired)
+}
+
+func (suite *OIDCUseCaseSuite) TestCallbackInvalidState() {
+	suite.mockOIDCLoginStateRepository.On("Consume", security.HashToken("unknown")).Return(nil, apperror.NewNotFound("login state not found"))
+	_, err := suite.useCase.Callback(context.Background(), "unknown", "code", suite.client)
+	suite.Assert().ErrorIs(err, ErrInvalidOIDCState)
+
+	suite.mockOIDCLoginStateRepository.On("Consume", security.HashToken("expired")).
+		Return(&entity.OIDCLoginState{Provider: "company", ExpiresAt: suite.now}, nil)
+	_, err = suite.useCase.Callback(context.Background(), "expired", "code", suite.client)
+	suite.Assert().ErrorIs(err, ErrInvalidOIDCState)
+
+	// 設定から外したプロバイダー
+	suite.mockOIDCLoginStateRepository.On("Consume", security.HashToken("removed")).
+		Return(&entity.OIDCLoginState{Provider: "removed", ExpiresAt: suite.now.Add(time.Minute)}, nil)
+	_, err = suite.useCase.Callback(context.Background(), "removed", "code", suite.client)
+	suite.Assert().ErrorIs(err, ErrInvalidOIDCState)
+}
+
+func (suite *OIDCUseCaseSuite) TestCallbackAuthenticationFailed() {
+	state, code := suite.signInToLogin(tester.FakeOIDCAccount{Subject: "sub-1"})
+	_, err := suite.useCase.Callback(context.Background(), state, code+"x", suite.client)
+	suite.Assert().ErrorIs(err, ErrOIDCLoginFailed)
+
+	// ID トークンの対象者が違う
+	suite.fake.ModifyClaims = func(claims jwt.MapClaims) { claims["aud"] = "another-client" }
+	state, code = suite.signInToLogin(tester.FakeOIDCAccount{Subject: "sub-1"})
+	_, err = suite.useCase.Callback(context.Background(), state, code, suite.client)
+	suite.Assert().ErrorIs(err, ErrOIDCLoginFailed)
+	suite.mockUserIdentityRepository.AssertNotCalled(suite.T(), "FindBySubject", mock.Anything, mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestLink() {
+	suite.mockUserIdentityRepository.On("List", 7).Return([]*entity.UserIdentity{}, nil)
+	suite.mockUserIdentityRepository.On("FindBySubject", "company", "sub-1").Return(nil, errIdentityNotFound)
+	suite.mockUserIdentityRepository.On("Create", mock.AnythingOfType("*entity.UserIdentity")).Return(nil)
+
+	// 連携ではメールアドレスが違っても、確認されていなくてもよい
+	state, code := suite.signIn(tester.FakeOIDCAccount{Subject: "sub-1", Email: "other@example.com"},
+		func() (*OIDCAuthorization, error) {
+			return suite.useCase.StartLink(sessionContext(7, "session"), "company")
+		})
+	result, err := suite.useCase.Callback(context.Background(), state, code, suite.client)
+	suite.Require().Nil(err)
+	suite.Assert().Equal(&OIDCCallbackResult{Provider: "company"}, result)
+	suite.mockUserIdentityRepository.AssertCalled(suite.T(), "Create", &entity.UserIdentity{
+		UserID: 7, Provider: "company", Subject: "sub-1", Email: "other@example.com", CreatedAt: suite.now,
+	})
+	suite.mockLoginCompleter.AssertNotCalled(suite.T(), "CompleteLogin", mock.Anything, mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestLinkConflicts() {
+	suite.mockUserIdentityRepository.On("List", 7).Return([]*entity.UserIdentity{{UserID: 7, Provider: "company"}}, nil)
+	suite.mockUserIdentityRepository.On("List", 8).Return([]*entity.UserIdentity{}, nil)
+	suite.mockUserIdentityRepository.On("FindBySubject", "company", "sub-1").Return(&entity.UserIdentity{UserID: 7, Provider: "company", Subject: "sub-1"}, nil)
+
+	_, err := suite.useCase.StartLink(sessionContext(7, "session"), "company")
+	suite.Assert().ErrorIs(err, ErrProviderAlreadyLinked)
+	_, err = suite.useCase.StartLink(sessionContext(8, "session"), "unknown")
+	suite.Assert().ErrorIs(err, ErrUnknownOIDCProvider)
+
+	// 別のユーザーに連携済みのアカウント
+	state, code := suite.signIn(tester.FakeOIDCAccount{Subject: "sub-1"},
+		func() (*OIDCAuthorization, error) {
+			return suite.useCase.StartLink(sessionContext(8, "session"), "company")
+		})
+	_, err = suite.useCase.Callback(context.Background(), state, code, suite.client)
+	suite.Assert().ErrorIs(err, ErrIdentityAlreadyLinked)
+	suite.mockUserIdentityRepository.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestUnlink() {
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Password: "hash"}, nil)
+	suite.mockUserRepository.On("GetCurrentUser", 8).Return(&entity.User{ID: 8}, nil)
+	suite.mockUserIdentityRepository.On("List", 8).Return([]*entity.UserIdentity{{UserID: 8, Provider: "company"}}, nil)
+	suite.mockUserIdentityRepository.On("Delete", 7, "company").Return(nil)
+
+	suite.Assert().Nil(suite.useCase.Unlink(sessionContext(7, "session"), "company"))
+	// パスワードのないユーザーは最後の連携を解除できない
+	suite.Assert().ErrorIs(suite.useCase.Unlink(sessionContext(8, "session"), "company"), ErrLastSignInMethod)
+	suite.mockUserIdentityRepository.AssertNotCalled(suite.T(), "Delete", 8, mock.Anything)
+}
+
+func (suite *OIDCUseCaseSuite) TestIdentitiesRequireSession() {
+	ctx := accessTokenContext(7, entity.AccessTokenScopes...)
+	_, err := suite.useCase.StartLink(ctx, "company")
+	suite.Assert().ErrorIs(err, ErrSessionRequired)
+	_, err = suite.useCase.ListIdentities(ctx)
+	suite.Assert().ErrorIs(err, ErrSessionRequired)
+	suite.Assert().ErrorIs(suite.useCase.Unlink(ctx, "company"), ErrSessionRequired)
+
+	_, err = suite.useCase.ListIdentities(context.Background())
+	suite.Assert().ErrorIs(err, ErrUnauthenticated)
+	suite.mockUserIdentityRepository.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
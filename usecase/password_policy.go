@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// BcryptMaxPasswordBytes は bcrypt が扱えるパスワードの長さ。これより長いとハッシュ化に失敗する
+const BcryptMaxPasswordBytes = 72
+
+// BreachedPasswordChecker は漏洩したパスワードの一覧に password が含まれるかを確かめる
+type BreachedPasswordChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// PasswordPolicy はユーザーが設定できるパスワードの条件
+type PasswordPolicy struct {
+	// MinLength は文字数、MaxBytes は UTF-8 でのバイト数で数える
+	// MaxBytes が 0 または BcryptMaxPasswordBytes より大きい場合は BcryptMaxPasswordBytes にする
+	MinLength int
+	MaxBytes  int
+	// Breached が nil の場合は漏洩したパスワードかどうかを確かめない
+	Breached BreachedPasswordChecker
+}
+
+// Validate は email のユーザーが password を設定できるかを確かめる
+// 満たしていない条件は /password の FieldError としてまとめて返す
+func (p PasswordPolicy) Validate(password, email string) error {
+	var fields []apperror.FieldError
+	if utf8.RuneCountInString(password) < p.MinLength {
+		fields = append(fields, apperror.BodyField("/password", fmt.Sprintf("must be at least %d characters", p.MinLength)))
+	}
+	maxBytes := p.MaxBytes
+	if maxBytes <= 0 || maxBytes > BcryptMaxPasswordBytes {
+		maxBytes = BcryptMaxPasswordBytes
+	}
+	if len(password) > maxBytes {
+		fields = append(fields, apperror.BodyField("/password", fmt.Sprintf("must be at most %d bytes", maxBytes)))
+	}
+	if email != "" && strings.EqualFold(strings.TrimSpace(password), strings.TrimSpace(email)) {
+		fields = append(fields, apperror.BodyField("/password", "must not be the same as the email address"))
+	}
+	if len(fields) > 0 {
+		return apperror.NewValidation("password does not meet the requirements", fields...)
+	}
+
+	if p.Breached == nil {
+		return nil
+	}
+	breached, err := p.Breached.IsBreached(password)
+	if err != nil {
+		return err
+	}
+	if breached {
+		return apperror.NewValidation("password does not meet the requirements",
+			apperror.BodyField("/password", "has appeared in a data breach, choose a different password"))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/mailer"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// SHA-1("password123")
+const breachedPasswordList = "CBFDAC6008F9CAB4083784CBD1874F76618D2A97:250000\n"
+
+func TestPasswordPolicy(t *testing.T) {
+	breached, err := security.ReadBreachedPasswords(strings.NewReader(breachedPasswordList))
+	assert.NoError(t, err)
+	policy := PasswordPolicy{MinLength: 8, MaxBytes: 100, Breached: breached}
+
+	tests := map[string]struct {
+		password string
+		details  []string
+	}{
+		"valid":              {password: "correct horse battery staple"},
+		"empty":              {password: "", details: []string{"must be at least 8 characters"}},
+		"too short":          {password: "short", details: []string{"must be at least 8 characters"}},
+		"multibyte counts":   {password: "パスワードを長めに"},
+		"over bcrypt limit":  {password: strings.Repeat("a", 73), details: []string{"must be at most 72 bytes"}},
+		"bcrypt limit bytes": {password: strings.Repeat("あ", 25), details: []string{"must be at most 72 bytes"}},
+		"same as email":      {password: " User@Example.com", details: []string{"must not be the same as the email address"}},
+		"breached":           {password: "password123", details: []string{"has appeared in a data breach, choose a different password"}},
+	}
+	for name, tt := range tests {
+		err := policy.Validate(tt.password, "user@example.com")
+		if tt.details == nil {
+			assert.Nil(t, err, name)
+			continue
+		}
+		assert.True(t, apperror.IsValidation(err), name)
+		var details []string
+		for _, field := range apperror.FieldsOf(err) {
+			assert.Equal(t, "/password", field.Pointer, name)
+			details = append(details, field.Detail)
+		}
+		assert.Equal(t, tt.details, details, name)
+	}
+
+	// 一覧がなければ漏洩したパスワードかどうかは確かめない
+	assert.Nil(t, PasswordPolicy{MinLength: 8}.Validate("password123", "user@example.com"))
+}
+
+func (suite *UserUseCaseSuite) TestSignupPasswordPolicy() {
+	mockUserRepository := NewMockUserRepository()
+	suite.userUseCase = NewUserUseCase(mockUserRepository, NewMockRefreshTokenRepository(), NewMockSessionRepository(), NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(),
+		NewMockEmailVerificationUseCase(), NewMockTwoFactorUseCase(), newPassingLoginThrottle(), testPasswordHasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
+
+	_, err := suite.userUseCase.Signup(context.Background(), &entity.User{Email: "test@example.com", Password: "short"})
+	suite.Assert().True(apperror.IsValidation(err))
+	suite.Assert().Equal("/password", apperror.FieldsOf(err)[0].Pointer)
+	mockUserRepository.AssertNotCalled(suite.T(), "Signup", mock.Anything)
+}
+
+func (suite *PasswordResetUseCaseSuite) TestResetPasswordPolicy() {
+	record := &entity.PasswordResetToken{SingleUseToken: entity.SingleUseToken{ID: 1, UserID: 7, ExpiresAt: suite.now.Add(time.Minute)}}
+	suite.mockPasswordResetTokenRepository.On("FindByHash", security.HashToken("token")).Return(record, nil)
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com"}, nil)
+
+	// 条件を満たさない場合はトークンを使用済みにしない
+	err := suite.useCase.ResetPassword(context.Background(), "token", "user@example.com")
+	suite.Assert().True(apperror.IsValidation(err))
+	suite.Assert().Equal("/password", apperror.FieldsOf(err)[0].Pointer)
+	suite.mockPasswordResetTokenRepository.AssertNotCalled(suite.T(), "ResetPassword", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg"
+	"go-todo-app-clean-arch/pkg/logger"
+	"go-todo-app-clean-arch/pkg/mailer"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+var ErrInvalidPasswordResetToken = apperror.NewValidation("invalid or expired password reset token",
+	apperror.BodyField("/token", "the password reset link is invalid or has expired"))
+
+// PasswordResetConfig は再設定メールの設定
+// URL にはトークンをクエリ文字列 token として付けて送る
+type PasswordResetConfig struct {
+	URL string
+	TTL time.Duration
+	// ResendInterval の間は同じユーザーに再設定メールを送らない
+	ResendInterval time.Duration
+}
+
+type PasswordResetUseCase interface {
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
+}
+
+type passwordResetUseCase struct {
+	userRepository                gateway.UserRepository
+	passwordResetTokenRepository  gateway.PasswordResetTokenRepository
+	sessionRepository             gateway.SessionRepository
+	refreshTokenRepository        gateway.RefreshTokenRepository
+	personalAccessTokenRepository gateway.PersonalAccessTokenRepository
+	passwordHasher                security.PasswordHasher
+	config                        PasswordResetConfig
+	passwordPolicy                PasswordPolicy
+	clock                         pkg.Clock
+	// send はメールを送る。テストでは送ったメールを記録するように差し替える
+	send func(msg *mailer.Message)
+	// async はレスポンスの後に回す処理を実行する。テストでは同期的に実行するように差し替える
+	async func(job func())
+}
+
+func NewPasswordResetUseCase(
+	userRepository gateway.UserRepository,
+	passwordResetTokenRepository gateway.PasswordResetTokenRepository,
+	sessionRepository gateway.SessionRepository,
+	refreshTokenRepository gateway.RefreshTokenRepository,
+	personalAccessTokenRepository gateway.PersonalAccessTokenRepository,
+	m mailer.Mailer,
+	passwordHasher security.PasswordHasher,
+	config PasswordResetConfig,
+	passwordPolicy PasswordPolicy,
+) *passwordResetUseCase {
+	return &passwordResetUseCase{
+		userRepository:                userRepository,
+		passwordResetTokenRepository:  passwordResetTokenRepository,
+		sessionRepository:             sessionRepository,
+		refreshTokenRepository:        refreshTokenRepository,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		passwordHasher:                passwordHasher,
+		config:                        config,
+		passwordPolicy:                passwordPolicy,
+		clock:                         pkg.NewClock(),
+		send:                          sendAsync(m),
+		async:                         goAsync,
+	}
+}
+
+// ForgotPassword は email のユーザーに再設定メールを送る
+// 登録されていないアドレスでも同じ結果を返し、アカウントの有無が分からないようにする
+// 応答時間にも差が出ないよう、トークンの保存とメールの送信はレスポンスの後に行う
+func (u *passwordResetUseCase) ForgotPassword(ctx context.Context, email string) error {
+	user, err := u.userRepository.FindByEmail(ctx, email)
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	u.async(func() {
+		if err := u.sendResetLink(ctx, user); err != nil {
+			logger.Error("Failed to issue password reset token", "user_id", user.ID, "error", err.Error())
+		}
+	})
+	return nil
+}
+
+// sendResetLink は user の再設定トークンを発行してメールで送る
+// 前回から ResendInterval が経っていない場合は送らない
+func (u *passwordResetUseCase) sendResetLink(ctx context.Context, user *entity.User) error {
+	now := u.clock.Now()
+	latest, err := u.passwordResetTokenRepository.FindLatest(ctx, user.ID)
+	if err != nil && !apperror.IsNotFound(err) {
+		return err
+	}
+	if latest != nil && now.Sub(latest.CreatedAt) < u.config.ResendInterval {
+		return nil
+	}
+
+	token, err := security.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+	record := &entity.PasswordResetToken{
+		SingleUseToken: entity.SingleUseToken{
+			UserID:    user.ID,
+			TokenHash: security.HashToken(token),
+			ExpiresAt: now.Add(u.config.TTL),
+			CreatedAt: now,
+		},
+	}
+	if err := u.passwordResetTokenRepository.Create(ctx, record); err != nil {
+		return err
+	}
+
+	u.send(&mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Someone requested a password reset for your account.\n\n"+
+			"Open the link below within %s to choose a new password:\n%s\n\n"+
+			"If you did not request this, you can ignore this email. Your password will not change.\n",
+			humanizeDuration(u.config.TTL), linkWithToken(u.config.URL, token)),
+	})
+	return nil
+}
+
+// ResetPassword は再設定トークンを使用済みにしてパスワードを変える
+// パスワードを知っている第三者が使い続けられないよう、すべてのセッションとパーソナルアクセストークンを失効させる
+func (u *passwordResetUseCase) ResetPassword(ctx context.Context, token, password string) error {
+	record, err := u.passwordResetTokenRepository.FindByHash(ctx, security.HashToken(token))
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return ErrInvalidPasswordResetToken
+		}
+		return err
+	}
+	now := u.clock.Now()
+	if !record.IsUsable(now) {
+		return ErrInvalidPasswordResetToken
+	}
+	user, err := u.userRepository.GetCurrentUser(ctx, record.UserID)
+	if err != nil {
+		return err
+	}
+	if err := u.passwordPolicy.Validate(password, user.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := u.passwordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+	if err := u.passwordResetTokenRepository.ResetPassword(ctx, record, hashedPassword, now); err != nil {
+		if errors.Is(err, gateway.ErrPasswordResetTokenAlreadyUsed) {
+			return ErrInvalidPasswordResetToken
+		}
+		return err
+	}
+	if err := revokeSessions(ctx, u.sessionRepository, u.refreshTokenRepository, record.UserID, "", now); err != nil {
+		return err
+	}
+	return u.personalAccessTokenRepository.RevokeAll(ctx, record.UserID, now)
+}
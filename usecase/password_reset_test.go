@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/mailer"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type mockPasswordResetTokenRepository struct {
+	mock.Mock
+}
+
+func NewMockPasswordResetTokenRepository() *mockPasswordResetTokenRepository {
+	return new(mockPasswordResetTokenRepository)
+}
+
+func (m *mockPasswordResetTokenRepository) Create(ctx context.Context, token *entity.PasswordResetToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *mockPasswordResetTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PasswordResetToken), args.Error(1)
+}
+
+func (m *mockPasswordResetTokenRepository) FindLatest(ctx context.Context, userID int) (*entity.PasswordResetToken, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PasswordResetToken), args.Error(1)
+}
+
+func (m *mockPasswordResetTokenRepository) ResetPassword(ctx context.Context, token *entity.PasswordResetToken, hashedPassword string, now time.Time) error {
+	args := m.Called(token, hashedPassword, now)
+	return args.Error(0)
+}
+
+var testPasswordResetConfig = PasswordResetConfig{URL: "https://todo.example.com/password/reset?lang=ja", TTL: time.Hour, ResendInterval: time.Minute}
+
+type PasswordResetUseCaseSuite struct {
+	suite.Suite
+	useCase                           *passwordResetUseCase
+	mockUserRepository                *mockUserRepository
+	mockPasswordResetTokenRepository  *mockPasswordResetTokenRepository
+	mockSessionRepository             *mockSessionRepository
+	mockRefreshTokenRepository        *mockRefreshTokenRepository
+	mockPersonalAccessTokenRepository *mockPersonalAccessTokenRepository
+	sent                              []*mailer.Message
+	jobs                              []func()
+	now                               time.Time
+}
+
+func TestPasswordResetUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(PasswordResetUseCaseSuite))
+}
+
+func (suite *PasswordResetUseCaseSuite) SetupTest() {
+	suite.mockUserRepository = NewMockUserRepository()
+	suite.mockPasswordResetTokenRepository = NewMockPasswordResetTokenRepository()
+	suite.mockSessionRepository = NewMockSessionRepository()
+	suite.mockRefreshTokenRepository = NewMockRefreshTokenRepository()
+	suite.mockPersonalAccessTokenRepository = NewMockPersonalAccessTokenRepository()
+	suite.useCase = NewPasswordResetUseCase(suite.mockUserRepository, suite.mockPasswordResetTokenRepository,
+		suite.mockSessionRepository, suite.mockRefreshTokenRepository, suite.mockPersonalAccessTokenRepository, mailer.NewLogMailer("no-reply@example.com"), testPasswordHasher, testPasswordResetConfig, testPasswordPolicy)
+	suite.now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.useCase.clock = tester.NewMockClock(suite.now)
+	// 送信を待たずに内容を確認できるよう、送るメールを記録する
+	suite.sent = nil
+	suite.useCase.send = func(msg *mailer.Message) { suite.sent = append(suite.sent, msg) }
+	// レスポンスの後に回す処理は runJobs で実行する
+	suite.jobs = nil
+	suite.useCase.async = func(job func()) { suite.jobs = append(suite.jobs, job) }
+}
+
+func (suite *PasswordResetUseCaseSuite) runJobs() {
+	for _, job := range suite.jobs {
+		job()
+	}
+	suite.jobs = nil
+}
+
+func (suite *PasswordResetUseCaseSuite) TestForgotPassword() {
+	suite.mockUserRepository.On("FindByEmail", "user@example.com").Return(&entity.User{ID: 7, Email: "user@example.com"}, nil)
+	suite.mockPasswordResetTokenRepository.On("FindLatest", 7).Return(nil, apperror.NewNotFound("password reset token not found"))
+	suite.mockPasswordResetTokenRepository.On("Create", mock.AnythingOfType("*entity.PasswordResetToken")).Return(nil)
+
+	// 応答時間でアカウントの有無が分からないよう、トークンの保存と送信はレスポンスの後に行う
+	suite.Assert().Nil(suite.useCase.ForgotPassword(context.Background(), "user@example.com"))
+	suite.mockPasswordResetTokenRepository.AssertNotCalled(suite.T(), "Create", mock.Anything)
+	suite.runJobs()
+	suite.Require().Len(suite.sent, 1)
+	suite.Assert().Equal("user@example.com", suite.sent[0].To)
+	suite.Assert().Contains(suite.sent[0].Body, "within 1 hour")
+
+	// メールのリンクに含まれるトークンのハッシュだけを保存する
+	link := regexp.MustCompile(`https://\S+`).FindString(suite.sent[0].Body)
+	parsed, err := url.Parse(link)
+	suite.Require().Nil(err)
+	suite.Assert().Equal("/password/reset", parsed.Path)
+	suite.Assert().Equal("ja", parsed.Query().Get("lang"))
+	token := parsed.Query().Get("token")
+	suite.Assert().NotEmpty(token)
+
+	saved := suite.mockPasswordResetTokenRepository.Calls[1].Arguments.Get(0).(*entity.PasswordResetToken)
+	suite.Assert().Equal(7, saved.UserID)
+	suite.Assert().Equal(security.HashToken(token), saved.TokenHash)
+	suite.Assert().Equal(suite.now.Add(time.Hour), saved.ExpiresAt)
+	suite.Assert().Equal(suite.now, saved.CreatedAt)
+}
+
+func (suite *PasswordResetUseCaseSuite) TestForgotPasswordResendInterval() {
+	suite.mockUserRepository.On("FindByEmail", "user@example.com").Return(&entity.User{ID: 7, Email: "user@example.com"}, nil)
+	suite.mockPasswordResetTokenRepository.On("FindLatest", 7).Return(&entity.PasswordResetToken{SingleUseToken: entity.SingleUseToken{ID: 1, UserID: 7, CreatedAt: suite.now.Add(-30 * time.Second)}}, nil).Once()
+
+	// 間隔が空いていない間は、登録されていないアドレスと同じく何も送らずに成功を返す
+	suite.Assert().Nil(suite.useCase.ForgotPassword(context.Background(), "user@example.com"))
+	suite.runJobs()
+	suite.Assert().Empty(suite.sent)
+	suite.mockPasswordResetTokenRepository.AssertNotCalled(suite.T(), "Create", mock.Anything)
+
+	suite.mockPasswordResetTokenRepository.On("FindLatest", 7).Return(&entity.PasswordResetToken{SingleUseToken: entity.SingleUseToken{ID: 1, UserID: 7, CreatedAt: suite.now.Add(-time.Minute)}}, nil)
+	suite.mockPasswordResetTokenRepository.On("Create", mock.AnythingOfType("*entity.PasswordResetToken")).Return(nil)
+	suite.Assert().Nil(suite.useCase.ForgotPassword(context.Background(), "user@example.com"))
+	suite.runJobs()
+	suite.Assert().Len(suite.sent, 1)
+}
+
+func (suite *PasswordResetUseCaseSuite) TestForgotPasswordUnknownEmail() {
+	suite.mockUserRepository.On("FindByEmail", "missing@example.com").Return(nil, apperror.NewNotFound("user not found"))
+
+	// 登録済みのアドレスと同じく成功を返し、メールは送らない
+	suite.Assert().Nil(suite.useCase.ForgotPassword(context.Background(), "missing@example.com"))
+	suite.Assert().Empty(suite.jobs)
+	suite.Assert().Empty(suite.sent)
+	suite.mockPasswordResetTokenRepository.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *PasswordResetUseCaseSuite) TestResetPassword() {
+	record := &entity.PasswordResetToken{SingleUseToken: entity.SingleUseToken{ID: 1, UserID: 7, ExpiresAt: suite.now.Add(time.Minute)}}
+	suite.mockPasswordResetTokenRepository.On("FindByHash", security.HashToken("token")).Return(record, nil)
+	suite.mockPasswordResetTokenRepository.On("ResetPassword", record, mock.AnythingOfType("string"), suite.now).Return(nil)
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com"}, nil)
+	suite.mockSessionRepository.On("ListActive", 7, suite.now).Return([]*entity.Session{{ID: "phone"}, {ID: "laptop"}}, nil)
+	suite.mockSessionRepository.On("Revoke", 7, mock.Anything, suite.now).Return(nil)
+	suite.mockRefreshTokenRepository.On("RevokeFamily", mock.Anything, suite.now).Return(nil)
+	suite.mockPersonalAccessTokenRepository.On("RevokeAll", 7, suite.now).Return(nil)
+
+	suite.Assert().Nil(suite.useCase.ResetPassword(context.Background(), "token", "new-password"))
+	hashed := suite.mockPasswordResetTokenRepository.Calls[1].Arguments.String(1)
+	ok, _ := testPasswordHasher.Verify("new-password", hashed)
+	suite.Assert().True(ok)
+
+	// パスワードを変えたらすべてのセッションとパーソナルアクセストークンを失効させる
+	suite.mockSessionRepository.AssertCalled(suite.T(), "Revoke", 7, "phone", suite.now)
+	suite.mockSessionRepository.AssertCalled(suite.T(), "Revoke", 7, "laptop", suite.now)
+	suite.mockRefreshTokenRepository.AssertCalled(suite.T(), "RevokeFamily", "laptop", suite.now)
+	suite.mockPersonalAccessTokenRepository.AssertCalled(suite.T(), "RevokeAll", 7, suite.now)
+}
+
+func (suite *PasswordResetUseCaseSuite) TestResetPasswordInvalidToken() {
+	usedAt := suite.now.Add(-time.Minute)
+	raced := &entity.PasswordResetToken{SingleUseToken: entity.SingleUseToken{ID: 3, UserID: 7, ExpiresAt: suite.now.Add(time.Minute)}}
+	suite.mockPasswordResetTokenRepository.On("FindByHash", security.HashToken("unknown")).Return(nil, apperror.NewNotFound("password reset token not found"))
+	suite.mockPasswordResetTokenRepository.On("FindByHash", security.HashToken("expired")).Return(&entity.PasswordResetToken{SingleUseToken: entity.SingleUseToken{ID: 1, ExpiresAt: suite.now}}, nil)
+	suite.mockPasswordResetTokenRepository.On("FindByHash", security.HashToken("used")).Return(&entity.PasswordResetToken{SingleUseToken: entity.SingleUseToken{ID: 2, ExpiresAt: suite.now.Add(time.Minute), UsedAt: &usedAt}}, nil)
+	// 同時に使われて、先に別のリクエストがパスワードを変えた場合
+	suite.mockPasswordResetTokenRepository.On("FindByHash", security.HashToken("raced")).Return(raced, nil)
+	suite.mockPasswordResetTokenRepository.On("ResetPassword", raced, mock.Anything, suite.now).Return(gateway.ErrPasswordResetTokenAlreadyUsed)
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com"}, nil)
+
+	for _, token := range []string{"unknown", "expired", "used", "raced"} {
+		err := suite.useCase.ResetPassword(context.Background(), token, "new-password")
+		suite.Assert().ErrorIs(err, ErrInvalidPasswordResetToken, token)
+		suite.Assert().True(apperror.IsValidation(err))
+	}
+	suite.mockSessionRepository.AssertNotCalled(suite.T(), "ListActive", mock.Anything, mock.Anything)
+}
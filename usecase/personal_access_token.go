@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg"
+	"go-todo-app-clean-arch/pkg/logger"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// AccessTokenPrefix はパーソナルアクセストークンの先頭に付ける文字列
+// Bearer トークンの種類を見分けるためと、リポジトリなどに漏れたときに見つけやすくするため
+const AccessTokenPrefix = "todo_pat_"
+
+var (
+	ErrInvalidAccessToken = apperror.NewUnauthorized("invalid or expired personal access token")
+	ErrAccessTokenExpired = apperror.NewValidation("expiry must be in the future",
+		apperror.BodyField("/expires_at", "must be in the future"))
+)
+
+// CreatedAccessToken は作成したトークンと、その平文。平文は作成時にしか返せない
+type CreatedAccessToken struct {
+	Token  *entity.PersonalAccessToken
+	Secret string
+}
+
+// PersonalAccessTokenUseCase の Create・List・Revoke は ctx の主体（Principal）のトークンを扱い、ログインしたセッションでだけ使える
+type PersonalAccessTokenUseCase interface {
+	// Create はトークンを作る。expiresAt が nil の場合は期限のないトークンになる
+	Create(ctx context.Context, name string, scopes []string, expiresAt *time.Time) (*CreatedAccessToken, error)
+	List(ctx context.Context) ([]*entity.PersonalAccessToken, error)
+	Revoke(ctx context.Context, id int) error
+	// Authenticate は Bearer トークンとして送られた平文のトークンを検証し、最終利用日時を更新する
+	Authenticate(ctx context.Context, token string) (*entity.PersonalAccessToken, error)
+}
+
+type personalAccessTokenUseCase struct {
+	personalAccessTokenRepository gateway.PersonalAccessTokenRepository
+	clock                         pkg.Clock
+}
+
+func NewPersonalAccessTokenUseCase(personalAccessTokenRepository gateway.PersonalAccessTokenRepository) *personalAccessTokenUseCase {
+	return &personalAccessTokenUseCase{
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		clock:                         pkg.NewClock(),
+	}
+}
+
+func (u *personalAccessTokenUseCase) Create(ctx context.Context, name string, scopes []string, expiresAt *time.Time) (*CreatedAccessToken, error) {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, apperror.NewValidation("name is required", apperror.BodyField("/name", "must not be blank"))
+	}
+	normalized, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+	now := u.clock.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, ErrAccessTokenExpired
+	}
+
+	secret, err := security.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	secret = AccessTokenPrefix + secret
+	token := &entity.PersonalAccessToken{
+		UserID:    principal.UserID,
+		Name:      name,
+		TokenHash: security.HashToken(secret),
+		Scopes:    strings.Join(normalized, " "),
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	if err := u.personalAccessTokenRepository.Create(ctx, token); err != nil {
+		return nil, err
+	}
+	return &CreatedAccessToken{Token: token, Secret: secret}, nil
+}
+
+// normalizeScopes は重複を除き、entity.AccessTokenScopes の順に並べ替える
+func normalizeScopes(scopes []string) ([]string, error) {
+	requested := map[string]bool{}
+	for _, scope := range scopes {
+		if !entity.IsValidAccessTokenScope(scope) {
+			return nil, apperror.NewValidation("unknown scope: "+scope,
+				apperror.BodyField("/scopes", "must be one of "+strings.Join(entity.AccessTokenScopes, ", ")))
+		}
+		requested[scope] = true
+	}
+	if len(requested) == 0 {
+		return nil, apperror.NewValidation("at least one scope is required", apperror.BodyField("/scopes", "must not be empty"))
+	}
+
+	normalized := []string{}
+	for _, scope := range entity.AccessTokenScopes {
+		if requested[scope] {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
+
+func (u *personalAccessTokenUseCase) List(ctx context.Context) ([]*entity.PersonalAccessToken, error) {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.personalAccessTokenRepository.List(ctx, principal.UserID)
+}
+
+func (u *personalAccessTokenUseCase) Revoke(ctx context.Context, id int) error {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return err
+	}
+	return u.personalAccessTokenRepository.Revoke(ctx, principal.UserID, id, u.clock.Now())
+}
+
+func (u *personalAccessTokenUseCase) Authenticate(ctx context.Context, token string) (*entity.PersonalAccessToken, error) {
+	if !strings.HasPrefix(token, AccessTokenPrefix) {
+		return nil, ErrInvalidAccessToken
+	}
+	record, err := u.personalAccessTokenRepository.FindByHash(ctx, security.HashToken(token))
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return nil, ErrInvalidAccessToken
+		}
+		return nil, err
+	}
+
+	now := u.clock.Now()
+	if !record.IsUsable(now) {
+		return nil, ErrInvalidAccessToken
+	}
+	// セッションと同じく、リクエストごとには書き込まない
+	if record.LastUsedAt == nil || now.Sub(*record.LastUsedAt) >= sessionTouchInterval {
+		// 最終利用日時は表示用なので、更新に失敗してもリクエストは続ける
+		if err := u.personalAccessTokenRepository.Touch(ctx, record.ID, now); err != nil {
+			logger.Warn("Failed to update personal access token last used time", "token_id", record.ID, "error", err.Error())
+		} else {
+			record.LastUsedAt = &now
+		}
+	}
+	return record, nil
+}
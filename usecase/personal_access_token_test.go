@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type mockPersonalAccessTokenRepository struct {
+	mock.Mock
+}
+
+func NewMockPersonalAccessTokenRepository() *mockPersonalAccessTokenRepository {
+	return new(mockPersonalAccessTokenRepository)
+}
+
+func (m *mockPersonalAccessTokenRepository) Create(ctx context.Context, token *entity.PersonalAccessToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *mockPersonalAccessTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PersonalAccessToken), args.Error(1)
+}
+
+func (m *mockPersonalAccessTokenRepository) List(ctx context.Context, userID int) ([]*entity.PersonalAccessToken, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.PersonalAccessToken), args.Error(1)
+}
+
+func (m *mockPersonalAccessTokenRepository) Touch(ctx context.Context, id int, lastUsedAt time.Time) error {
+	args := m.Called(id, lastUsedAt)
+	return args.Error(0)
+}
+
+func (m *mockPersonalAccessTokenRepository) Revoke(ctx context.Context, userID, id int, now time.Time) error {
+	args := m.Called(userID, id, now)
+	return args.Error(0)
+}
+
+func (m *mockPersonalAccessTokenRepository) RevokeAll(ctx context.Context, userID int, now time.Time) error {
+	args := m.Called(userID, now)
+	return args.Error(0)
+}
+
+type PersonalAccessTokenUseCaseSuite struct {
+	suite.Suite
+	useCase                           *personalAccessTokenUseCase
+	mockPersonalAccessTokenRepository *mockPersonalAccessTokenRepository
+	now                               time.Time
+}
+
+func TestPersonalAccessTokenUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenUseCaseSuite))
+}
+
+func (suite *PersonalAccessTokenUseCaseSuite) SetupTest() {
+	suite.mockPersonalAccessTokenRepository = NewMockPersonalAccessTokenRepository()
+	suite.useCase = NewPersonalAccessTokenUseCase(suite.mockPersonalAccessTokenRepository)
+	suite.now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.useCase.clock = tester.NewMockClock(suite.now)
+}
+
+func (suite *PersonalAccessTokenUseCaseSuite) TestCreate() {
+	suite.mockPersonalAccessTokenRepository.On("Create", mock.AnythingOfType("*entity.PersonalAccessToken")).Return(nil)
+	expiresAt := suite.now.Add(30 * 24 * time.Hour)
+
+	created, err := suite.useCase.Create(sessionContext(7, "session"), " ci ", []string{"user:read", "tasks:read", "user:read"}, &expiresAt)
+	suite.Assert().Nil(err)
+	suite.Assert().True(strings.HasPrefix(created.Secret, AccessTokenPrefix))
+
+	// 平文は返すだけで、保存するのはハッシュ
+	saved := suite.mockPersonalAccessTokenRepository.Calls[0].Arguments.Get(0).(*entity.PersonalAccessToken)
+	suite.Assert().Equal(7, saved.UserID)
+	suite.Assert().Equal("ci", saved.Name)
+	suite.Assert().Equal(security.HashToken(created.Secret), saved.TokenHash)
+	suite.Assert().Equal("tasks:read user:read", saved.Scopes)
+	suite.Assert().Equal(&expiresAt, saved.ExpiresAt)
+	suite.Assert().Equal(suite.now, saved.CreatedAt)
+}
+
+func (suite *PersonalAccessTokenUseCaseSuite) TestCreateInvalid() {
+	past := suite.now.Add(-time.Minute)
+	tests := map[string]struct {
+		name      string
+		scopes    []string
+		expiresAt *time.Time
+		pointer   string
+	}{
+		"blank name":    {name: " ", scopes: []string{"tasks:read"}, pointer: "/name"},
+		"no scopes":     {name: "ci", scopes: nil, pointer: "/scopes"},
+		"unknown scope": {name: "ci", scopes: []string{"tasks:read", "admin"}, pointer: "/scopes"},
+		"expired":       {name: "ci", scopes: []string{"tasks:read"}, expiresAt: &past, pointer: "/expires_at"},
+	}
+	for name, tt := range tests {
+		_, err := suite.useCase.Create(sessionContext(7, "session"), tt.name, tt.scopes, tt.expiresAt)
+		suite.Assert().True(apperror.IsValidation(err), name)
+		suite.Require().Len(apperror.FieldsOf(err), 1, name)
+		suite.Assert().Equal(tt.pointer, apperror.FieldsOf(err)[0].Pointer, name)
+	}
+	suite.mockPersonalAccessTokenRepository.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *PersonalAccessTokenUseCaseSuite) TestRequiresSession() {
+	// トークンでトークンを発行・失効させることはできない
+	ctx := accessTokenContext(7, entity.AccessTokenScopes...)
+	_, err := suite.useCase.Create(ctx, "ci", []string{"tasks:read"}, nil)
+	suite.Assert().ErrorIs(err, ErrSessionRequired)
+	_, err = suite.useCase.List(ctx)
+	suite.Assert().ErrorIs(err, ErrSessionRequired)
+	suite.Assert().ErrorIs(suite.useCase.Revoke(ctx, 3), ErrSessionRequired)
+
+	_, err = suite.useCase.List(context.Background())
+	suite.Assert().ErrorIs(err, ErrUnauthenticated)
+	suite.mockPersonalAccessTokenRepository.AssertNotCalled(suite.T(), "Create", mock.Anything)
+	suite.mockPersonalAccessTokenRepository.AssertNotCalled(suite.T(), "Revoke", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *PersonalAccessTokenUseCaseSuite) TestAuthenticate() {
+	secret := AccessTokenPrefix + "secret"
+	record := &entity.PersonalAccessToken{ID: 3, UserID: 7, Scopes: "tasks:read"}
+	suite.mockPersonalAccessTokenRepository.On("FindByHash", security.HashToken(secret)).Return(record, nil)
+	suite.mockPersonalAccessTokenRepository.On("Touch", 3, suite.now).Return(nil)
+
+	token, err := suite.useCase.Authenticate(context.Background(), secret)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(7, token.UserID)
+	suite.Assert().Equal(&suite.now, token.LastUsedAt)
+
+	// 最終利用日時は間隔を空けてしか更新しない
+	_, err = suite.useCase.Authenticate(context.Background(), secret)
+	suite.Assert().Nil(err)
+	suite.mockPersonalAccessTokenRepository.AssertNumberOfCalls(suite.T(), "Touch", 1)
+}
+
+func (suite *PersonalAccessTokenUseCaseSuite) TestAuthenticateInvalid() {
+	expiredAt := suite.now
+	revokedAt := suite.now.Add(-time.Minute)
+	suite.mockPersonalAccessTokenRepository.On("FindByHash", security.HashToken(AccessTokenPrefix+"unknown")).
+		Return(nil, apperror.NewNotFound("personal access token not found"))
+	suite.mockPersonalAccessTokenRepository.On("FindByHash", security.HashToken(AccessTokenPrefix+"expired")).
+		Return(&entity.PersonalAccessToken{ID: 1, ExpiresAt: &expiredAt}, nil)
+	suite.mockPersonalAccessTokenRepository.On("FindByHash", security.HashToken(AccessTokenPrefix+"revoked")).
+		Return(&entity.PersonalAccessToken{ID: 2, RevokedAt: &revokedAt}, nil)
+
+	for _, token := range []string{"no-prefix", AccessTokenPrefix + "unknown", AccessTokenPrefix + "expired", AccessTokenPrefix + "revoked"} {
+		_, err := suite.useCase.Authenticate(context.Background(), token)
+		suite.Assert().ErrorIs(err, ErrInvalidAccessToken, token)
+	}
+	suite.mockPersonalAccessTokenRepository.AssertNotCalled(suite.T(), "FindByHash", security.HashToken("no-prefix"))
+	suite.mockPersonalAccessTokenRepository.AssertNotCalled(suite.T(), "Touch", mock.Anything, mock.Anything)
+}
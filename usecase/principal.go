@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+var (
+	ErrUnauthenticated   = apperror.NewUnauthorized("authentication required")
+	ErrInsufficientScope = apperror.NewForbidden("personal access token does not have the required scope")
+	ErrSessionRequired   = apperror.NewForbidden("this endpoint cannot be used with a personal access token")
+)
+
+// AuthMethod はリクエストを認証した方法
+type AuthMethod string
+
+const (
+	// AuthMethodCookie は auth_token Cookie のアクセストークン
+	AuthMethodCookie AuthMethod = "cookie"
+	// AuthMethodBearer は Authorization: Bearer ヘッダーで送られたアクセストークン
+	AuthMethodBearer AuthMethod = "bearer"
+	// AuthMethodAccessToken はパーソナルアクセストークン
+	AuthMethodAccessToken AuthMethod = "access_token"
+)
+
+// Principal は認証したリクエストの主体。認証ミドルウェアが作り、context.Context に入れてユースケースに渡す
+type Principal struct {
+	UserID int
+	// SessionID はログインのセッション。パーソナルアクセストークンの場合は空
+	SessionID string
+	// Scopes はパーソナルアクセストークンに許可したスコープ。ログインしたセッションはすべての操作ができる
+	Scopes []string
+	Method AuthMethod
+}
+
+// IsSession はログインで発行したアクセストークンで認証したかを返す
+func (p *Principal) IsSession() bool {
+	return p.Method != AuthMethodAccessToken
+}
+
+// HasScope は scope の操作が許可されているかを返す
+func (p *Principal) HasScope(scope string) bool {
+	return p.IsSession() || slices.Contains(p.Scopes, scope)
+}
+
+type principalKey struct{}
+
+// WithPrincipal は principal を入れた ctx を返す
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext は ctx の主体を返す。認証していないリクエストでは false を返す
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// authorize は ctx の主体に scope の操作が許可されていれば、その主体を返す
+func authorize(ctx context.Context, scope string) (*Principal, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !principal.HasScope(scope) {
+		// errors.Is(err, ErrInsufficientScope) で判定できるようにしつつ、足りないスコープを伝える
+		return nil, &apperror.Error{
+			Kind:    ErrInsufficientScope.Kind,
+			Message: ErrInsufficientScope.Message + ": " + scope,
+			Err:     ErrInsufficientScope,
+		}
+	}
+	return principal, nil
+}
+
+// authorizeSession は ctx の主体がログインしたセッションであれば、その主体を返す
+// セッションやアカウントの管理など、パーソナルアクセストークンには許さない操作に使う
+func authorizeSession(ctx context.Context) (*Principal, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !principal.IsSession() {
+		return nil, ErrSessionRequired
+	}
+	return principal, nil
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// sessionContext はログインしたセッションで userID が認証したリクエストの ctx を返す
+func sessionContext(userID int, sessionID string) context.Context {
+	return WithPrincipal(context.Background(), &Principal{UserID: userID, SessionID: sessionID, Method: AuthMethodCookie})
+}
+
+// accessTokenContext はパーソナルアクセストークンで userID が認証したリクエストの ctx を返す
+func accessTokenContext(userID int, scopes ...string) context.Context {
+	return WithPrincipal(context.Background(), &Principal{UserID: userID, Scopes: scopes, Method: AuthMethodAccessToken})
+}
+
+func TestAuthorize(t *testing.T) {
+	principal, err := authorize(sessionContext(1, "session"), entity.ScopeTasksWrite)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, principal.UserID)
+
+	// パーソナルアクセストークンは許可したスコープだけ
+	_, err = authorize(accessTokenContext(1, entity.ScopeTasksRead), entity.ScopeTasksRead)
+	assert.NoError(t, err)
+	_, err = authorize(accessTokenContext(1, entity.ScopeTasksRead), entity.ScopeTasksWrite)
+	assert.ErrorIs(t, err, ErrInsufficientScope)
+	assert.True(t, apperror.IsForbidden(err))
+
+	_, err = authorize(context.Background(), entity.ScopeTasksRead)
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+	_, err = authorize(WithPrincipal(context.Background(), nil), entity.ScopeTasksRead)
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+}
+
+func TestAuthorizeSession(t *testing.T) {
+	principal, err := authorizeSession(WithPrincipal(context.Background(), &Principal{UserID: 1, SessionID: "session", Method: AuthMethodBearer}))
+	assert.NoError(t, err)
+	assert.Equal(t, "session", principal.SessionID)
+
+	_, err = authorizeSession(accessTokenContext(1, entity.AccessTokenScopes...))
+	assert.ErrorIs(t, err, ErrSessionRequired)
+	_, err = authorizeSession(context.Background())
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+}
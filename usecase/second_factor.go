@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+var ErrInvalidLoginChallenge = apperror.NewUnauthorized("login challenge is invalid or has expired")
+
+// LoginResult は Login の結果。Tokens と Challenge のどちらか一方だけを持つ
+type LoginResult struct {
+	Tokens *TokenPair
+	// Challenge は 2 要素目の入力が必要な場合に返す
+	Challenge *SecondFactorChallenge
+}
+
+// SecondFactorChallenge は 2 要素目の入力を待っているログイン
+// Token を 2 要素目のコードと一緒に LoginTOTP に渡すとログインが完了する
+type SecondFactorChallenge struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+func (u *userUseCase) createChallenge(ctx context.Context, userID int) (*SecondFactorChallenge, error) {
+	token, err := security.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	now := u.clock.Now()
+	challenge := &entity.LoginChallenge{
+		SingleUseToken: entity.SingleUseToken{
+			UserID:    userID,
+			TokenHash: security.HashToken(token),
+			ExpiresAt: now.Add(u.tokenConfig.ChallengeTTL),
+			CreatedAt: now,
+		},
+	}
+	if err := u.loginChallengeRepository.Create(ctx, challenge); err != nil {
+		return nil, err
+	}
+	return &SecondFactorChallenge{Token: token, ExpiresAt: challenge.ExpiresAt}, nil
+}
+
+// LoginTOTP は Login が返したチャレンジと 2 要素目のコード（TOTP かリカバリーコード）でログインを完了する
+// コードを誤れるのはチャレンジごとに entity.LoginChallengeMaxAttempts 回までで、使い切ったらパスワードから入力し直す
+func (u *userUseCase) LoginTOTP(ctx context.Context, challengeToken string, code string, client ClientInfo) (*TokenPair, error) {
+	challenge, err := u.loginChallengeRepository.FindByHash(ctx, security.HashToken(challengeToken))
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return nil, ErrInvalidLoginChallenge
+		}
+		return nil, err
+	}
+	if !challenge.IsUsable(u.clock.Now()) {
+		return nil, ErrInvalidLoginChallenge
+	}
+	if err := u.loginChallengeRepository.RecordAttempt(ctx, challenge); err != nil {
+		if errors.Is(err, gateway.ErrLoginChallengeUnusable) {
+			return nil, ErrInvalidLoginChallenge
+		}
+		return nil, err
+	}
+
+	if err := u.twoFactor.VerifyCode(ctx, challenge.UserID, code); err != nil {
+		// チャレンジの作成後に 2 要素認証を無効にした場合は、パスワードから入力し直す
+		if errors.Is(err, ErrTOTPNotEnabled) {
+			return nil, ErrInvalidLoginChallenge
+		}
+		return nil, err
+	}
+	if err := u.loginChallengeRepository.Consume(ctx, challenge, u.clock.Now()); err != nil {
+		if errors.Is(err, gateway.ErrLoginChallengeUnusable) {
+			return nil, ErrInvalidLoginChallenge
+		}
+		return nil, err
+	}
+	return u.startSession(ctx, challenge.UserID, client)
+}
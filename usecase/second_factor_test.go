@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/mailer"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type mockLoginChallengeRepository struct {
+	mock.Mock
+}
+
+func NewMockLoginChallengeRepository() *mockLoginChallengeRepository {
+	return new(mockLoginChallengeRepository)
+}
+
+func (m *mockLoginChallengeRepository) Create(ctx context.Context, challenge *entity.LoginChallenge) error {
+	args := m.Called(challenge)
+	return args.Error(0)
+}
+
+func (m *mockLoginChallengeRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.LoginChallenge, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.LoginChallenge), args.Error(1)
+}
+
+func (m *mockLoginChallengeRepository) RecordAttempt(ctx context.Context, challenge *entity.LoginChallenge) error {
+	args := m.Called(challenge)
+	return args.Error(0)
+}
+
+func (m *mockLoginChallengeRepository) Consume(ctx context.Context, challenge *entity.LoginChallenge, now time.Time) error {
+	args := m.Called(challenge, now)
+	return args.Error(0)
+}
+
+// newSecondFactorUseCase は時刻を now に固定し、2 要素認証に関わる依存をモックにする
+func (suite *UserUseCaseSuite) newSecondFactorUseCase(now time.Time) (*mockUserRepository, *mockLoginChallengeRepository, *mockTwoFactorUseCase, *mockSessionRepository) {
+	mockUserRepository := NewMockUserRepository()
+	mockLoginChallengeRepository := NewMockLoginChallengeRepository()
+	mockTwoFactorUseCase := NewMockTwoFactorUseCase()
+	mockRefreshTokenRepository := NewMockRefreshTokenRepository()
+	mockSessionRepository := NewMockSessionRepository()
+	mockRefreshTokenRepository.On("Create", mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
+	mockSessionRepository.On("Create", mock.AnythingOfType("*entity.Session")).Return(nil)
+	tokenConfig := testTokenConfig
+	tokenConfig.ChallengeTTL = 5 * time.Minute
+	suite.userUseCase = NewUserUseCase(mockUserRepository, mockRefreshTokenRepository, mockSessionRepository, NewMockPersonalAccessTokenRepository(), mockLoginChallengeRepository,
+		NewMockEmailVerificationUseCase(), mockTwoFactorUseCase, newPassingLoginThrottle(), testPasswordHasher, tokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
+	suite.userUseCase.clock = tester.NewMockClock(now)
+	return mockUserRepository, mockLoginChallengeRepository, mockTwoFactorUseCase, mockSessionRepository
+}
+
+func (suite *UserUseCaseSuite) TestLoginSecondFactorRequired() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockUserRepository, mockLoginChallengeRepository, mockTwoFactorUseCase, mockSessionRepository := suite.newSecondFactorUseCase(now)
+	hashedPassword, _ := testPasswordHasher.Hash("password123")
+	mockUserRepository.On("FindByEmail", "test@example.com").Return(&entity.User{ID: 7, Email: "test@example.com", Password: hashedPassword}, nil)
+	mockTwoFactorUseCase.On("IsEnabled", 7).Return(true, nil)
+	mockLoginChallengeRepository.On("Create", mock.AnythingOfType("*entity.LoginChallenge")).Return(nil)
+
+	result, err := suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: "test@example.com", Password: "password123"}, ClientInfo{})
+	suite.Assert().Nil(err)
+	// 2 要素目の入力が済むまでセッションもトークンも作らない
+	suite.Assert().Nil(result.Tokens)
+	suite.Require().NotNil(result.Challenge)
+	suite.Assert().Equal(now.Add(5*time.Minute), result.Challenge.ExpiresAt)
+	mockSessionRepository.AssertNotCalled(suite.T(), "Create", mock.Anything)
+
+	saved := mockLoginChallengeRepository.Calls[0].Arguments.Get(0).(*entity.LoginChallenge)
+	suite.Assert().Equal(7, saved.UserID)
+	suite.Assert().Equal(security.HashToken(result.Challenge.Token), saved.TokenHash)
+}
+
+func (suite *UserUseCaseSuite) TestLoginTOTP() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, mockLoginChallengeRepository, mockTwoFactorUseCase, mockSessionRepository := suite.newSecondFactorUseCase(now)
+	challenge := &entity.LoginChallenge{SingleUseToken: entity.SingleUseToken{ID: 1, UserID: 7, ExpiresAt: now.Add(time.Minute)}}
+	mockLoginChallengeRepository.On("FindByHash", security.HashToken("challenge")).Return(challenge, nil)
+	mockLoginChallengeRepository.On("RecordAttempt", challenge).Return(nil)
+	mockLoginChallengeRepository.On("Consume", challenge, now).Return(nil)
+	mockTwoFactorUseCase.On("VerifyCode", 7, "000000").Return(ErrInvalidTOTPCode)
+	mockTwoFactorUseCase.On("VerifyCode", 7, "123456").Return(nil)
+
+	// コードを誤ってもチャレンジは使用済みにせず、入力し直せる
+	_, err := suite.userUseCase.LoginTOTP(context.Background(), "challenge", "000000", ClientInfo{})
+	suite.Assert().ErrorIs(err, ErrInvalidTOTPCode)
+	mockLoginChallengeRepository.AssertNotCalled(suite.T(), "Consume", mock.Anything, mock.Anything)
+
+	tokens, err := suite.userUseCase.LoginTOTP(context.Background(), "challenge", "123456", ClientInfo{IPAddress: "192.0.2.1"})
+	suite.Assert().Nil(err)
+	suite.Assert().NotEmpty(tokens.AccessToken)
+	mockLoginChallengeRepository.AssertNumberOfCalls(suite.T(), "RecordAttempt", 2)
+	session := mockSessionRepository.Calls[0].Arguments.Get(0).(*entity.Session)
+	suite.Assert().Equal(7, session.UserID)
+	suite.Assert().Equal("192.0.2.1", session.IPAddress)
+}
+
+func (suite *UserUseCaseSuite) TestLoginTOTPInvalidChallenge() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, mockLoginChallengeRepository, mockTwoFactorUseCase, _ := suite.newSecondFactorUseCase(now)
+	exhausted := &entity.LoginChallenge{SingleUseToken: entity.SingleUseToken{ID: 3, UserID: 7, ExpiresAt: now.Add(time.Minute)}}
+	disabled := &entity.LoginChallenge{SingleUseToken: entity.SingleUseToken{ID: 4, UserID: 8, ExpiresAt: now.Add(time.Minute)}}
+	mockLoginChallengeRepository.On("FindByHash", security.HashToken("unknown")).Return(nil, apperror.NewNotFound("login challenge not found"))
+	mockLoginChallengeRepository.On("FindByHash", security.HashToken("expired")).Return(&entity.LoginChallenge{SingleUseToken: entity.SingleUseToken{ID: 1, UserID: 7, ExpiresAt: now}}, nil)
+	mockLoginChallengeRepository.On("FindByHash", security.HashToken("locked")).Return(&entity.LoginChallenge{SingleUseToken: entity.SingleUseToken{ID: 2, UserID: 7, ExpiresAt: now.Add(time.Minute)}, Attempts: entity.LoginChallengeMaxAttempts}, nil)
+	// 同時に入力されて、先に別のリクエストが回数を使い切った場合
+	mockLoginChallengeRepository.On("FindByHash", security.HashToken("exhausted")).Return(exhausted, nil)
+	mockLoginChallengeRepository.On("RecordAttempt", exhausted).Return(gateway.ErrLoginChallengeUnusable)
+	// チャレンジの作成後に 2 要素認証を無効にした場合
+	mockLoginChallengeRepository.On("FindByHash", security.HashToken("disabled")).Return(disabled, nil)
+	mockLoginChallengeRepository.On("RecordAttempt", disabled).Return(nil)
+	mockTwoFactorUseCase.On("VerifyCode", 8, "123456").Return(ErrTOTPNotEnabled)
+
+	for _, token := range []string{"unknown", "expired", "locked", "exhausted", "disabled"} {
+		_, err := suite.userUseCase.LoginTOTP(context.Background(), token, "123456", ClientInfo{})
+		suite.Assert().ErrorIs(err, ErrInvalidLoginChallenge, token)
+		suite.Assert().True(apperror.IsUnauthorized(err))
+	}
+	mockTwoFactorUseCase.AssertNumberOfCalls(suite.T(), "VerifyCode", 1)
+}
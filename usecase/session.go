@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/logger"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+const (
+	// 最終利用日時の更新はこの間隔より細かくしない。リクエストごとに書き込まないようにするため
+	sessionTouchInterval = time.Minute
+	// sessions.user_agent の長さ
+	maxUserAgentLength = 512
+)
+
+var ErrSessionRevoked = apperror.NewUnauthorized("session has been revoked")
+
+// ClientInfo はログイン・再発行したクライアントの情報。セッション一覧で表示する
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+func (c ClientInfo) applyTo(session *entity.Session) {
+	userAgent := c.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	session.UserAgent = userAgent
+	session.IPAddress = c.IPAddress
+}
+
+func (u *userUseCase) ListSessions(ctx context.Context) ([]*entity.Session, error) {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.sessionRepository.ListActive(ctx, principal.UserID, u.clock.Now())
+}
+
+// ValidateSession はアクセストークンの jti が有効なセッションかを確認し、最終利用日時を更新する
+func (u *userUseCase) ValidateSession(ctx context.Context, userID int, sessionID string) error {
+	session, err := u.sessionRepository.FindByID(ctx, sessionID)
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return ErrSessionRevoked
+		}
+		return err
+	}
+
+	now := u.clock.Now()
+	if session.UserID != userID || !session.IsActive(now) {
+		return ErrSessionRevoked
+	}
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		// 最終利用日時は表示用なので、更新に失敗してもリクエストは続ける
+		if err := u.sessionRepository.Touch(ctx, sessionID, now); err != nil {
+			logger.Warn("Failed to update session last seen time", "session_id", sessionID, "error", err.Error())
+		}
+	}
+	return nil
+}
+
+// RevokeSession は ctx の主体のセッションを 1 つ失効させる。発行済みのリフレッシュトークンも使えなくする
+func (u *userUseCase) RevokeSession(ctx context.Context, sessionID string) error {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return err
+	}
+	now := u.clock.Now()
+	if err := u.sessionRepository.Revoke(ctx, principal.UserID, sessionID, now); err != nil {
+		return err
+	}
+	return u.refreshTokenRepository.RevokeFamily(ctx, sessionID, now)
+}
+
+// RevokeOtherSessions は ctx の主体のセッション以外をすべて失効させる（他の端末からログアウト）
+func (u *userUseCase) RevokeOtherSessions(ctx context.Context) error {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return err
+	}
+	return revokeSessions(ctx, u.sessionRepository, u.refreshTokenRepository, principal.UserID, principal.SessionID, u.clock.Now())
+}
+
+// revokeSession はセッションとリフレッシュトークンを失効させる。既に失効している場合もエラーにしない
+func (u *userUseCase) revokeSession(ctx context.Context, userID int, sessionID string, now time.Time) error {
+	return revokeSession(ctx, u.sessionRepository, u.refreshTokenRepository, userID, sessionID, now)
+}
+
+func revokeSession(ctx context.Context, sessions gateway.SessionRepository, refreshTokens gateway.RefreshTokenRepository, userID int, sessionID string, now time.Time) error {
+	if err := sessions.Revoke(ctx, userID, sessionID, now); err != nil && !apperror.IsNotFound(err) {
+		return err
+	}
+	return refreshTokens.RevokeFamily(ctx, sessionID, now)
+}
+
+// revokeSessions は userID のセッションのうち exceptID 以外をすべて失効させる。exceptID が空の場合はすべて失効させる
+func revokeSessions(ctx context.Context, sessions gateway.SessionRepository, refreshTokens gateway.RefreshTokenRepository, userID int, exceptID string, now time.Time) error {
+	active, err := sessions.ListActive(ctx, userID, now)
+	if err != nil {
+		return err
+	}
+	for _, session := range active {
+		if session.ID == exceptID {
+			continue
+		}
+		if err := revokeSession(ctx, sessions, refreshTokens, userID, session.ID, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,44 +1,261 @@
 package usecase
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
 	"go-todo-app-clean-arch/adapter/gateway"
 	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+const (
+	DefaultTaskPageLimit = 50
+	MaxTaskPageLimit     = 100
+	// MaxTaskTitleLength は tasks.title（VARCHAR(255)）に入る文字数
+	MaxTaskTitleLength = 255
+)
+
+var (
+	ErrInvalidTaskTitle    = apperror.NewValidation("invalid task title")
+	ErrInvalidTaskStatus   = apperror.NewValidation("invalid task status")
+	ErrInvalidTaskPriority = apperror.NewValidation("invalid task priority")
+	ErrInvalidTaskQuery    = apperror.NewValidation("invalid task query")
+	ErrTaskLimitUnverified = apperror.NewForbidden("verify your email address to create more tasks")
 )
 
+// TaskUseCase は ctx の主体（Principal）のタスクを扱う
+// パーソナルアクセストークンでは、読み取りに tasks:read、変更に tasks:write のスコープが必要
 type TaskUseCase interface {
-	Create(task *entity.Task) (*entity.Task, error)
-	Get(userId int, taskId int) (*entity.Task, error)
-	GetAllTasks(userId int) ([]*entity.Task, error)
-	Save(task *entity.Task, userId int, taskId int) (*entity.Task, error)
-	Delete(taskId int, userId int) error
+	Create(ctx context.Context, task *entity.Task) (*entity.Task, error)
+	Get(ctx context.Context, taskId int) (*entity.Task, error)
+	List(ctx context.Context, query *entity.TaskQuery) (*entity.TaskPage, error)
+	Save(ctx context.Context, update *entity.TaskUpdate, taskId int) (*entity.Task, error)
+	Delete(ctx context.Context, taskId int) error
 }
 
 type taskUseCase struct {
-	taskRepository gateway.TaskRepository
+	taskRepository     gateway.TaskRepository
+	userRepository     gateway.UserRepository
+	verificationPolicy EmailVerificationPolicy
+	clock              pkg.Clock
 }
 
-func NewTaskUseCase(taskRepository gateway.TaskRepository) *taskUseCase {
+func NewTaskUseCase(taskRepository gateway.TaskRepository, userRepository gateway.UserRepository, verificationPolicy EmailVerificationPolicy) *taskUseCase {
 	return &taskUseCase{
-		taskRepository: taskRepository,
+		taskRepository:     taskRepository,
+		userRepository:     userRepository,
+		verificationPolicy: verificationPolicy,
+		clock:              pkg.NewClock(),
+	}
+}
+
+func (t *taskUseCase) Create(ctx context.Context, task *entity.Task) (*entity.Task, error) {
+	principal, err := authorize(ctx, entity.ScopeTasksWrite)
+	if err != nil {
+		return nil, err
+	}
+	task.UserID = principal.UserID
+	if task.Status == "" {
+		task.Status = entity.TaskStatusTodo
+	}
+	if task.Priority == 0 {
+		task.Priority = entity.TaskPriorityMedium
+	}
+	if err := validateTask(task); err != nil {
+		return nil, err
+	}
+	if err := t.checkTaskLimit(ctx, task.UserID); err != nil {
+		return nil, err
+	}
+
+	// 完了日時はクライアントから受け取らず、ステータスから決める
+	status := task.Status
+	task.Status = ""
+	task.CompletedAt = nil
+	task.SetStatus(status, t.clock.Now())
+
+	return t.taskRepository.Create(ctx, task)
+}
+
+// checkTaskLimit はメールアドレスが未確認のユーザーが上限を超えてタスクを作れないようにする
+func (t *taskUseCase) checkTaskLimit(ctx context.Context, userId int) error {
+	if t.verificationPolicy.Mode != UnverifiedPolicyLimit {
+		return nil
+	}
+	user, err := t.userRepository.GetCurrentUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+	count, err := t.taskRepository.Count(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if count >= int64(t.verificationPolicy.TaskLimit) {
+		return ErrTaskLimitUnverified
+	}
+	return nil
+}
+
+func (t *taskUseCase) Get(ctx context.Context, task_id int) (*entity.Task, error) {
+	principal, err := authorize(ctx, entity.ScopeTasksRead)
+	if err != nil {
+		return nil, err
+	}
+	return t.taskRepository.Get(ctx, principal.UserID, task_id)
+}
+
+// List は未指定の並び替え条件・件数に既定値を補い、検証した上で 1 ページ分のタスクを返す
+// query.UserID は ctx の主体で上書きする
+func (t *taskUseCase) List(ctx context.Context, query *entity.TaskQuery) (*entity.TaskPage, error) {
+	principal, err := authorize(ctx, entity.ScopeTasksRead)
+	if err != nil {
+		return nil, err
+	}
+	query.UserID = principal.UserID
+	if query.SortField == "" {
+		query.SortField = entity.TaskSortCreatedAt
+	}
+	if query.SortDirection == "" {
+		query.SortDirection = entity.SortAsc
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultTaskPageLimit
+	}
+
+	if !query.SortField.IsValid() {
+		return nil, invalidTaskQuery("sort", fmt.Sprintf("unknown sort field %q", query.SortField))
 	}
+	if !query.SortDirection.IsValid() {
+		return nil, invalidTaskQuery("order", fmt.Sprintf("unknown sort direction %q", query.SortDirection))
+	}
+	if query.Limit < 0 || query.Limit > MaxTaskPageLimit {
+		return nil, invalidTaskQuery("limit", fmt.Sprintf("limit must be between 1 and %d", MaxTaskPageLimit))
+	}
+	for _, status := range query.Statuses {
+		if !status.IsValid() {
+			return nil, invalidField(ErrInvalidTaskStatus, apperror.ParamField("status", fmt.Sprintf("unknown status %q", status)))
+		}
+	}
+	if query.Priority != 0 && !query.Priority.IsValid() {
+		return nil, invalidField(ErrInvalidTaskPriority, apperror.ParamField("priority", "priority must be between 1 and 3"))
+	}
+	// カーソルは発行時と同じ並び替え条件でしか使えない
+	if query.Cursor != nil &&
+		(query.Cursor.SortField != query.SortField || query.Cursor.SortDirection != query.SortDirection) {
+		return nil, invalidTaskQuery("cursor", "cursor does not match sort order")
+	}
+
+	return t.taskRepository.List(ctx, query)
+}
+
+// Save は update で指定された項目だけを既存のタスクに反映する
+func (t *taskUseCase) Save(ctx context.Context, update *entity.TaskUpdate, taskId int) (*entity.Task, error) {
+	principal, err := authorize(ctx, entity.ScopeTasksWrite)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTaskUpdate(update); err != nil {
+		return nil, err
+	}
+
+	selectedTask, err := t.taskRepository.Get(ctx, principal.UserID, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Title != nil {
+		selectedTask.Title = *update.Title
+	}
+	if update.Description != nil {
+		selectedTask.Description = *update.Description
+	}
+	if update.Priority != nil {
+		selectedTask.Priority = *update.Priority
+	}
+	if update.ClearDueAt {
+		selectedTask.DueAt = nil
+	} else if update.DueAt != nil {
+		selectedTask.DueAt = update.DueAt
+	}
+	if update.Status != nil {
+		selectedTask.SetStatus(*update.Status, t.clock.Now())
+	}
+
+	return t.taskRepository.Save(ctx, selectedTask)
+}
+
+func (t *taskUseCase) Delete(ctx context.Context, taskId int) error {
+	principal, err := authorize(ctx, entity.ScopeTasksWrite)
+	if err != nil {
+		return err
+	}
+	return t.taskRepository.Delete(ctx, taskId, principal.UserID)
 }
 
-func (t *taskUseCase) Create(task *entity.Task) (*entity.Task, error) {
-	return t.taskRepository.Create(task)
+// 未設定（ゼロ値）の項目はチェックしない。タイトルは必須
+func validateTask(task *entity.Task) error {
+	if err := validateTaskTitle(task.Title); err != nil {
+		return err
+	}
+	if task.Status != "" && !task.Status.IsValid() {
+		return invalidField(ErrInvalidTaskStatus, apperror.BodyField("/status", fmt.Sprintf("unknown status %q", task.Status)))
+	}
+	if task.Priority != 0 && !task.Priority.IsValid() {
+		return invalidField(ErrInvalidTaskPriority, apperror.BodyField("/priority", "priority must be between 1 and 3"))
+	}
+	return nil
 }
 
-func (t *taskUseCase) Get(userId int, task_id int) (*entity.Task, error) {
-	return t.taskRepository.Get(userId, task_id)
+// 更新では、指定された項目は空にできない
+func validateTaskUpdate(update *entity.TaskUpdate) error {
+	if update.Title != nil {
+		if err := validateTaskTitle(*update.Title); err != nil {
+			return err
+		}
+	}
+	if update.Status != nil && !update.Status.IsValid() {
+		return invalidField(ErrInvalidTaskStatus, apperror.BodyField("/status", fmt.Sprintf("unknown status %q", *update.Status)))
+	}
+	if update.Priority != nil && !update.Priority.IsValid() {
+		return invalidField(ErrInvalidTaskPriority, apperror.BodyField("/priority", "priority must be between 1 and 3"))
+	}
+	return nil
 }
 
-func (t *taskUseCase) GetAllTasks(userId int) ([]*entity.Task, error) {
-	return t.taskRepository.GetAllTasks(userId)
+// ルーターの OpenAPI の検証が無効でも、DB の列に入らないタイトルは受け付けない
+func validateTaskTitle(title string) error {
+	if strings.TrimSpace(title) == "" {
+		return invalidField(ErrInvalidTaskTitle, apperror.BodyField("/title", "must not be blank"))
+	}
+	if utf8.RuneCountInString(title) > MaxTaskTitleLength {
+		return invalidField(ErrInvalidTaskTitle, apperror.BodyField("/title", fmt.Sprintf("must be at most %d characters", MaxTaskTitleLength)))
+	}
+	return nil
 }
 
-func (t *taskUseCase) Save(task *entity.Task, userId int, taskId int) (*entity.Task, error) {
-	return t.taskRepository.Save(task, userId, taskId)
+// 詳細なメッセージを付けつつ errors.Is(err, ErrInvalidTaskQuery) で判定できるようにする
+func invalidTaskQuery(parameter, message string) error {
+	return &apperror.Error{
+		Kind:    apperror.KindValidation,
+		Message: message,
+		Fields:  []apperror.FieldError{apperror.ParamField(parameter, message)},
+		Err:     ErrInvalidTaskQuery,
+	}
 }
 
-func (t *taskUseCase) Delete(userId int, taskId int) error {
-	return t.taskRepository.Delete(userId, taskId)
+// sentinel と同じメッセージのまま、どの項目が不正かを付け加える
+func invalidField(sentinel *apperror.Error, field apperror.FieldError) error {
+	return &apperror.Error{
+		Kind:    sentinel.Kind,
+		Message: sentinel.Message,
+		Fields:  []apperror.FieldError{field},
+		Err:     sentinel,
+	}
 }
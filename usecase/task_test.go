@@ -1,12 +1,17 @@
 package usecase
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
 )
 
 type mockTaskRepository struct {
@@ -17,7 +22,7 @@ func NewMockTaskRepository() *mockTaskRepository {
 	return new(mockTaskRepository)
 }
 
-func (m *mockTaskRepository) Create(task *entity.Task) (*entity.Task, error) {
+func (m *mockTaskRepository) Create(ctx context.Context, task *entity.Task) (*entity.Task, error) {
 	args := m.Called(task)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -25,7 +30,7 @@ func (m *mockTaskRepository) Create(task *entity.Task) (*entity.Task, error) {
 	return args.Get(0).(*entity.Task), args.Error(1)
 }
 
-func (m *mockTaskRepository) Get(userID int, ID int) (*entity.Task, error) {
+func (m *mockTaskRepository) Get(ctx context.Context, userID int, ID int) (*entity.Task, error) {
 	args := m.Called(userID, ID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -33,15 +38,15 @@ func (m *mockTaskRepository) Get(userID int, ID int) (*entity.Task, error) {
 	return args.Get(0).(*entity.Task), args.Error(1)
 }
 
-func (m *mockTaskRepository) GetAllTasks(userID int) ([]*entity.Task, error) {
-	args := m.Called(userID)
+func (m *mockTaskRepository) List(ctx context.Context, query *entity.TaskQuery) (*entity.TaskPage, error) {
+	args := m.Called(query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*entity.Task), args.Error(1)
+	return args.Get(0).(*entity.TaskPage), args.Error(1)
 }
 
-func (m *mockTaskRepository) Save(task *entity.Task) (*entity.Task, error) {
+func (m *mockTaskRepository) Save(ctx context.Context, task *entity.Task) (*entity.Task, error) {
 	args := m.Called(task)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -49,11 +54,16 @@ func (m *mockTaskRepository) Save(task *entity.Task) (*entity.Task, error) {
 	return args.Get(0).(*entity.Task), args.Error(1)
 }
 
-func (m *mockTaskRepository) Delete(ID int) error {
-	args := m.Called(ID)
+func (m *mockTaskRepository) Delete(ctx context.Context, userID int, ID int) error {
+	args := m.Called(userID, ID)
 	return args.Error(0)
 }
 
+func (m *mockTaskRepository) Count(ctx context.Context, userID int) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 type TaskUseCaseSuite struct {
 	suite.Suite
 	taskUseCase *taskUseCase
@@ -70,23 +80,75 @@ func (suite *TaskUseCaseSuite) TestCreate() {
 	title := "Test Task"
 	userID := 1
 	mockTaskRepository := NewMockTaskRepository()
-	suite.taskUseCase = NewTaskUseCase(mockTaskRepository)
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, NewMockUserRepository(), EmailVerificationPolicy{})
 
 	task := &entity.Task{
 		Title: title,
 		UserID: userID,
 	}
 
-	mockTaskRepository.On("Create", task).Return(&entity.Task{
-		ID: 1, 
-		Title: title,
-		UserID: userID,
-	}, nil)
+	mockTaskRepository.On("Create", task).Return(task, nil)
 
-	task, err := suite.taskUseCase.Create(task)
+	task, err := suite.taskUseCase.Create(sessionContext(userID, "session"), task)
 	suite.Assert().Nil(err)
 	suite.Assert().Equal(title, task.Title)
 	suite.Assert().Equal(userID, task.UserID)
+	suite.Assert().Equal(entity.TaskStatusTodo, task.Status)
+	suite.Assert().Equal(entity.TaskPriorityMedium, task.Priority)
+	suite.Assert().Nil(task.CompletedAt)
+}
+
+func (suite *TaskUseCaseSuite) TestCreateDone() {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockTaskRepository := NewMockTaskRepository()
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, NewMockUserRepository(), EmailVerificationPolicy{})
+	suite.taskUseCase.clock = tester.NewMockClock(now)
+
+	task := &entity.Task{
+		Title:  "Done Task",
+		Status: entity.TaskStatusDone,
+		UserID: 1,
+	}
+	mockTaskRepository.On("Create", task).Return(task, nil)
+
+	createdTask, err := suite.taskUseCase.Create(sessionContext(1, "session"), task)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(entity.TaskStatusDone, createdTask.Status)
+	suite.Assert().Equal(now, *createdTask.CompletedAt)
+}
+
+func (suite *TaskUseCaseSuite) TestCreateInvalidStatus() {
+	mockTaskRepository := NewMockTaskRepository()
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, NewMockUserRepository(), EmailVerificationPolicy{})
+
+	task := &entity.Task{
+		Title:  "Invalid Task",
+		Status: entity.TaskStatus("unknown"),
+		UserID: 1,
+	}
+
+	createdTask, err := suite.taskUseCase.Create(sessionContext(1, "session"), task)
+	suite.Assert().Nil(createdTask)
+	suite.Assert().ErrorIs(err, ErrInvalidTaskStatus)
+	mockTaskRepository.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *TaskUseCaseSuite) TestCreateInvalidTitle() {
+	mockTaskRepository := NewMockTaskRepository()
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, NewMockUserRepository(), EmailVerificationPolicy{})
+
+	for _, title := range []string{"", "  ", strings.Repeat("あ", MaxTaskTitleLength+1)} {
+		createdTask, err := suite.taskUseCase.Create(sessionContext(1, "session"), &entity.Task{Title: title})
+		suite.Assert().Nil(createdTask)
+		suite.Assert().ErrorIs(err, ErrInvalidTaskTitle)
+	}
+	mockTaskRepository.AssertNotCalled(suite.T(), "Create", mock.Anything)
+
+	// 文字数で数えるため、255 文字ちょうどならマルチバイトでも作れる
+	task := &entity.Task{Title: strings.Repeat("あ", MaxTaskTitleLength), UserID: 1}
+	mockTaskRepository.On("Create", task).Return(task, nil)
+	_, err := suite.taskUseCase.Create(sessionContext(1, "session"), task)
+	suite.Assert().Nil(err)
 }
 
 func (suite *TaskUseCaseSuite) TestGet() {
@@ -94,15 +156,15 @@ func (suite *TaskUseCaseSuite) TestGet() {
 	title := "Test Task"
 	userID := 1 // ユーザーID
 	mockTaskRepository := NewMockTaskRepository()
-	suite.taskUseCase = NewTaskUseCase(mockTaskRepository)
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, NewMockUserRepository(), EmailVerificationPolicy{})
 
-	mockTaskRepository.On("Get", taskID).Return(&entity.Task{
+	mockTaskRepository.On("Get", userID, taskID).Return(&entity.Task{
 		ID:     taskID,
 		Title:  title,
 		UserID: userID,
 	}, nil)
 
-	task, err := suite.taskUseCase.Get(userID, taskID)
+	task, err := suite.taskUseCase.Get(sessionContext(userID, "session"), taskID)
 	suite.Assert().Nil(err)
 	suite.Assert().Equal(taskID, task.ID)
 	suite.Assert().Equal(title, task.Title)
@@ -113,58 +175,213 @@ func (suite *TaskUseCaseSuite) TestSave() {
 	taskID := 1
 	title := "Updated Task"
 	userID := 1 // ユーザーID
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
 	mockTaskRepository := NewMockTaskRepository()
-	suite.taskUseCase = NewTaskUseCase(mockTaskRepository)
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, NewMockUserRepository(), EmailVerificationPolicy{})
+	suite.taskUseCase.clock = tester.NewMockClock(now)
 
-	task := &entity.Task{
-		ID:     taskID,
-		Title:  title,
-		UserID: userID,
+	status := entity.TaskStatusDone
+	update := &entity.TaskUpdate{
+		Title:  &title,
+		Status: &status,
 	}
 
-	mockTaskRepository.On("Save", task).Return(&entity.Task{
-		ID:     taskID,
-		Title:  title,
-		UserID: userID,
-	}, nil)
+	selectedTask := &entity.Task{
+		ID:          taskID,
+		Title:       "Test Task",
+		Description: "description",
+		Status:      entity.TaskStatusTodo,
+		Priority:    entity.TaskPriorityHigh,
+		UserID:      userID,
+	}
+	mockTaskRepository.On("Get", userID, taskID).Return(selectedTask, nil)
+	mockTaskRepository.On("Save", selectedTask).Return(selectedTask, nil)
 
-	updatedTask, err := suite.taskUseCase.Save(task)
+	updatedTask, err := suite.taskUseCase.Save(sessionContext(userID, "session"), update, taskID)
 	suite.Assert().Nil(err)
 	suite.Assert().Equal(taskID, updatedTask.ID)
 	suite.Assert().Equal(title, updatedTask.Title)
 	suite.Assert().Equal(userID, updatedTask.UserID)
+	suite.Assert().Equal("description", updatedTask.Description)
+	suite.Assert().Equal(entity.TaskPriorityHigh, updatedTask.Priority)
+	suite.Assert().Equal(entity.TaskStatusDone, updatedTask.Status)
+	suite.Assert().Equal(now, *updatedTask.CompletedAt)
+}
+
+func (suite *TaskUseCaseSuite) TestSaveClearsOptionalFields() {
+	taskID := 1
+	userID := 1
+	dueAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	newDueAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockTaskRepository := NewMockTaskRepository()
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, NewMockUserRepository(), EmailVerificationPolicy{})
+	selected := func() *entity.Task {
+		task := &entity.Task{ID: taskID, Title: "Test Task", Description: "description", DueAt: &dueAt, UserID: userID}
+		mockTaskRepository.On("Save", task).Return(task, nil).Once()
+		return task
+	}
+
+	// 説明を空にすると消え、期限はそのまま
+	mockTaskRepository.On("Get", userID, taskID).Return(selected(), nil).Once()
+	empty := ""
+	updatedTask, err := suite.taskUseCase.Save(sessionContext(userID, "session"), &entity.TaskUpdate{Description: &empty}, taskID)
+	suite.Require().Nil(err)
+	suite.Assert().Equal("", updatedTask.Description)
+	suite.Assert().Equal(&dueAt, updatedTask.DueAt)
+
+	// 期限を消しても、説明はそのまま
+	mockTaskRepository.On("Get", userID, taskID).Return(selected(), nil).Once()
+	updatedTask, err = suite.taskUseCase.Save(sessionContext(userID, "session"), &entity.TaskUpdate{ClearDueAt: true}, taskID)
+	suite.Require().Nil(err)
+	suite.Assert().Nil(updatedTask.DueAt)
+	suite.Assert().Equal("description", updatedTask.Description)
+
+	// 何も指定しなければ変わらない
+	mockTaskRepository.On("Get", userID, taskID).Return(selected(), nil).Once()
+	updatedTask, err = suite.taskUseCase.Save(sessionContext(userID, "session"), &entity.TaskUpdate{}, taskID)
+	suite.Require().Nil(err)
+	suite.Assert().Equal("description", updatedTask.Description)
+	suite.Assert().Equal(&dueAt, updatedTask.DueAt)
+
+	mockTaskRepository.On("Get", userID, taskID).Return(selected(), nil).Once()
+	updatedTask, err = suite.taskUseCase.Save(sessionContext(userID, "session"), &entity.TaskUpdate{DueAt: &newDueAt}, taskID)
+	suite.Require().Nil(err)
+	suite.Assert().Equal(&newDueAt, updatedTask.DueAt)
+}
+
+func (suite *TaskUseCaseSuite) TestSaveInvalid() {
+	mockTaskRepository := NewMockTaskRepository()
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, NewMockUserRepository(), EmailVerificationPolicy{})
+	empty := ""
+	long := strings.Repeat("a", MaxTaskTitleLength+1)
+	status := entity.TaskStatus("unknown")
+	priority := entity.TaskPriority(5)
+
+	for _, tc := range []struct {
+		update *entity.TaskUpdate
+		err    error
+	}{
+		{&entity.TaskUpdate{Title: &empty}, ErrInvalidTaskTitle},
+		{&entity.TaskUpdate{Title: &long}, ErrInvalidTaskTitle},
+		{&entity.TaskUpdate{Status: &status}, ErrInvalidTaskStatus},
+		{&entity.TaskUpdate{Priority: &priority}, ErrInvalidTaskPriority},
+	} {
+		_, err := suite.taskUseCase.Save(sessionContext(1, "session"), tc.update, 1)
+		suite.Assert().ErrorIs(err, tc.err)
+	}
+	mockTaskRepository.AssertNotCalled(suite.T(), "Get", mock.Anything, mock.Anything)
 }
 
 func (suite *TaskUseCaseSuite) TestDelete() {
 	taskID := 1
+	userID := 1
 	mockTaskRepository := NewMockTaskRepository()
-	suite.taskUseCase = NewTaskUseCase(mockTaskRepository)
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, NewMockUserRepository(), EmailVerificationPolicy{})
 
-	mockTaskRepository.On("Delete", taskID).Return(nil)
+	mockTaskRepository.On("Delete", taskID, userID).Return(nil)
 
-	err := suite.taskUseCase.Delete(taskID)
+	err := suite.taskUseCase.Delete(sessionContext(userID, "session"), taskID)
 	suite.Assert().Nil(err)
 }
 
-func (suite *TaskUseCaseSuite) TestGetAllTasks() {
+func (suite *TaskUseCaseSuite) TestList() {
 	userID := 1
-	title := "Test Task"
 	mockTaskRepository := NewMockTaskRepository()
-	suite.taskUseCase = NewTaskUseCase(mockTaskRepository)
-
-	mockTaskRepository.On("GetAllTasks", userID).Return([]*entity.Task{
-		{
-			ID:     1,
-			Title:  title,
-			UserID: userID,
-		},
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, NewMockUserRepository(), EmailVerificationPolicy{})
+
+	query := &entity.TaskQuery{UserID: userID}
+	mockTaskRepository.On("List", query).Return(&entity.TaskPage{
+		Tasks: []*entity.Task{{ID: 1, Title: "Test Task", UserID: userID}},
 	}, nil)
 
-	tasks, err := suite.taskUseCase.GetAllTasks(userID)
+	page, err := suite.taskUseCase.List(sessionContext(userID, "session"), query)
+	suite.Assert().Nil(err)
+	suite.Assert().Len(page.Tasks, 1)
+	suite.Assert().Nil(page.NextCursor)
+	suite.Assert().Equal(entity.TaskSortCreatedAt, query.SortField)
+	suite.Assert().Equal(entity.SortAsc, query.SortDirection)
+	suite.Assert().Equal(DefaultTaskPageLimit, query.Limit)
+}
+
+func (suite *TaskUseCaseSuite) TestListInvalidQuery() {
+	mockTaskRepository := NewMockTaskRepository()
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, NewMockUserRepository(), EmailVerificationPolicy{})
+
+	queries := []*entity.TaskQuery{
+		{UserID: 1, SortField: entity.TaskSortField("id")},
+		{UserID: 1, SortDirection: entity.SortDirection("up")},
+		{UserID: 1, Limit: MaxTaskPageLimit + 1},
+		{UserID: 1, SortField: entity.TaskSortTitle, Cursor: &entity.TaskCursor{
+			SortField:     entity.TaskSortCreatedAt,
+			SortDirection: entity.SortAsc,
+			ID:            1,
+		}},
+	}
+	for _, query := range queries {
+		page, err := suite.taskUseCase.List(sessionContext(1, "session"), query)
+		suite.Assert().Nil(page)
+		suite.Assert().ErrorIs(err, ErrInvalidTaskQuery)
+	}
+
+	page, err := suite.taskUseCase.List(sessionContext(1, "session"), &entity.TaskQuery{UserID: 1, Statuses: []entity.TaskStatus{"unknown"}})
+	suite.Assert().Nil(page)
+	suite.Assert().ErrorIs(err, ErrInvalidTaskStatus)
+	mockTaskRepository.AssertNotCalled(suite.T(), "List", mock.Anything)
+}
+
+func (suite *TaskUseCaseSuite) TestCreateUnverifiedLimit() {
+	verifiedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockTaskRepository := NewMockTaskRepository()
+	mockUserRepository := NewMockUserRepository()
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, mockUserRepository, EmailVerificationPolicy{Mode: UnverifiedPolicyLimit, TaskLimit: 2})
+
+	mockUserRepository.On("GetCurrentUser", 1).Return(&entity.User{ID: 1}, nil)
+	mockUserRepository.On("GetCurrentUser", 2).Return(&entity.User{ID: 2, EmailVerifiedAt: &verifiedAt}, nil)
+	mockTaskRepository.On("Count", 1).Return(int64(1), nil).Once()
+	mockTaskRepository.On("Count", 1).Return(int64(2), nil)
+	unverifiedTask := &entity.Task{Title: "Task", UserID: 1}
+	verifiedTask := &entity.Task{Title: "Task", UserID: 2}
+	mockTaskRepository.On("Create", unverifiedTask).Return(unverifiedTask, nil)
+	mockTaskRepository.On("Create", verifiedTask).Return(verifiedTask, nil)
+
+	_, err := suite.taskUseCase.Create(sessionContext(1, "session"), unverifiedTask)
+	suite.Assert().Nil(err)
+
+	// 上限に達したら確認が済むまで作れない
+	_, err = suite.taskUseCase.Create(sessionContext(1, "session"), &entity.Task{Title: "Task", UserID: 1})
+	suite.Assert().ErrorIs(err, ErrTaskLimitUnverified)
+	suite.Assert().True(apperror.IsForbidden(err))
+
+	// 確認済みのユーザーは数えない
+	_, err = suite.taskUseCase.Create(sessionContext(2, "session"), verifiedTask)
 	suite.Assert().Nil(err)
-	suite.Assert().Len(tasks, 1)
-	suite.Assert().Equal(title, tasks[0].Title)
-	suite.Assert().Equal(userID, tasks[0].UserID)
+	mockTaskRepository.AssertNotCalled(suite.T(), "Count", 2)
 }
 
+func (suite *TaskUseCaseSuite) TestPrincipal() {
+	mockTaskRepository := NewMockTaskRepository()
+	suite.taskUseCase = NewTaskUseCase(mockTaskRepository, NewMockUserRepository(), EmailVerificationPolicy{})
+
+	// タスクの持ち主はリクエストの値ではなく、認証した主体で決める
+	task := &entity.Task{Title: "Task", UserID: 2}
+	mockTaskRepository.On("Create", task).Return(task, nil)
+	createdTask, err := suite.taskUseCase.Create(sessionContext(1, "session"), task)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(1, createdTask.UserID)
 
+	query := &entity.TaskQuery{UserID: 2}
+	mockTaskRepository.On("List", query).Return(&entity.TaskPage{}, nil)
+	_, err = suite.taskUseCase.List(accessTokenContext(1, entity.ScopeTasksRead), query)
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(1, query.UserID)
+
+	// tasks:read だけのトークンでは変更できない
+	_, err = suite.taskUseCase.Create(accessTokenContext(1, entity.ScopeTasksRead), &entity.Task{Title: "Task"})
+	suite.Assert().ErrorIs(err, ErrInsufficientScope)
+	err = suite.taskUseCase.Delete(accessTokenContext(1, entity.ScopeTasksRead), 1)
+	suite.Assert().ErrorIs(err, ErrInsufficientScope)
+	mockTaskRepository.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+
+	_, err = suite.taskUseCase.Get(context.Background(), 1)
+	suite.Assert().ErrorIs(err, ErrUnauthenticated)
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/logger"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+var (
+	ErrInvalidRefreshToken = apperror.NewUnauthorized("invalid refresh token")
+	ErrRefreshTokenReused  = apperror.NewUnauthorized("refresh token reuse detected")
+)
+
+// TokenConfig はログイン時に発行するトークンの設定
+// アクセストークン（JWT）は TTL で短く切り、RefreshTTL の間はリフレッシュトークンで再発行する
+type TokenConfig struct {
+	// Keys はアクセストークンに署名する鍵。JWTMiddleware も同じ KeyRing で検証する
+	Keys       *security.KeyRing
+	TTL        time.Duration
+	RefreshTTL time.Duration
+	// ChallengeTTL はパスワードの確認後、2 要素目を入力できる時間
+	ChallengeTTL time.Duration
+}
+
+// TokenPair はログイン・再発行で返すトークンの組
+type TokenPair struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+// issueTokens はセッションのアクセストークンと新しいリフレッシュトークンを作る
+// リフレッシュトークンの FamilyID にはセッション ID を使う。保存せずに返すので、呼び出し側で保存する
+func (u *userUseCase) issueTokens(userID int, sessionID string) (*TokenPair, *entity.RefreshToken, error) {
+	now := u.clock.Now()
+	accessToken, err := u.signAccessToken(userID, sessionID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refreshToken, err := security.NewOpaqueToken()
+	if err != nil {
+		return nil, nil, err
+	}
+	record := &entity.RefreshToken{
+		UserID:    userID,
+		FamilyID:  sessionID,
+		TokenHash: security.HashToken(refreshToken),
+		ExpiresAt: now.Add(u.tokenConfig.RefreshTTL),
+	}
+
+	return &TokenPair{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  now.Add(u.tokenConfig.TTL),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: record.ExpiresAt,
+	}, record, nil
+}
+
+func (u *userUseCase) signAccessToken(userID int, sessionID string, now time.Time) (string, error) {
+	// ペイロードの作成
+	// jti はセッション ID。JWTMiddleware で失効したセッションのトークンを拒否するために使う
+	claims := &jwt.MapClaims{
+		"user_id": userID,
+		"jti":     sessionID,
+		"iat":     now.Unix(),
+		"exp":     now.Add(u.tokenConfig.TTL).Unix(),
+	}
+
+	// 署名した鍵の kid をヘッダーに付ける
+	return u.tokenConfig.Keys.Sign(claims)
+}
+
+// Refresh はリフレッシュトークンを使用済みにし、新しいトークンの組を発行する（ローテーション）
+// 使用済みのトークンが再び使われた場合は漏洩したとみなし、セッションごと失効させる
+func (u *userUseCase) Refresh(ctx context.Context, refreshToken string, client ClientInfo) (*TokenPair, error) {
+	current, err := u.refreshTokenRepository.FindByHash(ctx, security.HashToken(refreshToken))
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	now := u.clock.Now()
+	if current.UsedAt != nil {
+		return nil, u.revokeReusedFamily(ctx, current, now)
+	}
+	if !current.IsUsable(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	session, err := u.sessionRepository.FindByID(ctx, current.FamilyID)
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if session.RevokedAt != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	tokens, next, err := u.issueTokens(current.UserID, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	// ローテーション後に失敗するとクライアントの手元に使えるトークンが残らないため、先に更新する
+	client.applyTo(session)
+	session.LastSeenAt = now
+	session.ExpiresAt = next.ExpiresAt
+	if err := u.sessionRepository.Renew(ctx, session); err != nil {
+		return nil, err
+	}
+	if err := u.refreshTokenRepository.Rotate(ctx, current, next, now); err != nil {
+		// 同時に別のリクエストが同じトークンを使った場合もここに来る
+		if errors.Is(err, gateway.ErrRefreshTokenAlreadyUsed) {
+			return nil, u.revokeReusedFamily(ctx, current, now)
+		}
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (u *userUseCase) revokeReusedFamily(ctx context.Context, token *entity.RefreshToken, now time.Time) error {
+	logger.Warn("Refresh token reuse detected, revoking the session",
+		"user_id", token.UserID, "session_id", token.FamilyID)
+	if err := u.revokeSession(ctx, token.UserID, token.FamilyID, now); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// Logout はリフレッシュトークンのセッションを失効させる
+// 既に失効している・存在しないトークンでもエラーにしない
+func (u *userUseCase) Logout(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return nil
+	}
+	token, err := u.refreshTokenRepository.FindByHash(ctx, security.HashToken(refreshToken))
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return u.revokeSession(ctx, token.UserID, token.FamilyID, u.clock.Now())
+}
@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+// recoveryCodeCount は登録の完了時に発行するリカバリーコードの数
+const recoveryCodeCount = 10
+
+var (
+	ErrTOTPAlreadyEnabled = apperror.NewConflict("two-factor authentication is already enabled")
+	ErrTOTPNotEnabled     = apperror.NewConflict("two-factor authentication is not enabled")
+	ErrTOTPNotEnrolling   = apperror.NewNotFound("two-factor authentication enrollment not found")
+	ErrInvalidTOTPCode    = apperror.NewValidation("invalid two-factor authentication code",
+		apperror.BodyField("/code", "the code is invalid or has already been used"))
+	ErrIncorrectPassword = apperror.NewValidation("incorrect password",
+		apperror.BodyField("/password", "the password is incorrect"))
+)
+
+// 6 桁の数字は TOTP のコード、それ以外はリカバリーコードとして扱う
+var totpCodePattern = regexp.MustCompile(`^[0-9]{6}$`)
+
+// TwoFactorConfig は認証アプリに表示するアカウントの発行者名
+type TwoFactorConfig struct {
+	Issuer string
+}
+
+// TOTPEnrollment は認証アプリに登録する内容。URI は QR コードにして読み込ませる
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+type TwoFactorStatus struct {
+	Enabled                bool
+	RecoveryCodesRemaining int64
+}
+
+// TwoFactorUseCase の Status・Enroll・PendingEnrollment・Confirm・Disable は ctx の主体（Principal）の登録を扱い、
+// ログインしたセッションでだけ使える。IsEnabled・VerifyCode はログインの途中で userID のユーザーに使う
+type TwoFactorUseCase interface {
+	Status(ctx context.Context) (*TwoFactorStatus, error)
+	// Enroll は新しい秘密鍵で登録を始める。登録途中のものがあれば置き換える
+	Enroll(ctx context.Context) (*TOTPEnrollment, error)
+	// PendingEnrollment は登録途中の内容を返す。QR コードの表示に使う
+	PendingEnrollment(ctx context.Context) (*TOTPEnrollment, error)
+	// Confirm は認証アプリのコードで登録を完了し、リカバリーコードを返す
+	Confirm(ctx context.Context, code string) ([]string, error)
+	// Disable はパスワードを確認してから登録を削除する。パスワードの誤りはログインの失敗と同じく数える
+	Disable(ctx context.Context, password string) error
+	IsEnabled(ctx context.Context, userID int) (bool, error)
+	// VerifyCode はログインの 2 要素目として TOTP のコードかリカバリーコードを確かめる
+	VerifyCode(ctx context.Context, userID int, code string) error
+}
+
+type twoFactorUseCase struct {
+	userRepository gateway.UserRepository
+	totpRepository gateway.TOTPRepository
+	passwordHasher security.PasswordHasher
+	loginThrottle  LoginThrottle
+	config         TwoFactorConfig
+	clock          pkg.Clock
+}
+
+func NewTwoFactorUseCase(userRepository gateway.UserRepository, totpRepository gateway.TOTPRepository, passwordHasher security.PasswordHasher, loginThrottle LoginThrottle, config TwoFactorConfig) *twoFactorUseCase {
+	return &twoFactorUseCase{
+		userRepository: userRepository,
+		totpRepository: totpRepository,
+		passwordHasher: passwordHasher,
+		loginThrottle:  loginThrottle,
+		config:         config,
+		clock:          pkg.NewClock(),
+	}
+}
+
+// findCredential は登録がない場合に nil を返す
+func (u *twoFactorUseCase) findCredential(ctx context.Context, userID int) (*entity.TOTPCredential, error) {
+	credential, err := u.totpRepository.FindCredential(ctx, userID)
+	if apperror.IsNotFound(err) {
+		return nil, nil
+	}
+	return credential, err
+}
+
+func (u *twoFactorUseCase) Status(ctx context.Context) (*TwoFactorStatus, error) {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID := principal.UserID
+	credential, err := u.findCredential(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if credential == nil || !credential.IsEnabled() {
+		return &TwoFactorStatus{}, nil
+	}
+	count, err := u.totpRepository.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorStatus{Enabled: true, RecoveryCodesRemaining: count}, nil
+}
+
+func (u *twoFactorUseCase) Enroll(ctx context.Context) (*TOTPEnrollment, error) {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID := principal.UserID
+	credential, err := u.findCredential(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if credential != nil && credential.IsEnabled() {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	user, err := u.userRepository.GetCurrentUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := security.NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	credential = &entity.TOTPCredential{UserID: userID, Secret: secret, CreatedAt: u.clock.Now()}
+	if err := u.totpRepository.StartEnrollment(ctx, credential); err != nil {
+		// 同時に登録が完了した場合
+		if apperror.IsConflict(err) {
+			return nil, ErrTOTPAlreadyEnabled
+		}
+		return nil, err
+	}
+	return u.enrollment(user, credential), nil
+}
+
+func (u *twoFactorUseCase) PendingEnrollment(ctx context.Context) (*TOTPEnrollment, error) {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID := principal.UserID
+	credential, err := u.pendingCredential(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := u.userRepository.GetCurrentUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.enrollment(user, credential), nil
+}
+
+func (u *twoFactorUseCase) enrollment(user *entity.User, credential *entity.TOTPCredential) *TOTPEnrollment {
+	return &TOTPEnrollment{
+		Secret: credential.Secret,
+		URI:    security.TOTPURI(u.config.Issuer, user.Email, credential.Secret),
+	}
+}
+
+// pendingCredential は登録途中のものを返す。登録が完了した後は秘密鍵を返さない
+func (u *twoFactorUseCase) pendingCredential(ctx context.Context, userID int) (*entity.TOTPCredential, error) {
+	credential, err := u.findCredential(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if credential == nil {
+		return nil, ErrTOTPNotEnrolling
+	}
+	if credential.IsEnabled() {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	return credential, nil
+}
+
+func (u *twoFactorUseCase) Confirm(ctx context.Context, code string) ([]string, error) {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID := principal.UserID
+	credential, err := u.pendingCredential(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := u.clock.Now()
+	step, ok := security.ValidateTOTP(credential.Secret, code, now)
+	if !ok {
+		return nil, ErrInvalidTOTPCode
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	records := make([]*entity.RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		if codes[i], err = security.NewRecoveryCode(); err != nil {
+			return nil, err
+		}
+		records[i] = &entity.RecoveryCode{
+			UserID:    userID,
+			CodeHash:  security.HashToken(security.NormalizeRecoveryCode(codes[i])),
+			CreatedAt: now,
+		}
+	}
+	if err := u.totpRepository.Enable(ctx, userID, step, records, now); err != nil {
+		// 同時に登録が完了した場合
+		if apperror.IsNotFound(err) {
+			return nil, ErrTOTPAlreadyEnabled
+		}
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (u *twoFactorUseCase) Disable(ctx context.Context, password string) error {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return err
+	}
+	userID := principal.UserID
+	user, err := u.userRepository.GetCurrentUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := verifyCurrentPassword(ctx, u.loginThrottle, u.passwordHasher, user, password, ErrIncorrectPassword); err != nil {
+		return err
+	}
+	credential, err := u.findCredential(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if credential == nil || !credential.IsEnabled() {
+		return ErrTOTPNotEnabled
+	}
+	return u.totpRepository.Delete(ctx, userID)
+}
+
+func (u *twoFactorUseCase) IsEnabled(ctx context.Context, userID int) (bool, error) {
+	credential, err := u.findCredential(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return credential != nil && credential.IsEnabled(), nil
+}
+
+func (u *twoFactorUseCase) VerifyCode(ctx context.Context, userID int, code string) error {
+	credential, err := u.findCredential(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if credential == nil || !credential.IsEnabled() {
+		return ErrTOTPNotEnabled
+	}
+	now := u.clock.Now()
+
+	if !totpCodePattern.MatchString(code) {
+		err := u.totpRepository.UseRecoveryCode(ctx, userID, security.HashToken(security.NormalizeRecoveryCode(code)), now)
+		if apperror.IsNotFound(err) {
+			return ErrInvalidTOTPCode
+		}
+		return err
+	}
+
+	step, ok := security.ValidateTOTP(credential.Secret, code, now)
+	if !ok {
+		return ErrInvalidTOTPCode
+	}
+	// 一度使ったコードを盗み見られても、同じコードではログインできない
+	if err := u.totpRepository.UseStep(ctx, userID, step); err != nil {
+		if errors.Is(err, gateway.ErrTOTPCodeAlreadyUsed) {
+			return ErrInvalidTOTPCode
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"go-todo-app-clean-arch/adapter/gateway"
+	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
+
+type mockTOTPRepository struct {
+	mock.Mock
+}
+
+func NewMockTOTPRepository() *mockTOTPRepository {
+	return new(mockTOTPRepository)
+}
+
+func (m *mockTOTPRepository) FindCredential(ctx context.Context, userID int) (*entity.TOTPCredential, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.TOTPCredential), args.Error(1)
+}
+
+func (m *mockTOTPRepository) StartEnrollment(ctx context.Context, credential *entity.TOTPCredential) error {
+	args := m.Called(credential)
+	return args.Error(0)
+}
+
+func (m *mockTOTPRepository) Enable(ctx context.Context, userID int, step int64, codes []*entity.RecoveryCode, now time.Time) error {
+	args := m.Called(userID, step, codes, now)
+	return args.Error(0)
+}
+
+func (m *mockTOTPRepository) UseStep(ctx context.Context, userID int, step int64) error {
+	args := m.Called(userID, step)
+	return args.Error(0)
+}
+
+func (m *mockTOTPRepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string, now time.Time) error {
+	args := m.Called(userID, codeHash, now)
+	return args.Error(0)
+}
+
+func (m *mockTOTPRepository) CountRecoveryCodes(ctx context.Context, userID int) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockTOTPRepository) Delete(ctx context.Context, userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+type mockTwoFactorUseCase struct {
+	mock.Mock
+}
+
+func NewMockTwoFactorUseCase() *mockTwoFactorUseCase {
+	return new(mockTwoFactorUseCase)
+}
+
+func (m *mockTwoFactorUseCase) Status(ctx context.Context) (*TwoFactorStatus, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TwoFactorStatus), args.Error(1)
+}
+
+func (m *mockTwoFactorUseCase) Enroll(ctx context.Context) (*TOTPEnrollment, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TOTPEnrollment), args.Error(1)
+}
+
+func (m *mockTwoFactorUseCase) PendingEnrollment(ctx context.Context) (*TOTPEnrollment, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TOTPEnrollment), args.Error(1)
+}
+
+func (m *mockTwoFactorUseCase) Confirm(ctx context.Context, code string) ([]string, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockTwoFactorUseCase) Disable(ctx context.Context, password string) error {
+	args := m.Called(password)
+	return args.Error(0)
+}
+
+func (m *mockTwoFactorUseCase) IsEnabled(ctx context.Context, userID int) (bool, error) {
+	args := m.Called(userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockTwoFactorUseCase) VerifyCode(ctx context.Context, userID int, code string) error {
+	args := m.Called(userID, code)
+	return args.Error(0)
+}
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+type TwoFactorUseCaseSuite struct {
+	suite.Suite
+	useCase            *twoFactorUseCase
+	mockUserRepository *mockUserRepository
+	mockTOTPRepository *mockTOTPRepository
+	mockLoginThrottle  *mockLoginThrottle
+	now                time.Time
+}
+
+func TestTwoFactorUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorUseCaseSuite))
+}
+
+func (suite *TwoFactorUseCaseSuite) SetupTest() {
+	suite.mockUserRepository = NewMockUserRepository()
+	suite.mockTOTPRepository = NewMockTOTPRepository()
+	suite.mockLoginThrottle = NewMockLoginThrottle()
+	suite.useCase = NewTwoFactorUseCase(suite.mockUserRepository, suite.mockTOTPRepository, testPasswordHasher, suite.mockLoginThrottle, TwoFactorConfig{Issuer: "ToDo App"})
+	suite.now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.useCase.clock = tester.NewMockClock(suite.now)
+}
+
+// code は now から offset ステップずらした時刻のコードを返す
+func (suite *TwoFactorUseCaseSuite) code(offset int64) string {
+	code, err := security.TOTPCode(testTOTPSecret, security.TOTPStep(suite.now)+offset)
+	suite.Require().Nil(err)
+	return code
+}
+
+func (suite *TwoFactorUseCaseSuite) enabledCredential() *entity.TOTPCredential {
+	confirmedAt := suite.now.Add(-time.Hour)
+	return &entity.TOTPCredential{UserID: 7, Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}
+}
+
+func (suite *TwoFactorUseCaseSuite) TestEnroll() {
+	suite.mockTOTPRepository.On("FindCredential", 7).Return(nil, apperror.NewNotFound("TOTP credential not found"))
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com"}, nil)
+	suite.mockTOTPRepository.On("StartEnrollment", mock.AnythingOfType("*entity.TOTPCredential")).Return(nil)
+
+	enrollment, err := suite.useCase.Enroll(sessionContext(7, "session"))
+	suite.Assert().Nil(err)
+	saved := suite.mockTOTPRepository.Calls[1].Arguments.Get(0).(*entity.TOTPCredential)
+	suite.Assert().Equal(saved.Secret, enrollment.Secret)
+	suite.Assert().Nil(saved.ConfirmedAt)
+
+	uri, err := url.Parse(enrollment.URI)
+	suite.Require().Nil(err)
+	suite.Assert().Equal("/ToDo App:user@example.com", uri.Path)
+	suite.Assert().Equal(enrollment.Secret, uri.Query().Get("secret"))
+}
+
+func (suite *TwoFactorUseCaseSuite) TestEnrollAlreadyEnabled() {
+	suite.mockTOTPRepository.On("FindCredential", 7).Return(suite.enabledCredential(), nil)
+
+	_, err := suite.useCase.Enroll(sessionContext(7, "session"))
+	suite.Assert().ErrorIs(err, ErrTOTPAlreadyEnabled)
+	// 登録が完了した後は秘密鍵を返さない
+	_, err = suite.useCase.PendingEnrollment(sessionContext(7, "session"))
+	suite.Assert().ErrorIs(err, ErrTOTPAlreadyEnabled)
+	suite.mockTOTPRepository.AssertNotCalled(suite.T(), "StartEnrollment", mock.Anything)
+}
+
+func (suite *TwoFactorUseCaseSuite) TestConfirm() {
+	suite.mockTOTPRepository.On("FindCredential", 7).Return(&entity.TOTPCredential{UserID: 7, Secret: testTOTPSecret}, nil)
+	suite.mockTOTPRepository.On("Enable", 7, security.TOTPStep(suite.now), mock.Anything, suite.now).Return(nil)
+
+	_, err := suite.useCase.Confirm(sessionContext(7, "session"), "000000")
+	suite.Assert().ErrorIs(err, ErrInvalidTOTPCode)
+
+	codes, err := suite.useCase.Confirm(sessionContext(7, "session"), suite.code(0))
+	suite.Assert().Nil(err)
+	suite.Assert().Len(codes, recoveryCodeCount)
+
+	// リカバリーコードはハッシュだけを保存する
+	records := suite.mockTOTPRepository.Calls[2].Arguments.Get(2).([]*entity.RecoveryCode)
+	suite.Require().Len(records, recoveryCodeCount)
+	suite.Assert().Equal(security.HashToken(security.NormalizeRecoveryCode(codes[0])), records[0].CodeHash)
+	suite.Assert().Equal(7, records[0].UserID)
+}
+
+func (suite *TwoFactorUseCaseSuite) TestConfirmNotEnrolling() {
+	suite.mockTOTPRepository.On("FindCredential", 7).Return(nil, apperror.NewNotFound("TOTP credential not found"))
+
+	_, err := suite.useCase.Confirm(sessionContext(7, "session"), suite.code(0))
+	suite.Assert().ErrorIs(err, ErrTOTPNotEnrolling)
+	suite.Assert().True(apperror.IsNotFound(err))
+}
+
+func (suite *TwoFactorUseCaseSuite) TestDisable() {
+	hashedPassword, _ := testPasswordHasher.Hash("password123")
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com", Password: hashedPassword}, nil)
+	suite.mockTOTPRepository.On("FindCredential", 7).Return(suite.enabledCredential(), nil)
+	suite.mockTOTPRepository.On("Delete", 7).Return(nil)
+	suite.mockLoginThrottle.On("Check", "user@example.com", "").Return(nil)
+	suite.mockLoginThrottle.On("RecordFailure", "user@example.com", "").Return(nil)
+	suite.mockLoginThrottle.On("RecordSuccess", "user@example.com").Return(nil)
+
+	// パスワードを入力し直さないと無効にできない。間違えたらログインの失敗と同じく数える
+	err := suite.useCase.Disable(sessionContext(7, "session"), "wrong")
+	suite.Assert().ErrorIs(err, ErrIncorrectPassword)
+	suite.Assert().True(apperror.IsValidation(err))
+	suite.mockTOTPRepository.AssertNotCalled(suite.T(), "Delete", 7)
+	suite.mockLoginThrottle.AssertCalled(suite.T(), "RecordFailure", "user@example.com", "")
+
+	suite.Assert().Nil(suite.useCase.Disable(sessionContext(7, "session"), "password123"))
+	suite.mockTOTPRepository.AssertCalled(suite.T(), "Delete", 7)
+	suite.mockLoginThrottle.AssertCalled(suite.T(), "RecordSuccess", "user@example.com")
+}
+
+func (suite *TwoFactorUseCaseSuite) TestDisableThrottled() {
+	hashedPassword, _ := testPasswordHasher.Hash("password123")
+	suite.mockUserRepository.On("GetCurrentUser", 7).Return(&entity.User{ID: 7, Email: "user@example.com", Password: hashedPassword}, nil)
+	suite.mockLoginThrottle.On("Check", "user@example.com", "").Return(apperror.NewTooManyRequests("too many failed login attempts", time.Minute))
+
+	// 失敗が続いている間は、正しいパスワードでも確かめずに断る
+	err := suite.useCase.Disable(sessionContext(7, "session"), "password123")
+	suite.Assert().True(apperror.IsTooManyRequests(err))
+	suite.mockTOTPRepository.AssertNotCalled(suite.T(), "Delete", 7)
+	suite.mockLoginThrottle.AssertNotCalled(suite.T(), "RecordSuccess", mock.Anything)
+}
+
+func (suite *TwoFactorUseCaseSuite) TestVerifyCode() {
+	suite.mockTOTPRepository.On("FindCredential", 7).Return(suite.enabledCredential(), nil)
+	suite.mockTOTPRepository.On("UseStep", 7, security.TOTPStep(suite.now)).Return(nil).Once()
+	suite.mockTOTPRepository.On("UseStep", 7, security.TOTPStep(suite.now)).Return(gateway.ErrTOTPCodeAlreadyUsed)
+	suite.mockTOTPRepository.On("UseRecoveryCode", 7, security.HashToken("ABCDE23456"), suite.now).Return(nil)
+	suite.mockTOTPRepository.On("UseRecoveryCode", 7, mock.Anything, suite.now).Return(apperror.NewNotFound("recovery code not found"))
+
+	suite.Assert().Nil(suite.useCase.VerifyCode(context.Background(), 7, suite.code(0)))
+	// 同じコードは 2 回使えない
+	suite.Assert().ErrorIs(suite.useCase.VerifyCode(context.Background(), 7, suite.code(0)), ErrInvalidTOTPCode)
+	suite.Assert().ErrorIs(suite.useCase.VerifyCode(context.Background(), 7, suite.code(-2)), ErrInvalidTOTPCode)
+
+	// リカバリーコードは区切りや大文字・小文字を問わない
+	suite.Assert().Nil(suite.useCase.VerifyCode(context.Background(), 7, "abcde-23456"))
+	suite.Assert().ErrorIs(suite.useCase.VerifyCode(context.Background(), 7, "ZZZZZ-ZZZZZ"), ErrInvalidTOTPCode)
+}
+
+func (suite *TwoFactorUseCaseSuite) TestStatus() {
+	suite.mockTOTPRepository.On("FindCredential", 7).Return(suite.enabledCredential(), nil)
+	suite.mockTOTPRepository.On("FindCredential", 8).Return(&entity.TOTPCredential{UserID: 8, Secret: testTOTPSecret}, nil)
+	suite.mockTOTPRepository.On("FindCredential", 9).Return(nil, errors.New("connection refused"))
+	suite.mockTOTPRepository.On("CountRecoveryCodes", 7).Return(int64(3), nil)
+
+	status, err := suite.useCase.Status(sessionContext(7, "session"))
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(&TwoFactorStatus{Enabled: true, RecoveryCodesRemaining: 3}, status)
+
+	// 登録途中は無効として扱う
+	status, err = suite.useCase.Status(sessionContext(8, "session"))
+	suite.Assert().Nil(err)
+	suite.Assert().False(status.Enabled)
+
+	_, err = suite.useCase.Status(sessionContext(9, "session"))
+	suite.Assert().EqualError(err, "connection refused")
+}
+
+func (suite *TwoFactorUseCaseSuite) TestRequiresSession() {
+	// 二要素認証の設定はパーソナルアクセストークンでは変更できない
+	ctx := accessTokenContext(7, entity.AccessTokenScopes...)
+	_, err := suite.useCase.Status(ctx)
+	suite.Assert().ErrorIs(err, ErrSessionRequired)
+	_, err = suite.useCase.Enroll(ctx)
+	suite.Assert().ErrorIs(err, ErrSessionRequired)
+	_, err = suite.useCase.Confirm(ctx, suite.code(0))
+	suite.Assert().ErrorIs(err, ErrSessionRequired)
+	suite.Assert().ErrorIs(suite.useCase.Disable(ctx, "password123"), ErrSessionRequired)
+
+	_, err = suite.useCase.Status(context.Background())
+	suite.Assert().ErrorIs(err, ErrUnauthenticated)
+	suite.mockTOTPRepository.AssertNotCalled(suite.T(), "FindCredential", mock.Anything)
+}
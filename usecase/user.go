@@ -1,31 +1,98 @@
 package usecase
 
 import (
-	"errors"
+	"context"
+	"sync"
+
 	"go-todo-app-clean-arch/adapter/gateway"
 	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg"
 	"go-todo-app-clean-arch/pkg/logger"
-	"os"
-	"time"
+	"go-todo-app-clean-arch/pkg/mailer"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/usecase/apperror"
+)
 
-	"github.com/golang-jwt/jwt/v4"
-	"golang.org/x/crypto/bcrypt"
+var (
+	ErrInvalidCredentials = apperror.NewUnauthorized("invalid credentials")
 )
 
 type UserUseCase interface {
-	GetCurrentUser(userId int) (*entity.User, error)
-	DeleteUser(userId int) error
-	Signup(user *entity.User) (*entity.User, error)
-	Login(credentials *entity.Credentials) (string, error)
+	// GetCurrentUser は ctx の主体のユーザーを返す。パーソナルアクセストークンでは user:read のスコープが必要
+	GetCurrentUser(ctx context.Context) (*entity.User, error)
+	DeleteUser(ctx context.Context) error
+	Signup(ctx context.Context, user *entity.User) (*entity.User, error)
+	Login(ctx context.Context, credentials *entity.Credentials, client ClientInfo) (*LoginResult, error)
+	LoginTOTP(ctx context.Context, challengeToken string, code string, client ClientInfo) (*TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string, client ClientInfo) (*TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	ListSessions(ctx context.Context) ([]*entity.Session, error)
+	ValidateSession(ctx context.Context, userID int, sessionID string) error
+	RevokeSession(ctx context.Context, sessionID string) error
+	RevokeOtherSessions(ctx context.Context) error
+	// ChangePassword は現在のパスワードを確かめてから変え、リクエストしたセッション以外を失効させる
+	ChangePassword(ctx context.Context, currentPassword, newPassword string) error
+	// RequestEmailChange は新しいアドレスに確認メールを送る。確認されるまでメールアドレスは変えない
+	RequestEmailChange(ctx context.Context, newEmail string) error
+	ConfirmEmailChange(ctx context.Context, token string) error
 }
 
 type userUseCase struct {
-	userRepository gateway.UserRepository
+	userRepository                gateway.UserRepository
+	refreshTokenRepository        gateway.RefreshTokenRepository
+	sessionRepository             gateway.SessionRepository
+	personalAccessTokenRepository gateway.PersonalAccessTokenRepository
+	loginChallengeRepository      gateway.LoginChallengeRepository
+	emailVerification             EmailVerificationUseCase
+	twoFactor                     TwoFactorUseCase
+	loginThrottle                 LoginThrottle
+	passwordHasher                security.PasswordHasher
+	tokenConfig                   TokenConfig
+	verificationPolicy            EmailVerificationPolicy
+	passwordPolicy                PasswordPolicy
+	emailChangeConfig             EmailChangeConfig
+	clock                         pkg.Clock
+	// dummyPasswordHash は設定と同じアルゴリズム・パラメータで作った、どのユーザーのものでもないハッシュ
+	dummyPasswordHash func() (string, error)
+	// send はメールを送る。テストでは送ったメールを記録するように差し替える
+	send func(msg *mailer.Message)
 }
 
-func NewUserUseCase(userRepository gateway.UserRepository) *userUseCase {
+func NewUserUseCase(
+	userRepository gateway.UserRepository,
+	refreshTokenRepository gateway.RefreshTokenRepository,
+	sessionRepository gateway.SessionRepository,
+	personalAccessTokenRepository gateway.PersonalAccessTokenRepository,
+	loginChallengeRepository gateway.LoginChallengeRepository,
+	emailVerification EmailVerificationUseCase,
+	twoFactor TwoFactorUseCase,
+	loginThrottle LoginThrottle,
+	passwordHasher security.PasswordHasher,
+	tokenConfig TokenConfig,
+	verificationPolicy EmailVerificationPolicy,
+	passwordPolicy PasswordPolicy,
+	m mailer.Mailer,
+	emailChangeConfig EmailChangeConfig,
+) *userUseCase {
 	return &userUseCase{
-		userRepository: userRepository,
+		userRepository:                userRepository,
+		refreshTokenRepository:        refreshTokenRepository,
+		sessionRepository:             sessionRepository,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		loginChallengeRepository:      loginChallengeRepository,
+		emailVerification:             emailVerification,
+		twoFactor:                     twoFactor,
+		loginThrottle:                 loginThrottle,
+		passwordHasher:                passwordHasher,
+		tokenConfig:                   tokenConfig,
+		verificationPolicy:            verificationPolicy,
+		passwordPolicy:                passwordPolicy,
+		emailChangeConfig:             emailChangeConfig,
+		clock:                         pkg.NewClock(),
+		dummyPasswordHash: sync.OnceValues(func() (string, error) {
+			return passwordHasher.Hash("dummy password")
+		}),
+		send: sendAsync(m),
 	}
 }
 
@@ -33,61 +100,173 @@ func NewUserUseCase(userRepository gateway.UserRepository) *userUseCase {
 // 	return u.userRepository.Create(user)
 // }
 
-func (u *userUseCase) GetCurrentUser(userId int) (*entity.User, error) {
-	return u.userRepository.GetCurrentUser(userId)
+func (u *userUseCase) GetCurrentUser(ctx context.Context) (*entity.User, error) {
+	principal, err := authorize(ctx, entity.ScopeUserRead)
+	if err != nil {
+		return nil, err
+	}
+	return u.userRepository.GetCurrentUser(ctx, principal.UserID)
 }
 
-func (u *userUseCase) DeleteUser(userId int) error {
-	return u.userRepository.DeleteUser(userId)
+// DeleteUser は ctx の主体のアカウントを削除する。ログインしたセッションでしか使えない
+func (u *userUseCase) DeleteUser(ctx context.Context) error {
+	principal, err := authorizeSession(ctx)
+	if err != nil {
+		return err
+	}
+	return u.userRepository.DeleteUser(ctx, principal.UserID)
 }
 
-func (u *userUseCase) Signup(user *entity.User) (*entity.User, error) {
+func (u *userUseCase) Signup(ctx context.Context, user *entity.User) (*entity.User, error) {
+	if err := u.passwordPolicy.Validate(user.Password, user.Email); err != nil {
+		return nil, err
+	}
+
 	// パスワードをハッシュ化
-	hashedPassword, err := HashPassword(user.Password)
+	hashedPassword, err := u.passwordHasher.Hash(user.Password)
 	if err != nil {
 		return nil, err
 	}
 	user.Password = hashedPassword
 
 	// ユーザー作成
-	return u.userRepository.Signup(user)
+	createdUser, err := u.userRepository.Signup(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// アカウントは作成済みのため、確認メールを送れなくても登録は成功させる。ユーザーは再送を依頼できる
+	if err := u.emailVerification.SendVerification(ctx, createdUser); err != nil {
+		logger.Error("Failed to send verification email", "user_id", createdUser.ID, "error", err.Error())
+	}
+	return createdUser, nil
 }
 
-func (u *userUseCase) Login(credentials *entity.Credentials) (string, error) {
+// Login は新しいセッションを作り、アクセストークンとリフレッシュトークンを発行する
+// 2 要素認証を有効にしているユーザーにはトークンの代わりにチャレンジを返し、LoginTOTP で続きを行う
+func (u *userUseCase) Login(ctx context.Context, credentials *entity.Credentials, client ClientInfo) (*LoginResult, error) {
+	// 失敗が続いているアカウント・接続元は、パスワードを確かめる前に断る
+	if err := u.loginThrottle.Check(ctx, credentials.Email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
 	// メールアドレスでユーザーを検索
 	// TODO: credentialsではなく普通にuserを使用した方が余計な処理が減るかも
-	user, err := u.userRepository.FindByEmail(credentials.Email)
-	if err != nil || !CheckPasswordHash(credentials.Password, user.Password) {
-		return "", errors.New("invalid credentials")
+	user, err := u.userRepository.FindByEmail(ctx, credentials.Email)
+	if err != nil {
+		// 存在しないメールアドレスもパスワード誤りと同じエラーにする
+		if apperror.IsNotFound(err) {
+			u.verifyDummyPassword(credentials.Password)
+			return nil, u.loginFailed(ctx, credentials.Email, client)
+		}
+		return nil, err
+	}
+	if !user.HasPassword() {
+		u.verifyDummyPassword(credentials.Password)
+		return nil, u.loginFailed(ctx, credentials.Email, client)
 	}
+	ok, err := u.passwordHasher.Verify(credentials.Password, user.Password)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, u.loginFailed(ctx, credentials.Email, client)
+	}
+	if err := u.loginThrottle.RecordSuccess(ctx, credentials.Email); err != nil {
+		return nil, err
+	}
+	u.rehashPassword(ctx, user, credentials.Password)
+	return u.CompleteLogin(ctx, user, client)
+}
 
-	// ペイロードの作成
-	claims := &jwt.MapClaims{
-		"user_id": user.ID,
-		"exp": time.Now().Add(time.Hour * 24).Unix(),
+// CompleteLogin は本人であることを確かめたユーザーのログインを進める
+// パスワードと OpenID Connect のどちらでログインした場合も、同じようにメールアドレスの確認と 2 要素認証を求める
+func (u *userUseCase) CompleteLogin(ctx context.Context, user *entity.User, client ClientInfo) (*LoginResult, error) {
+	// 本人であることを確かめた後でだけ伝え、未確認のアカウントがあることを第三者に知らせない
+	if !user.IsEmailVerified() && u.verificationPolicy.Mode == UnverifiedPolicyBlock {
+		return nil, ErrEmailNotVerified
 	}
 
-	// トークン生成
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	enabled, err := u.twoFactor.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		challenge, err := u.createChallenge(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{Challenge: challenge}, nil
+	}
 
-	secret := os.Getenv("SECRET")
-	logger.Info("os.Getenv(SECRET): " + secret)
+	tokens, err := u.startSession(ctx, user.ID, client)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{Tokens: tokens}, nil
+}
 
-	// トークンに署名を付与
-	tokenString, err := token.SignedString([]byte(secret))
-	logger.Info("tokenString: " + tokenString)
+// verifyDummyPassword はパスワードで照合できるユーザーがいない場合にも、同じだけ時間をかけてパスワードを検証する
+// ハッシュの検証の有無で応答時間が変わり、アカウントがあるかを知られないようにする
+func (u *userUseCase) verifyDummyPassword(password string) {
+	hash, err := u.dummyPasswordHash()
 	if err != nil {
-		return "", err
+		logger.Warn("Failed to create the dummy password hash", "error", err.Error())
+		return
 	}
-	return tokenString, nil
+	_, _ = u.passwordHasher.Verify(password, hash)
 }
 
-func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	return string(bytes), err
+// loginFailed は失敗を数え、ErrInvalidCredentials を返す
+func (u *userUseCase) loginFailed(ctx context.Context, email string, client ClientInfo) error {
+	if err := u.loginThrottle.RecordFailure(ctx, email, client.IPAddress); err != nil {
+		return err
+	}
+	return ErrInvalidCredentials
 }
 
-func CheckPasswordHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+// startSession はログインが済んだユーザーのセッションを作り、トークンを発行する
+func (u *userUseCase) startSession(ctx context.Context, userID int, client ClientInfo) (*TokenPair, error) {
+	sessionID, err := security.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	tokens, refreshToken, err := u.issueTokens(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	// セッションのないリフレッシュトークンは使えないため、途中で失敗しても使えるトークンは残らない
+	if err := u.refreshTokenRepository.Create(ctx, refreshToken); err != nil {
+		return nil, err
+	}
+	now := u.clock.Now()
+	session := &entity.Session{
+		ID:         sessionID,
+		UserID:     userID,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  refreshToken.ExpiresAt,
+	}
+	client.applyTo(session)
+	if err := u.sessionRepository.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// rehashPassword は古いアルゴリズム・パラメータのハッシュを、確認できた平文から今の設定で作り直す
+// パスワードの再設定を求めずに移行するためのもので、失敗してもログインは続ける
+func (u *userUseCase) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	if !u.passwordHasher.NeedsRehash(user.Password) {
+		return
+	}
+	hashedPassword, err := u.passwordHasher.Hash(password)
+	if err == nil {
+		err = u.userRepository.UpdatePasswordHash(ctx, user.ID, user.Password, hashedPassword)
+	}
+	if err != nil {
+		logger.Warn("Failed to rehash password", "user_id", user.ID, "error", err.Error())
+		return
+	}
+	user.Password = hashedPassword
 }
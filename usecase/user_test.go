@@ -1,12 +1,23 @@
 package usecase
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
 
+	"go-todo-app-clean-arch/adapter/gateway"
 	"go-todo-app-clean-arch/entity"
+	"go-todo-app-clean-arch/pkg/mailer"
+	"go-todo-app-clean-arch/pkg/security"
+	"go-todo-app-clean-arch/pkg/tester"
+	"go-todo-app-clean-arch/usecase/apperror"
 )
 
 type mockUserRepository struct {
@@ -17,7 +28,7 @@ func NewMockUserRepository() *mockUserRepository {
 	return new(mockUserRepository)
 }
 
-func (m *mockUserRepository) Create(user *entity.User) (*entity.User, error) {
+func (m *mockUserRepository) Signup(ctx context.Context, user *entity.User) (*entity.User, error) {
 	args := m.Called(user)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -25,7 +36,7 @@ func (m *mockUserRepository) Create(user *entity.User) (*entity.User, error) {
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
-func (m *mockUserRepository) Get(ID int) (*entity.User, error) {
+func (m *mockUserRepository) GetCurrentUser(ctx context.Context, ID int) (*entity.User, error) {
 	args := m.Called(ID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -33,138 +44,258 @@ func (m *mockUserRepository) Get(ID int) (*entity.User, error) {
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
-func (m *mockUserRepository) Delete(ID int) error {
+func (m *mockUserRepository) DeleteUser(ctx context.Context, ID int) error {
 	args := m.Called(ID)
 	return args.Error(0)
 }
 
-func (m *mockUserRepository) GetTasksForUser(userID int) ([]*entity.Task, error) {
+func (m *mockUserRepository) UpdatePasswordHash(ctx context.Context, userID int, currentHash, newHash string) error {
+	args := m.Called(userID, currentHash, newHash)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *mockUserRepository) ChangePassword(ctx context.Context, userID int, currentHash, newHash string) error {
+	args := m.Called(userID, currentHash, newHash)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) CreateEmailChangeToken(ctx context.Context, token *entity.EmailChangeToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) FindEmailChangeToken(ctx context.Context, tokenHash string) (*entity.EmailChangeToken, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.EmailChangeToken), args.Error(1)
+}
+
+func (m *mockUserRepository) FindLatestEmailChangeToken(ctx context.Context, userID int) (*entity.EmailChangeToken, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*entity.Task), args.Error(1)
+	return args.Get(0).(*entity.EmailChangeToken), args.Error(1)
 }
 
-func (m *mockUserRepository) FindByEmail(email string) (*entity.User, error) {
-	args := m.Called(email)
+func (m *mockUserRepository) ChangeEmail(ctx context.Context, token *entity.EmailChangeToken, now time.Time) error {
+	args := m.Called(token, now)
+	return args.Error(0)
+}
+
+type mockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func NewMockRefreshTokenRepository() *mockRefreshTokenRepository {
+	return new(mockRefreshTokenRepository)
+}
+
+func (m *mockRefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *mockRefreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	args := m.Called(tokenHash)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*entity.User), args.Error(1)
+	return args.Get(0).(*entity.RefreshToken), args.Error(1)
 }
 
-type UserUseCaseSuite struct {
-	suite.Suite
-	userUseCase *userUseCase
+func (m *mockRefreshTokenRepository) Rotate(ctx context.Context, current *entity.RefreshToken, next *entity.RefreshToken, now time.Time) error {
+	args := m.Called(current, next, now)
+	return args.Error(0)
 }
 
-func TestUserUseCaseTestSuite(t *testing.T) {
-	suite.Run(t, new(UserUseCaseSuite))
+func (m *mockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, now time.Time) error {
+	args := m.Called(familyID, now)
+	return args.Error(0)
 }
 
-func (suite *UserUseCaseSuite) TestCreate() {
-	email := "test@example.com"
-	password := "password123"
-	mockUserRepository := NewMockUserRepository()
-	suite.userUseCase = NewUserUseCase(mockUserRepository)
+type mockSessionRepository struct {
+	mock.Mock
+}
 
-	user := &entity.User{
-		Email:    email,
-		Password: password,
+func NewMockSessionRepository() *mockSessionRepository {
+	return new(mockSessionRepository)
+}
+
+func (m *mockSessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepository) FindByID(ctx context.Context, id string) (*entity.Session, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
+	return args.Get(0).(*entity.Session), args.Error(1)
+}
 
-	mockUserRepository.On("Create", user).Return(&entity.User{
-		ID:       1,
-		Email:    email,
-		Password: password,
-	}, nil)
+func (m *mockSessionRepository) ListActive(ctx context.Context, userID int, now time.Time) ([]*entity.Session, error) {
+	args := m.Called(userID, now)
+	return args.Get(0).([]*entity.Session), args.Error(1)
+}
 
-	createdUser, err := suite.userUseCase.Create(user)
-	suite.Assert().Nil(err)
-	suite.Assert().Equal(email, createdUser.Email)
-	suite.Assert().Equal(password, createdUser.Password)
+func (m *mockSessionRepository) Touch(ctx context.Context, id string, lastSeenAt time.Time) error {
+	args := m.Called(id, lastSeenAt)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepository) Renew(ctx context.Context, session *entity.Session) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepository) Revoke(ctx context.Context, userID int, id string, now time.Time) error {
+	args := m.Called(userID, id, now)
+	return args.Error(0)
+}
+
+var testTokenConfig = TokenConfig{Keys: security.NewHMACKeyRing([]byte("test-secret")), TTL: time.Hour, RefreshTTL: 24 * time.Hour}
+
+var testVerificationPolicy = EmailVerificationPolicy{Mode: UnverifiedPolicyLimit, TaskLimit: 10}
+
+var testPasswordPolicy = PasswordPolicy{MinLength: 8}
+
+// テストを速くするため、bcrypt の最小のコストでハッシュ化する
+var testPasswordHasher = security.NewBcryptHasher(bcrypt.MinCost)
+
+type UserUseCaseSuite struct {
+	suite.Suite
+	userUseCase *userUseCase
+}
+
+func TestUserUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(UserUseCaseSuite))
 }
 
-func (suite *UserUseCaseSuite) TestGet() {
+func (suite *UserUseCaseSuite) TestGetCurrentUser() {
 	userID := 1
 	email := "test@example.com"
 	password := "password123"
 	mockUserRepository := NewMockUserRepository()
-	suite.userUseCase = NewUserUseCase(mockUserRepository)
+	suite.userUseCase = NewUserUseCase(mockUserRepository, NewMockRefreshTokenRepository(), NewMockSessionRepository(), NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(), NewMockEmailVerificationUseCase(), NewMockTwoFactorUseCase(), newPassingLoginThrottle(), testPasswordHasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
 
-	mockUserRepository.On("Get", userID).Return(&entity.User{
+	mockUserRepository.On("GetCurrentUser", userID).Return(&entity.User{
 		ID:       userID,
 		Email:    email,
 		Password: password,
 	}, nil)
 
-	user, err := suite.userUseCase.Get(userID)
+	user, err := suite.userUseCase.GetCurrentUser(sessionContext(userID, "session"))
 	suite.Assert().Nil(err)
 	suite.Assert().Equal(userID, user.ID)
 	suite.Assert().Equal(email, user.Email)
 	suite.Assert().Equal(password, user.Password)
-}
-
-func (suite *UserUseCaseSuite) TestDelete() {
-	userID := 1
-	mockUserRepository := NewMockUserRepository()
-	suite.userUseCase = NewUserUseCase(mockUserRepository)
-
-	mockUserRepository.On("Delete", userID).Return(nil)
 
-	err := suite.userUseCase.Delete(userID)
+	_, err = suite.userUseCase.GetCurrentUser(accessTokenContext(userID, entity.ScopeUserRead))
 	suite.Assert().Nil(err)
+	_, err = suite.userUseCase.GetCurrentUser(accessTokenContext(userID, entity.ScopeTasksRead))
+	suite.Assert().ErrorIs(err, ErrInsufficientScope)
 }
 
-func (suite *UserUseCaseSuite) TestGetTasksForUser() {
+func (suite *UserUseCaseSuite) TestDeleteUser() {
 	userID := 1
 	mockUserRepository := NewMockUserRepository()
-	suite.userUseCase = NewUserUseCase(mockUserRepository)
+	suite.userUseCase = NewUserUseCase(mockUserRepository, NewMockRefreshTokenRepository(), NewMockSessionRepository(), NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(), NewMockEmailVerificationUseCase(), NewMockTwoFactorUseCase(), newPassingLoginThrottle(), testPasswordHasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
 
-	mockUserRepository.On("GetTasksForUser", userID).Return([]*entity.Task{
-		{
-			ID:    1,
-			Title: "Test Task",
-		},
-	}, nil)
+	mockUserRepository.On("DeleteUser", userID).Return(nil)
+
+	// パーソナルアクセストークンではアカウントを削除できない
+	err := suite.userUseCase.DeleteUser(accessTokenContext(userID, entity.AccessTokenScopes...))
+	suite.Assert().ErrorIs(err, ErrSessionRequired)
+	mockUserRepository.AssertNotCalled(suite.T(), "DeleteUser", userID)
 
-	tasks, err := suite.userUseCase.GetTasksForUser(userID)
+	err = suite.userUseCase.DeleteUser(sessionContext(userID, "session"))
 	suite.Assert().Nil(err)
-	suite.Assert().Len(tasks, 1)
-	suite.Assert().Equal("Test Task", tasks[0].Title)
 }
 
 func (suite *UserUseCaseSuite) TestSignup() {
 	email := "test@example.com"
 	password := "password123"
-	hashedPassword, _ := HashPassword(password)
+	hashedPassword, _ := testPasswordHasher.Hash(password)
 	mockUserRepository := NewMockUserRepository()
-	suite.userUseCase = NewUserUseCase(mockUserRepository)
+	mockEmailVerificationUseCase := NewMockEmailVerificationUseCase()
+	suite.userUseCase = NewUserUseCase(mockUserRepository, NewMockRefreshTokenRepository(), NewMockSessionRepository(), NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(), mockEmailVerificationUseCase, NewMockTwoFactorUseCase(), newPassingLoginThrottle(), testPasswordHasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
 
 	user := &entity.User{
 		Email:    email,
 		Password: password,
 	}
 
-	mockUserRepository.On("Create", mock.AnythingOfType("*entity.User")).Return(&entity.User{
+	mockUserRepository.On("Signup", mock.AnythingOfType("*entity.User")).Return(&entity.User{
 		ID:       1,
 		Email:    email,
 		Password: hashedPassword,
 	}, nil)
+	mockEmailVerificationUseCase.On("SendVerification", mock.AnythingOfType("*entity.User")).Return(nil)
 
-	createdUser, err := suite.userUseCase.Signup(user)
+	createdUser, err := suite.userUseCase.Signup(context.Background(), user)
 	suite.Assert().Nil(err)
 	suite.Assert().Equal(email, createdUser.Email)
-	suite.Assert().True(CheckPasswordHash(password, createdUser.Password))
+	ok, _ := testPasswordHasher.Verify(password, createdUser.Password)
+	suite.Assert().True(ok)
+	mockEmailVerificationUseCase.AssertCalled(suite.T(), "SendVerification", createdUser)
+}
+
+func (suite *UserUseCaseSuite) TestSignupVerificationFailure() {
+	mockUserRepository := NewMockUserRepository()
+	mockEmailVerificationUseCase := NewMockEmailVerificationUseCase()
+	suite.userUseCase = NewUserUseCase(mockUserRepository, NewMockRefreshTokenRepository(), NewMockSessionRepository(), NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(), mockEmailVerificationUseCase, NewMockTwoFactorUseCase(), newPassingLoginThrottle(), testPasswordHasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
+
+	mockUserRepository.On("Signup", mock.AnythingOfType("*entity.User")).Return(&entity.User{ID: 1, Email: "test@example.com"}, nil)
+	mockEmailVerificationUseCase.On("SendVerification", mock.AnythingOfType("*entity.User")).Return(errors.New("connection refused"))
+
+	// 確認メールを送れなくても登録は成功させる
+	createdUser, err := suite.userUseCase.Signup(context.Background(), &entity.User{Email: "test@example.com", Password: "password123"})
+	suite.Assert().Nil(err)
+	suite.Assert().Equal(1, createdUser.ID)
+}
+
+func (suite *UserUseCaseSuite) TestLoginUnverifiedBlocked() {
+	hashedPassword, _ := testPasswordHasher.Hash("password123")
+	mockUserRepository := NewMockUserRepository()
+	suite.userUseCase = NewUserUseCase(mockUserRepository, NewMockRefreshTokenRepository(), NewMockSessionRepository(), NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(), NewMockEmailVerificationUseCase(), NewMockTwoFactorUseCase(), newPassingLoginThrottle(), testPasswordHasher, testTokenConfig,
+		EmailVerificationPolicy{Mode: UnverifiedPolicyBlock}, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
+
+	mockUserRepository.On("FindByEmail", "test@example.com").Return(&entity.User{
+		ID:       1,
+		Email:    "test@example.com",
+		Password: hashedPassword,
+	}, nil)
+
+	_, err := suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: "test@example.com", Password: "password123"}, ClientInfo{})
+	suite.Assert().ErrorIs(err, ErrEmailNotVerified)
+	suite.Assert().True(apperror.IsForbidden(err))
+
+	// パスワードが誤っている場合は未確認であることを伝えない
+	_, err = suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: "test@example.com", Password: "wrong"}, ClientInfo{})
+	suite.Assert().ErrorIs(err, ErrInvalidCredentials)
 }
 
 func (suite *UserUseCaseSuite) TestLogin() {
 	email := "test@example.com"
 	password := "password123"
-	hashedPassword, _ := HashPassword(password)
+	hashedPassword, _ := testPasswordHasher.Hash(password)
 	mockUserRepository := NewMockUserRepository()
-	suite.userUseCase = NewUserUseCase(mockUserRepository)
+	mockRefreshTokenRepository := NewMockRefreshTokenRepository()
+	mockSessionRepository := NewMockSessionRepository()
+	mockTwoFactorUseCase := NewMockTwoFactorUseCase()
+	suite.userUseCase = NewUserUseCase(mockUserRepository, mockRefreshTokenRepository, mockSessionRepository, NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(), NewMockEmailVerificationUseCase(), mockTwoFactorUseCase, newPassingLoginThrottle(), testPasswordHasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
 
 	credentials := &entity.Credentials{
 		Email:    email,
@@ -176,8 +307,306 @@ func (suite *UserUseCaseSuite) TestLogin() {
 		Email:    email,
 		Password: hashedPassword,
 	}, nil)
+	mockRefreshTokenRepository.On("Create", mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
+	mockSessionRepository.On("Create", mock.AnythingOfType("*entity.Session")).Return(nil)
+	mockTwoFactorUseCase.On("IsEnabled", 1).Return(false, nil)
 
-	jwt, err := suite.userUseCase.Login(credentials)
+	client := ClientInfo{UserAgent: "Mozilla/5.0 " + strings.Repeat("x", maxUserAgentLength), IPAddress: "192.0.2.1"}
+	result, err := suite.userUseCase.Login(context.Background(), credentials, client)
+	suite.Assert().Nil(err)
+	suite.Assert().Nil(result.Challenge)
+	tokens := result.Tokens
+	suite.Assert().NotEmpty(tokens.AccessToken)
+	suite.Assert().NotEmpty(tokens.RefreshToken)
+
+	// 設定した鍵で署名され、有効期限は TTL 後になる
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(tokens.AccessToken, claims, testTokenConfig.Keys.Keyfunc)
 	suite.Assert().Nil(err)
-	suite.Assert().NotEmpty(jwt)
+	suite.Assert().InDelta(time.Now().Add(testTokenConfig.TTL).Unix(), claims["exp"], 5)
+
+	// DB にはリフレッシュトークンそのものではなくハッシュを保存する
+	saved := mockRefreshTokenRepository.Calls[0].Arguments.Get(0).(*entity.RefreshToken)
+	suite.Assert().Equal(1, saved.UserID)
+	suite.Assert().NotEmpty(saved.FamilyID)
+	suite.Assert().Equal(security.HashToken(tokens.RefreshToken), saved.TokenHash)
+	suite.Assert().Equal(tokens.RefreshTokenExpiresAt, saved.ExpiresAt)
+
+	// セッション ID は jti とリフレッシュトークンの family に使う
+	session := mockSessionRepository.Calls[0].Arguments.Get(0).(*entity.Session)
+	suite.Assert().Equal(saved.FamilyID, session.ID)
+	suite.Assert().Equal(session.ID, claims["jti"])
+	suite.Assert().Equal(1, session.UserID)
+	suite.Assert().Equal("192.0.2.1", session.IPAddress)
+	suite.Assert().Len(session.UserAgent, maxUserAgentLength)
+	suite.Assert().Equal(saved.ExpiresAt, session.ExpiresAt)
+}
+
+func (suite *UserUseCaseSuite) TestLoginInvalidCredentials() {
+	email := "test@example.com"
+	hashedPassword, _ := testPasswordHasher.Hash("password123")
+	mockUserRepository := NewMockUserRepository()
+	suite.userUseCase = NewUserUseCase(mockUserRepository, NewMockRefreshTokenRepository(), NewMockSessionRepository(), NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(), NewMockEmailVerificationUseCase(), NewMockTwoFactorUseCase(), newPassingLoginThrottle(), testPasswordHasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
+
+	mockUserRepository.On("FindByEmail", email).Return(&entity.User{
+		ID:       1,
+		Email:    email,
+		Password: hashedPassword,
+	}, nil)
+	mockUserRepository.On("FindByEmail", "missing@example.com").Return(nil, apperror.NewNotFound("user not found"))
+	mockUserRepository.On("FindByEmail", "broken@example.com").Return(nil, errors.New("connection refused"))
+
+	_, err := suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: email, Password: "wrong"}, ClientInfo{})
+	suite.Assert().ErrorIs(err, ErrInvalidCredentials)
+	suite.Assert().True(apperror.IsUnauthorized(err))
+
+	_, err = suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: "missing@example.com", Password: "password123"}, ClientInfo{})
+	suite.Assert().ErrorIs(err, ErrInvalidCredentials)
+
+	// DB の障害は認証失敗として扱わない
+	_, err = suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: "broken@example.com", Password: "password123"}, ClientInfo{})
+	suite.Assert().False(apperror.IsUnauthorized(err))
+	suite.Assert().EqualError(err, "connection refused")
+}
+
+// recordingPasswordHasher は検証したハッシュを記録する
+type recordingPasswordHasher struct {
+	security.PasswordHasher
+	verified []string
+}
+
+func (h *recordingPasswordHasher) Verify(password, encoded string) (bool, error) {
+	h.verified = append(h.verified, encoded)
+	return h.PasswordHasher.Verify(password, encoded)
+}
+
+func (suite *UserUseCaseSuite) TestLoginVerifiesDummyPassword() {
+	hasher := &recordingPasswordHasher{PasswordHasher: testPasswordHasher}
+	mockUserRepository := NewMockUserRepository()
+	suite.userUseCase = NewUserUseCase(mockUserRepository, NewMockRefreshTokenRepository(), NewMockSessionRepository(), NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(),
+		NewMockEmailVerificationUseCase(), NewMockTwoFactorUseCase(), newPassingLoginThrottle(), hasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
+
+	mockUserRepository.On("FindByEmail", "missing@example.com").Return(nil, apperror.NewNotFound("user not found"))
+	// OpenID Connect だけで登録したユーザー
+	mockUserRepository.On("FindByEmail", "oidc@example.com").Return(&entity.User{ID: 2, Email: "oidc@example.com"}, nil)
+
+	// 登録済みのアドレスと同じく、設定どおりのハッシュでパスワードを検証してから失敗させる
+	for _, email := range []string{"missing@example.com", "oidc@example.com"} {
+		_, err := suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: email, Password: "password123"}, ClientInfo{})
+		suite.Assert().ErrorIs(err, ErrInvalidCredentials, email)
+	}
+	suite.Require().Len(hasher.verified, 2)
+	suite.Assert().NotEmpty(hasher.verified[0])
+	suite.Assert().False(testPasswordHasher.NeedsRehash(hasher.verified[0]))
+	// ダミーのハッシュは一度だけ作る
+	suite.Assert().Equal(hasher.verified[0], hasher.verified[1])
+}
+
+func (suite *UserUseCaseSuite) TestLoginRehashesPassword() {
+	// bcrypt で保存されたユーザーを、argon2id に移行する設定でログインさせる
+	oldHash, _ := testPasswordHasher.Hash("password123")
+	hasher := security.NewArgon2idHasher(security.Argon2idParams{Memory: 64, Time: 1, Threads: 1})
+	mockUserRepository := NewMockUserRepository()
+	mockSessionRepository := NewMockSessionRepository()
+	mockRefreshTokenRepository := NewMockRefreshTokenRepository()
+	mockTwoFactorUseCase := NewMockTwoFactorUseCase()
+	suite.userUseCase = NewUserUseCase(mockUserRepository, mockRefreshTokenRepository, mockSessionRepository, NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(),
+		NewMockEmailVerificationUseCase(), mockTwoFactorUseCase, newPassingLoginThrottle(), hasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
+
+	mockUserRepository.On("FindByEmail", "test@example.com").Return(&entity.User{ID: 1, Email: "test@example.com", Password: oldHash}, nil)
+	mockUserRepository.On("UpdatePasswordHash", 1, oldHash, mock.AnythingOfType("string")).Return(nil)
+	mockRefreshTokenRepository.On("Create", mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
+	mockSessionRepository.On("Create", mock.AnythingOfType("*entity.Session")).Return(nil)
+	mockTwoFactorUseCase.On("IsEnabled", 1).Return(false, nil)
+
+	// 間違ったパスワードでは作り直さない
+	_, err := suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: "test@example.com", Password: "wrong"}, ClientInfo{})
+	suite.Assert().ErrorIs(err, ErrInvalidCredentials)
+	mockUserRepository.AssertNotCalled(suite.T(), "UpdatePasswordHash", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err = suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: "test@example.com", Password: "password123"}, ClientInfo{})
+	suite.Assert().Nil(err)
+	newHash := mockUserRepository.Calls[len(mockUserRepository.Calls)-1].Arguments.String(2)
+	suite.Assert().True(strings.HasPrefix(newHash, "$argon2id$"))
+	ok, err := hasher.Verify("password123", newHash)
+	suite.Assert().Nil(err)
+	suite.Assert().True(ok)
+	suite.Assert().False(hasher.NeedsRehash(newHash))
+}
+
+func (suite *UserUseCaseSuite) TestLoginRehashFailure() {
+	oldHash, _ := security.NewBcryptHasher(bcrypt.MinCost + 1).Hash("password123")
+	mockUserRepository := NewMockUserRepository()
+	mockSessionRepository := NewMockSessionRepository()
+	mockRefreshTokenRepository := NewMockRefreshTokenRepository()
+	mockTwoFactorUseCase := NewMockTwoFactorUseCase()
+	suite.userUseCase = NewUserUseCase(mockUserRepository, mockRefreshTokenRepository, mockSessionRepository, NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(),
+		NewMockEmailVerificationUseCase(), mockTwoFactorUseCase, newPassingLoginThrottle(), testPasswordHasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
+
+	mockUserRepository.On("FindByEmail", "test@example.com").Return(&entity.User{ID: 1, Email: "test@example.com", Password: oldHash}, nil)
+	mockUserRepository.On("UpdatePasswordHash", 1, oldHash, mock.AnythingOfType("string")).Return(errors.New("connection refused"))
+	mockRefreshTokenRepository.On("Create", mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
+	mockSessionRepository.On("Create", mock.AnythingOfType("*entity.Session")).Return(nil)
+	mockTwoFactorUseCase.On("IsEnabled", 1).Return(false, nil)
+
+	// コストが変わっただけでも作り直し、保存に失敗してもログインは成功させる
+	result, err := suite.userUseCase.Login(context.Background(), &entity.Credentials{Email: "test@example.com", Password: "password123"}, ClientInfo{})
+	suite.Assert().Nil(err)
+	suite.Assert().NotNil(result.Tokens)
+	mockUserRepository.AssertCalled(suite.T(), "UpdatePasswordHash", 1, oldHash, mock.AnythingOfType("string"))
+}
+
+// newSessionUseCase は時刻を now に固定し、トークン・セッションのリポジトリをモックにする
+func (suite *UserUseCaseSuite) newSessionUseCase(now time.Time) (*mockRefreshTokenRepository, *mockSessionRepository) {
+	mockRefreshTokenRepository := NewMockRefreshTokenRepository()
+	mockSessionRepository := NewMockSessionRepository()
+	suite.userUseCase = NewUserUseCase(NewMockUserRepository(), mockRefreshTokenRepository, mockSessionRepository, NewMockPersonalAccessTokenRepository(), NewMockLoginChallengeRepository(), NewMockEmailVerificationUseCase(), NewMockTwoFactorUseCase(), newPassingLoginThrottle(), testPasswordHasher, testTokenConfig, testVerificationPolicy, testPasswordPolicy, mailer.NewLogMailer("no-reply@example.com"), testEmailChangeConfig)
+	suite.userUseCase.clock = tester.NewMockClock(now)
+	return mockRefreshTokenRepository, mockSessionRepository
+}
+
+func (suite *UserUseCaseSuite) TestRefresh() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRefreshTokenRepository, mockSessionRepository := suite.newSessionUseCase(now)
+	current := &entity.RefreshToken{ID: 1, UserID: 7, FamilyID: "family", ExpiresAt: now.Add(time.Hour)}
+	mockRefreshTokenRepository.On("FindByHash", security.HashToken("current")).Return(current, nil)
+	mockRefreshTokenRepository.On("Rotate", current, mock.AnythingOfType("*entity.RefreshToken"), now).Return(nil)
+	mockSessionRepository.On("FindByID", "family").Return(&entity.Session{ID: "family", UserID: 7, IPAddress: "192.0.2.1", ExpiresAt: now.Add(time.Hour)}, nil)
+	mockSessionRepository.On("Renew", mock.AnythingOfType("*entity.Session")).Return(nil)
+
+	tokens, err := suite.userUseCase.Refresh(context.Background(), "current", ClientInfo{UserAgent: "curl/8.0", IPAddress: "198.51.100.1"})
+	suite.Assert().Nil(err)
+	suite.Assert().NotEqual("current", tokens.RefreshToken)
+	suite.Assert().Equal(now.Add(testTokenConfig.TTL), tokens.AccessTokenExpiresAt)
+
+	// 新しいトークンは同じ family に属する
+	next := mockRefreshTokenRepository.Calls[1].Arguments.Get(1).(*entity.RefreshToken)
+	suite.Assert().Equal(7, next.UserID)
+	suite.Assert().Equal("family", next.FamilyID)
+	suite.Assert().Equal(security.HashToken(tokens.RefreshToken), next.TokenHash)
+	suite.Assert().Equal(now.Add(testTokenConfig.RefreshTTL), next.ExpiresAt)
+
+	// セッションの接続元と有効期限を更新する
+	session := mockSessionRepository.Calls[1].Arguments.Get(0).(*entity.Session)
+	suite.Assert().Equal("198.51.100.1", session.IPAddress)
+	suite.Assert().Equal("curl/8.0", session.UserAgent)
+	suite.Assert().Equal(now, session.LastSeenAt)
+	suite.Assert().Equal(next.ExpiresAt, session.ExpiresAt)
+}
+
+func (suite *UserUseCaseSuite) TestRefreshReuseRevokesSession() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	usedAt := now.Add(-time.Minute)
+	mockRefreshTokenRepository, mockSessionRepository := suite.newSessionUseCase(now)
+	mockRefreshTokenRepository.On("FindByHash", security.HashToken("used")).Return(&entity.RefreshToken{
+		ID: 1, UserID: 7, FamilyID: "family", ExpiresAt: now.Add(time.Hour), UsedAt: &usedAt,
+	}, nil)
+	mockRefreshTokenRepository.On("RevokeFamily", "family", now).Return(nil)
+	mockSessionRepository.On("Revoke", 7, "family", now).Return(nil)
+
+	_, err := suite.userUseCase.Refresh(context.Background(), "used", ClientInfo{})
+	suite.Assert().ErrorIs(err, ErrRefreshTokenReused)
+	suite.Assert().True(apperror.IsUnauthorized(err))
+	mockRefreshTokenRepository.AssertCalled(suite.T(), "RevokeFamily", "family", now)
+	mockSessionRepository.AssertCalled(suite.T(), "Revoke", 7, "family", now)
+
+	// 同時に使われてローテーションに失敗した場合も同じ扱いにする
+	current := &entity.RefreshToken{ID: 2, UserID: 7, FamilyID: "family2", ExpiresAt: now.Add(time.Hour)}
+	mockRefreshTokenRepository.On("FindByHash", security.HashToken("raced")).Return(current, nil)
+	mockRefreshTokenRepository.On("Rotate", current, mock.Anything, now).Return(gateway.ErrRefreshTokenAlreadyUsed)
+	mockRefreshTokenRepository.On("RevokeFamily", "family2", now).Return(nil)
+	mockSessionRepository.On("FindByID", "family2").Return(&entity.Session{ID: "family2", UserID: 7, ExpiresAt: now.Add(time.Hour)}, nil)
+	mockSessionRepository.On("Renew", mock.Anything).Return(nil)
+	mockSessionRepository.On("Revoke", 7, "family2", now).Return(apperror.NewNotFound("session not found"))
+
+	_, err = suite.userUseCase.Refresh(context.Background(), "raced", ClientInfo{})
+	suite.Assert().ErrorIs(err, ErrRefreshTokenReused)
+	mockRefreshTokenRepository.AssertCalled(suite.T(), "RevokeFamily", "family2", now)
+}
+
+func (suite *UserUseCaseSuite) TestRefreshInvalid() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	revokedAt := now.Add(-time.Minute)
+	mockRefreshTokenRepository, mockSessionRepository := suite.newSessionUseCase(now)
+	mockRefreshTokenRepository.On("FindByHash", security.HashToken("unknown")).Return(nil, apperror.NewNotFound("refresh token not found"))
+	mockRefreshTokenRepository.On("FindByHash", security.HashToken("expired")).Return(&entity.RefreshToken{ID: 1, ExpiresAt: now}, nil)
+	mockRefreshTokenRepository.On("FindByHash", security.HashToken("revoked")).Return(&entity.RefreshToken{ID: 2, ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}, nil)
+	// セッションが失効・削除されたトークン
+	mockRefreshTokenRepository.On("FindByHash", security.HashToken("revoked-session")).Return(&entity.RefreshToken{ID: 3, FamilyID: "revoked", ExpiresAt: now.Add(time.Hour)}, nil)
+	mockRefreshTokenRepository.On("FindByHash", security.HashToken("no-session")).Return(&entity.RefreshToken{ID: 4, FamilyID: "missing", ExpiresAt: now.Add(time.Hour)}, nil)
+	mockSessionRepository.On("FindByID", "revoked").Return(&entity.Session{ID: "revoked", ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}, nil)
+	mockSessionRepository.On("FindByID", "missing").Return(nil, apperror.NewNotFound("session not found"))
+
+	for _, token := range []string{"unknown", "expired", "revoked", "revoked-session", "no-session"} {
+		_, err := suite.userUseCase.Refresh(context.Background(), token, ClientInfo{})
+		suite.Assert().ErrorIs(err, ErrInvalidRefreshToken, token)
+	}
+	mockRefreshTokenRepository.AssertNotCalled(suite.T(), "Rotate", mock.Anything, mock.Anything, mock.Anything)
+	mockRefreshTokenRepository.AssertNotCalled(suite.T(), "RevokeFamily", mock.Anything, mock.Anything)
+}
+
+func (suite *UserUseCaseSuite) TestLogout() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRefreshTokenRepository, mockSessionRepository := suite.newSessionUseCase(now)
+	mockRefreshTokenRepository.On("FindByHash", security.HashToken("current")).Return(&entity.RefreshToken{ID: 1, UserID: 7, FamilyID: "family"}, nil)
+	mockRefreshTokenRepository.On("FindByHash", security.HashToken("unknown")).Return(nil, apperror.NewNotFound("refresh token not found"))
+	mockRefreshTokenRepository.On("RevokeFamily", "family", now).Return(nil)
+	mockSessionRepository.On("Revoke", 7, "family", now).Return(nil)
+
+	suite.Assert().Nil(suite.userUseCase.Logout(context.Background(), "current"))
+	mockRefreshTokenRepository.AssertCalled(suite.T(), "RevokeFamily", "family", now)
+	mockSessionRepository.AssertCalled(suite.T(), "Revoke", 7, "family", now)
+
+	// 不明なトークンや Cookie がない場合もログアウトは成功させる
+	suite.Assert().Nil(suite.userUseCase.Logout(context.Background(), "unknown"))
+	suite.Assert().Nil(suite.userUseCase.Logout(context.Background(), ""))
+}
+
+func (suite *UserUseCaseSuite) TestValidateSession() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	revokedAt := now.Add(-time.Hour)
+	_, mockSessionRepository := suite.newSessionUseCase(now)
+	mockSessionRepository.On("FindByID", "fresh").Return(&entity.Session{ID: "fresh", UserID: 7, LastSeenAt: now.Add(-time.Second), ExpiresAt: now.Add(time.Hour)}, nil)
+	mockSessionRepository.On("FindByID", "stale").Return(&entity.Session{ID: "stale", UserID: 7, LastSeenAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)}, nil)
+	mockSessionRepository.On("FindByID", "revoked").Return(&entity.Session{ID: "revoked", UserID: 7, ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}, nil)
+	mockSessionRepository.On("FindByID", "missing").Return(nil, apperror.NewNotFound("session not found"))
+	mockSessionRepository.On("Touch", "stale", now).Return(errors.New("connection refused"))
+
+	suite.Assert().Nil(suite.userUseCase.ValidateSession(context.Background(), 7, "fresh"))
+	mockSessionRepository.AssertNotCalled(suite.T(), "Touch", "fresh", mock.Anything)
+
+	// 最終利用日時の更新に失敗してもリクエストは通す
+	suite.Assert().Nil(suite.userUseCase.ValidateSession(context.Background(), 7, "stale"))
+	mockSessionRepository.AssertCalled(suite.T(), "Touch", "stale", now)
+
+	for _, tc := range []struct {
+		userID    int
+		sessionID string
+	}{{7, "revoked"}, {7, "missing"}, {8, "fresh"}} {
+		err := suite.userUseCase.ValidateSession(context.Background(), tc.userID, tc.sessionID)
+		suite.Assert().ErrorIs(err, ErrSessionRevoked, tc.sessionID)
+	}
+}
+
+func (suite *UserUseCaseSuite) TestRevokeSessions() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRefreshTokenRepository, mockSessionRepository := suite.newSessionUseCase(now)
+	mockSessionRepository.On("ListActive", 7, now).Return([]*entity.Session{{ID: "current"}, {ID: "phone"}, {ID: "laptop"}}, nil)
+	mockSessionRepository.On("Revoke", 7, "other-user", now).Return(apperror.NewNotFound("session not found"))
+	mockSessionRepository.On("Revoke", 7, mock.Anything, now).Return(nil)
+	mockRefreshTokenRepository.On("RevokeFamily", mock.Anything, now).Return(nil)
+
+	// 他のユーザーのセッションは失効させられない
+	err := suite.userUseCase.RevokeSession(sessionContext(7, "current"), "other-user")
+	suite.Assert().True(apperror.IsNotFound(err))
+	mockRefreshTokenRepository.AssertNotCalled(suite.T(), "RevokeFamily", "other-user", now)
+
+	suite.Assert().Nil(suite.userUseCase.RevokeSession(sessionContext(7, "current"), "phone"))
+	mockRefreshTokenRepository.AssertCalled(suite.T(), "RevokeFamily", "phone", now)
+
+	// 他の端末からログアウトしても、リクエストしたセッションは残す
+	suite.Assert().Nil(suite.userUseCase.RevokeOtherSessions(sessionContext(7, "current")))
+	mockSessionRepository.AssertCalled(suite.T(), "Revoke", 7, "laptop", now)
+	mockRefreshTokenRepository.AssertCalled(suite.T(), "RevokeFamily", "laptop", now)
+	mockSessionRepository.AssertNotCalled(suite.T(), "Revoke", 7, "current", now)
 }